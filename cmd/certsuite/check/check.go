@@ -2,6 +2,8 @@ package check
 
 import (
 	imagecert "github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/check/image_cert_status"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/check/permissions"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/check/policy"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/check/results"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +18,8 @@ var (
 func NewCommand() *cobra.Command {
 	checkCmd.AddCommand(imagecert.NewCommand())
 	checkCmd.AddCommand(results.NewCommand())
+	checkCmd.AddCommand(permissions.NewCommand())
+	checkCmd.AddCommand(policy.NewCommand())
 
 	return checkCmd
 }
@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
+	"github.com/spf13/cobra"
+)
+
+var checkPermissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Verifies that the current identity has the API permissions required by the selected checks",
+	RunE:  checkPermissions,
+}
+
+func checkPermissions(cmd *cobra.Command, _ []string) error {
+	labelsFilter, _ := cmd.Flags().GetString("label-filter")
+	configFile, _ := cmd.Flags().GetString("config-file")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	if err := checksdb.InitLabelsExprEvaluator(labelsFilter); err != nil {
+		return fmt.Errorf("failed to initialize the test case label evaluator, err: %v", err)
+	}
+
+	_ = clientsholder.GetClientsHolder(clientsholder.GetKubeconfigFileNames(kubeconfig)...)
+	certsuite.LoadChecksDB(labelsFilter)
+
+	missingByCheck, err := certsuite.GetChecksMissingPermissions(configFile)
+	if err != nil {
+		return err
+	}
+
+	if len(missingByCheck) == 0 {
+		fmt.Printf("Result: %s\n", color.GreenString("All the permissions required by the checks are granted"))
+		return nil
+	}
+
+	for _, checkID := range certsuite.GetSortedCheckIDs(missingByCheck) {
+		fmt.Printf("%s\n", checkID)
+		for _, perm := range missingByCheck[checkID] {
+			fmt.Printf("  - %s\n", perm.String())
+		}
+	}
+	fmt.Printf("Result: %s\n", color.RedString("Missing permissions for %d check(s)", len(missingByCheck)))
+
+	return fmt.Errorf("missing permissions: %s", permissions.ToString(getAllMissing(missingByCheck)))
+}
+
+// getAllMissing returns the missing permissions of all the checks, without duplicates.
+func getAllMissing(missingByCheck map[string][]permissions.Permission) []permissions.Permission {
+	seen := map[permissions.Permission]bool{}
+	all := []permissions.Permission{}
+	for _, missing := range missingByCheck {
+		for _, perm := range missing {
+			if !seen[perm] {
+				seen[perm] = true
+				all = append(all, perm)
+			}
+		}
+	}
+	return all
+}
+
+func NewCommand() *cobra.Command {
+	checkPermissionsCmd.Flags().StringP("label-filter", "l", "all", "Label expression to select the checks whose permissions are verified")
+	checkPermissionsCmd.Flags().StringP("config-file", "c", "config/tnf_config.yml", "The workload configuration file")
+	checkPermissionsCmd.Flags().StringP("kubeconfig", "k", "", "The target cluster's Kubeconfig file")
+
+	return checkPermissionsCmd
+}
@@ -0,0 +1,422 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Types of the policy rules.
+	RuleAllPass       = "allPass"
+	RuleNoNewFailures = "noNewFailures"
+	RuleMinScore      = "minScore"
+
+	maxScore = 100
+)
+
+var (
+	RuleTypes       = []string{RuleAllPass, RuleNoNewFailures, RuleMinScore}
+	Scenarios       = []string{identifiers.Telco, identifiers.NonTelco, identifiers.FarEdge, identifiers.Extended}
+	Classifications = []string{identifiers.Mandatory, identifiers.Optional}
+)
+
+// Rule is a policy rule. The test cases it applies to are selected by suite, tags and category classification
+// for a scenario. All the test cases are selected if none of them is set.
+type Rule struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+
+	// Test cases selection.
+	Suites         []string `yaml:"suites,omitempty"`
+	Tags           []string `yaml:"tags,omitempty"`
+	Scenario       string   `yaml:"scenario,omitempty"`
+	Classification string   `yaml:"classification,omitempty"`
+
+	// allPass rules: whether the selected test cases can be skipped, or missing in the claim file.
+	AllowSkipped bool `yaml:"allowSkipped,omitempty"`
+	AllowMissing bool `yaml:"allowMissing,omitempty"`
+	// noNewFailures rules: claim file the failures are compared with.
+	Baseline string `yaml:"baseline,omitempty"`
+	// minScore rules: minimum percentage of passed test cases, skipped test cases are not counted.
+	MinScore float64 `yaml:"minScore,omitempty"`
+}
+
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// RuleResult is the evaluation of a rule: a summary and, if the rule failed, the test cases that made it fail.
+type RuleResult struct {
+	Rule       *Rule
+	Passed     bool
+	Summary    string
+	Violations []string
+}
+
+// testCaseInfo has the catalog fields the test cases are selected by.
+type testCaseInfo struct {
+	suite          string
+	tags           []string
+	classification map[string]string
+}
+
+var (
+	policyFileFlag string
+
+	checkPolicyCmd = &cobra.Command{
+		Use:   "policy <claim-file>",
+		Short: "Verifies that the results of a claim file comply with the rules of a policy",
+		Long: `Evaluates the rules of a policy YAML file against the results of a claim file, prints the result of every rule
+and exits with a non-zero code if any of them failed. The rule types are:
+ - allPass: all the selected test cases must pass.
+ - noNewFailures: none of the selected test cases can fail unless it also failed in a baseline claim file.
+ - minScore: the percentage of the selected test cases that passed, not counting the skipped ones, must be at
+   least minScore.
+
+The test cases of a rule are selected by suites, tags and category classification for a scenario, e.g. the Mandatory
+test cases of the FarEdge scenario, using the test cases catalog.`,
+		Example: `./certsuite check policy --policy gate.yaml claim.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    checkPolicy,
+	}
+)
+
+func checkPolicy(_ *cobra.Command, args []string) error {
+	policy, err := LoadPolicy(policyFileFlag)
+	if err != nil {
+		return err
+	}
+
+	claimScheme, err := parseClaim(args[0])
+	if err != nil {
+		return err
+	}
+
+	baselines := map[string]claim.TestSuiteResults{}
+	for i := range policy.Rules {
+		baseline := policy.Rules[i].Baseline
+		if baseline == "" || baselines[baseline] != nil {
+			continue
+		}
+		baselineClaim, err := parseClaim(baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load the baseline claim of rule %q: %v", policy.Rules[i].Name, err)
+		}
+		baselines[baseline] = baselineClaim.Claim.Results
+	}
+
+	results := evaluate(policy, claimScheme.Claim.Results, getCatalogInfo(), baselines)
+
+	failed := 0
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("%-6s%s: %s\n", status, result.Rule.Name, result.Summary)
+		for _, violation := range result.Violations {
+			fmt.Printf("        %s\n", violation)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d policy rules failed\n", failed, len(results))
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nAll the %d policy rules passed\n", len(results))
+	return nil
+}
+
+func parseClaim(claimFile string) (*claim.Schema, error) {
+	claimScheme, err := claim.Parse(claimFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse claim file %s: %v", claimFile, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return nil, err
+	}
+	return claimScheme, nil
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPolicy reads and validates a policy file. The classification of the rules with a scenario is Mandatory
+// by default.
+func LoadPolicy(policyFile string) (*Policy, error) {
+	content, err := os.ReadFile(policyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %v", policyFile, err)
+	}
+
+	policy := Policy{}
+	if err := yaml.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %v", policyFile, err)
+	}
+
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("policy file %s has no rules", policyFile)
+	}
+
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d (%s)", i+1, rule.Type)
+		}
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %v", rule.Name, err)
+		}
+	}
+
+	return &policy, nil
+}
+
+func validateRule(rule *Rule) error {
+	if !contains(RuleTypes, rule.Type) {
+		return fmt.Errorf("unknown type %q - available types: %v", rule.Type, RuleTypes)
+	}
+
+	if rule.Scenario != "" {
+		if !contains(Scenarios, rule.Scenario) {
+			return fmt.Errorf("unknown scenario %q - available scenarios: %v", rule.Scenario, Scenarios)
+		}
+		if rule.Classification == "" {
+			rule.Classification = identifiers.Mandatory
+		}
+	}
+	if rule.Classification != "" {
+		if rule.Scenario == "" {
+			return errors.New("the classification requires a scenario")
+		}
+		if !contains(Classifications, rule.Classification) {
+			return fmt.Errorf("unknown classification %q - available classifications: %v", rule.Classification, Classifications)
+		}
+	}
+
+	switch rule.Type {
+	case RuleNoNewFailures:
+		if rule.Baseline == "" {
+			return errors.New("the baseline claim file is required")
+		}
+	case RuleMinScore:
+		if rule.MinScore <= 0 || rule.MinScore > maxScore {
+			return fmt.Errorf("the minimum score must be between 0 and %d", maxScore)
+		}
+	}
+
+	return nil
+}
+
+// getCatalogInfo returns the suite, tags and classification of the test cases of the catalog, by test case id.
+func getCatalogInfo() map[string]testCaseInfo {
+	catalog := map[string]testCaseInfo{}
+	for id, description := range identifiers.Catalog {
+		catalog[id.Id] = testCaseInfo{
+			suite:          id.Suite,
+			tags:           strings.Split(description.Tags, ","),
+			classification: identifiers.Classification[id.Id],
+		}
+	}
+	return catalog
+}
+
+func (rule *Rule) selects(info *testCaseInfo) bool {
+	if len(rule.Suites) > 0 && !contains(rule.Suites, info.suite) {
+		return false
+	}
+
+	if len(rule.Tags) > 0 {
+		found := false
+		for _, tag := range info.tags {
+			if contains(rule.Tags, strings.TrimSpace(tag)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return rule.Scenario == "" || info.classification[rule.Scenario] == rule.Classification
+}
+
+// getSelectedTestCases returns the sorted ids of the test cases, in the catalog or in the claim file, selected
+// by the rule. The catalog fields of the test cases that are not in the catalog are taken from the claim file.
+func getSelectedTestCases(rule *Rule, results claim.TestSuiteResults, catalog map[string]testCaseInfo) []string {
+	infos := map[string]testCaseInfo{}
+	for id, info := range catalog {
+		infos[id] = info
+	}
+	for id := range results {
+		if _, found := infos[id]; !found {
+			result := results[id]
+			infos[id] = testCaseInfo{
+				suite:          result.TestID.Suite,
+				tags:           strings.Split(result.TestID.Tags, ","),
+				classification: result.CategoryClassification,
+			}
+		}
+	}
+
+	selected := []string{}
+	for id, info := range infos {
+		if rule.selects(&info) {
+			selected = append(selected, id)
+		}
+	}
+	sort.Strings(selected)
+	return selected
+}
+
+func isFailure(state string) bool {
+	return state == claim.TestCaseResultFailed || state == "error"
+}
+
+// evaluate evaluates the rules of the policy against the results of a claim file. The baselines are the results
+// of the baseline claim files of the rules, by file name.
+func evaluate(policy *Policy, results claim.TestSuiteResults, catalog map[string]testCaseInfo, baselines map[string]claim.TestSuiteResults) []RuleResult {
+	ruleResults := []RuleResult{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		selected := getSelectedTestCases(rule, results, catalog)
+
+		var ruleResult RuleResult
+		switch rule.Type {
+		case RuleAllPass:
+			ruleResult = evaluateAllPass(rule, selected, results)
+		case RuleNoNewFailures:
+			ruleResult = evaluateNoNewFailures(rule, selected, results, baselines[rule.Baseline])
+		case RuleMinScore:
+			ruleResult = evaluateMinScore(rule, selected, results)
+		}
+		ruleResult.Rule = rule
+		ruleResults = append(ruleResults, ruleResult)
+	}
+	return ruleResults
+}
+
+func evaluateAllPass(rule *Rule, selected []string, results claim.TestSuiteResults) RuleResult {
+	ruleResult := RuleResult{}
+	passed, total := 0, 0
+	for _, id := range selected {
+		result, found := results[id]
+		switch {
+		case !found && rule.AllowMissing:
+			continue
+		case !found:
+			ruleResult.Violations = append(ruleResult.Violations, id+": not run")
+		case result.State == claim.TestCaseResultPassed:
+			passed++
+		case result.State == claim.TestCaseResultSkipped && rule.AllowSkipped:
+		default:
+			ruleResult.Violations = append(ruleResult.Violations, id+": "+result.State)
+		}
+		total++
+	}
+
+	ruleResult.Passed = len(ruleResult.Violations) == 0
+	ruleResult.Summary = fmt.Sprintf("%d/%d test cases passed", passed, total)
+	return ruleResult
+}
+
+func evaluateNoNewFailures(rule *Rule, selected []string, results, baseline claim.TestSuiteResults) RuleResult {
+	ruleResult := RuleResult{}
+	for _, id := range selected {
+		result, found := results[id]
+		if !found || !isFailure(result.State) {
+			continue
+		}
+
+		baselineState := "missing"
+		if baselineResult, found := baseline[id]; found {
+			baselineState = baselineResult.State
+		}
+		if !isFailure(baselineState) {
+			ruleResult.Violations = append(ruleResult.Violations, fmt.Sprintf("%s: %s (baseline: %s)", id, result.State, baselineState))
+		}
+	}
+
+	ruleResult.Passed = len(ruleResult.Violations) == 0
+	ruleResult.Summary = fmt.Sprintf("%d new failures compared to %s", len(ruleResult.Violations), rule.Baseline)
+	return ruleResult
+}
+
+func evaluateMinScore(rule *Rule, selected []string, results claim.TestSuiteResults) RuleResult {
+	ruleResult := RuleResult{}
+	passed, run := 0, 0
+	for _, id := range selected {
+		result, found := results[id]
+		if !found || result.State == claim.TestCaseResultSkipped {
+			continue
+		}
+		run++
+		if result.State == claim.TestCaseResultPassed {
+			passed++
+		} else {
+			ruleResult.Violations = append(ruleResult.Violations, id+": "+result.State)
+		}
+	}
+
+	if run == 0 {
+		ruleResult.Summary = "none of the selected test cases was run"
+		return ruleResult
+	}
+
+	score := float64(passed) * maxScore / float64(run)
+	ruleResult.Passed = score >= rule.MinScore
+	ruleResult.Summary = fmt.Sprintf("score %.1f, minimum %.1f (%d/%d test cases passed)", score, rule.MinScore, passed, run)
+	if ruleResult.Passed {
+		ruleResult.Violations = nil
+	}
+	return ruleResult
+}
+
+func NewCommand() *cobra.Command {
+	checkPolicyCmd.Flags().StringVarP(&policyFileFlag, "policy", "p", "",
+		"Required: policy YAML file with the rules to evaluate.",
+	)
+
+	err := checkPolicyCmd.MarkFlagRequired("policy")
+	if err != nil {
+		log.Fatalf("Failed to mark policy file path as required parameter: %v", err)
+		return nil
+	}
+
+	return checkPolicyCmd
+}
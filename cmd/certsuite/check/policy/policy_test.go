@@ -0,0 +1,153 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCatalog = map[string]testCaseInfo{
+	"access-control-namespace": {suite: "access-control", tags: []string{"common"},
+		classification: map[string]string{identifiers.FarEdge: identifiers.Mandatory, identifiers.Telco: identifiers.Mandatory}},
+	"access-control-pod-host-network": {suite: "access-control", tags: []string{"common"},
+		classification: map[string]string{identifiers.FarEdge: identifiers.Mandatory, identifiers.Telco: identifiers.Optional}},
+	"lifecycle-pod-owner-type": {suite: "lifecycle", tags: []string{"telco"},
+		classification: map[string]string{identifiers.FarEdge: identifiers.Optional, identifiers.Telco: identifiers.Mandatory}},
+	"networking-icmpv4-connectivity": {suite: "networking", tags: []string{"common"},
+		classification: map[string]string{identifiers.FarEdge: identifiers.Mandatory, identifiers.Telco: identifiers.Mandatory}},
+}
+
+func newResults(states map[string]string) claim.TestSuiteResults {
+	results := claim.TestSuiteResults{}
+	for id, state := range states {
+		results[id] = claim.TestCaseResult{State: state}
+	}
+	return results
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "gate.yaml")
+	assert.Nil(t, os.WriteFile(policyFile, []byte(`rules:
+  - name: FarEdge mandatory
+    type: allPass
+    scenario: FarEdge
+  - type: minScore
+    minScore: 90
+`), 0o600))
+
+	policy, err := LoadPolicy(policyFile)
+	assert.Nil(t, err)
+	assert.Len(t, policy.Rules, 2)
+	assert.Equal(t, identifiers.Mandatory, policy.Rules[0].Classification)
+	assert.Equal(t, "rule 2 (minScore)", policy.Rules[1].Name)
+
+	invalidRules := []Rule{
+		{Type: "somePass"},
+		{Type: RuleAllPass, Scenario: "Edge"},
+		{Type: RuleAllPass, Classification: identifiers.Optional},
+		{Type: RuleAllPass, Scenario: identifiers.Telco, Classification: "Required"},
+		{Type: RuleNoNewFailures},
+		{Type: RuleMinScore, MinScore: 120},
+	}
+	for i := range invalidRules {
+		assert.NotNil(t, validateRule(&invalidRules[i]))
+	}
+}
+
+func TestEvaluateAllPass(t *testing.T) {
+	results := newResults(map[string]string{
+		"access-control-namespace":        claim.TestCaseResultPassed,
+		"access-control-pod-host-network": claim.TestCaseResultSkipped,
+		"lifecycle-pod-owner-type":        claim.TestCaseResultFailed,
+	})
+
+	policy := &Policy{Rules: []Rule{
+		{Name: "FarEdge", Type: RuleAllPass, Scenario: identifiers.FarEdge, Classification: identifiers.Mandatory},
+		{Name: "FarEdge skipped", Type: RuleAllPass, Scenario: identifiers.FarEdge, Classification: identifiers.Mandatory,
+			AllowSkipped: true, AllowMissing: true},
+		{Name: "telco", Type: RuleAllPass, Tags: []string{"telco"}},
+	}}
+
+	ruleResults := evaluate(policy, results, testCatalog, nil)
+	assert.Len(t, ruleResults, 3)
+	assert.False(t, ruleResults[0].Passed)
+	assert.Equal(t, "1/3 test cases passed", ruleResults[0].Summary)
+	assert.Equal(t, []string{"access-control-pod-host-network: skipped", "networking-icmpv4-connectivity: not run"}, ruleResults[0].Violations)
+	assert.True(t, ruleResults[1].Passed)
+	assert.Equal(t, "1/2 test cases passed", ruleResults[1].Summary)
+	assert.False(t, ruleResults[2].Passed)
+	assert.Equal(t, []string{"lifecycle-pod-owner-type: failed"}, ruleResults[2].Violations)
+}
+
+func TestEvaluateNoNewFailures(t *testing.T) {
+	results := newResults(map[string]string{
+		"access-control-namespace":        claim.TestCaseResultFailed,
+		"access-control-pod-host-network": claim.TestCaseResultFailed,
+		"lifecycle-pod-owner-type":        claim.TestCaseResultFailed,
+		// Not in the catalog, but in the claim file.
+		"custom-test": claim.TestCaseResultFailed,
+	})
+	baseline := newResults(map[string]string{
+		"access-control-namespace":        claim.TestCaseResultFailed,
+		"access-control-pod-host-network": claim.TestCaseResultPassed,
+	})
+
+	policy := &Policy{Rules: []Rule{
+		{Name: "no new failures", Type: RuleNoNewFailures, Baseline: "baseline.json"},
+		{Name: "no new access-control failures", Type: RuleNoNewFailures, Baseline: "baseline.json", Suites: []string{"access-control"}},
+	}}
+
+	ruleResults := evaluate(policy, results, testCatalog, map[string]claim.TestSuiteResults{"baseline.json": baseline})
+	assert.False(t, ruleResults[0].Passed)
+	assert.Equal(t, []string{
+		"access-control-pod-host-network: failed (baseline: passed)",
+		"custom-test: failed (baseline: missing)",
+		"lifecycle-pod-owner-type: failed (baseline: missing)",
+	}, ruleResults[0].Violations)
+	assert.False(t, ruleResults[1].Passed)
+	assert.Equal(t, "1 new failures compared to baseline.json", ruleResults[1].Summary)
+}
+
+func TestEvaluateMinScore(t *testing.T) {
+	results := newResults(map[string]string{
+		"access-control-namespace":        claim.TestCaseResultPassed,
+		"access-control-pod-host-network": claim.TestCaseResultSkipped,
+		"lifecycle-pod-owner-type":        claim.TestCaseResultFailed,
+		"networking-icmpv4-connectivity":  claim.TestCaseResultPassed,
+	})
+
+	policy := &Policy{Rules: []Rule{
+		{Name: "score", Type: RuleMinScore, MinScore: 60},
+		{Name: "telco score", Type: RuleMinScore, MinScore: 90, Scenario: identifiers.Telco, Classification: identifiers.Mandatory},
+		{Name: "operator score", Type: RuleMinScore, MinScore: 90, Suites: []string{"operator"}},
+	}}
+
+	ruleResults := evaluate(policy, results, testCatalog, nil)
+	assert.True(t, ruleResults[0].Passed)
+	assert.Equal(t, "score 66.7, minimum 60.0 (2/3 test cases passed)", ruleResults[0].Summary)
+	assert.Empty(t, ruleResults[0].Violations)
+	assert.False(t, ruleResults[1].Passed)
+	assert.Equal(t, []string{"lifecycle-pod-owner-type: failed"}, ruleResults[1].Violations)
+	// A rule without run test cases fails.
+	assert.False(t, ruleResults[2].Passed)
+}
@@ -3,13 +3,17 @@
 package results
 
 import (
-	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +21,8 @@ import (
 const (
 	TestResultsTemplateFileName        = "expected_results.yaml"
 	TestResultsTemplateFilePermissions = 0o644
+
+	claimFileName = "claim.json"
 )
 
 const (
@@ -24,31 +30,86 @@ const (
 	resultSkip = "SKIPPED"
 	resultFail = "FAILED"
 	resultMiss = "MISSING"
+	// Results of the checks that could not be completed, which have no expected state in the template.
+	resultError   = "ERROR"
+	resultAborted = "ABORTED"
 )
 
+// TestCaseList has the expected results of the test cases. The test case names can have "*" and "?" wildcards,
+// and the most specific entry that matches a test case is used. The test cases in AllowedSkip can be skipped or
+// have the result they have in the other lists, or passed if they're not in them.
 type TestCaseList struct {
-	Pass []string `yaml:"pass"`
-	Fail []string `yaml:"fail"`
-	Skip []string `yaml:"skip"`
+	Pass        []string `yaml:"pass"`
+	Fail        []string `yaml:"fail"`
+	Skip        []string `yaml:"skip"`
+	AllowedSkip []string `yaml:"allowedSkip,omitempty"`
+}
+
+// ExpectedObject matches the non-compliant objects of the type, if set, with all these fields. The field values
+// can have "*" and "?" wildcards.
+type ExpectedObject struct {
+	Type   string            `yaml:"type,omitempty"`
+	Fields map[string]string `yaml:"fields"`
+}
+
+// ObjectsExpectation is the exact set of non-compliant objects of the test cases that match TestCase, which can have
+// wildcards: every non-compliant object must match one of the expected objects, and every expected object must
+// match one of the non-compliant objects.
+type ObjectsExpectation struct {
+	TestCase     string           `yaml:"testCase"`
+	NonCompliant []ExpectedObject `yaml:"nonCompliant"`
 }
 
 type TestResults struct {
 	TestCaseList `yaml:"testCases"`
+	Objects      []ObjectsExpectation `yaml:"objects,omitempty"`
+}
+
+// ActualResult is the result of a test case in the claim file, with its non-compliant objects.
+type ActualResult struct {
+	Result       string
+	NonCompliant []*testhelper.ReportObject
+}
+
+// expectedResult is the expected result of the test cases that match a template entry.
+type expectedResult struct {
+	pattern string
+	regex   *regexp.Regexp
+	result  string
+}
+
+// ObjectMismatch is a non-compliant object of a test case that was not expected, or an expected non-compliant
+// object that was not found.
+type ObjectMismatch struct {
+	TestCase string
+	Problem  string
+	Object   string
 }
 
 var checkResultsCmd = &cobra.Command{
 	Use:   "results",
 	Short: "Verifies that the actual CERTSUITE results match the ones found in a reference template",
-	RunE:  checkResults,
+	Long: `Verifies that the results of the test cases in a claim file match the ones in a reference YAML template.
+
+The template lists the test cases expected to pass, fail or be skipped. The test case names can have "*" and "?"
+wildcards, and the most specific entry matching a test case sets its expected result. The test cases under
+"allowedSkip" can also be skipped. The template can also set the exact list of non-compliant objects of some test
+cases, under "objects".`,
+	RunE: checkResults,
 }
 
 func checkResults(cmd *cobra.Command, _ []string) error {
 	templateFileName, _ := cmd.Flags().GetString("template")
 	generateTemplate, _ := cmd.Flags().GetBool("generate-template")
-	logFileName, _ := cmd.Flags().GetString("log-file")
+	claimFile, _ := cmd.Flags().GetString("claim-file")
+	if cmd.Flags().Changed("log-file") && !cmd.Flags().Changed("claim-file") {
+		// The claim file is in the same output folder as the log file.
+		logFileName, _ := cmd.Flags().GetString("log-file")
+		claimFile = filepath.Join(filepath.Dir(logFileName), claimFileName)
+	}
 
-	// Build a database with the test results from the log file
-	actualTestResults, err := getTestResultsDB(logFileName)
+	// Build a database with the test results from the claim file
+	actualTestResults, err := getTestResultsDB(claimFile)
 	if err != nil {
 		return fmt.Errorf("could not get the test results DB, err: %v", err)
 	}
@@ -65,23 +126,17 @@ func checkResults(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Match the results between the test results DB and the reference YAML template
-	var mismatchedTestCases []string
-	for testCase, testResult := range actualTestResults {
-		if testResult != expectedTestResults[testCase] {
-			mismatchedTestCases = append(mismatchedTestCases, testCase)
-		}
-	}
-
-	// Verify that there are no unmatched expected test results
-	for testCase := range expectedTestResults {
-		if _, exists := actualTestResults[testCase]; !exists {
-			mismatchedTestCases = append(mismatchedTestCases, testCase)
-		}
-	}
+	mismatchedTestCases, expectedResults := getMismatchedTestCases(actualTestResults, expectedTestResults)
+	objectMismatches := getObjectMismatches(actualTestResults, expectedTestResults.Objects)
 
-	if len(mismatchedTestCases) > 0 {
+	if len(mismatchedTestCases) > 0 || len(objectMismatches) > 0 {
 		fmt.Println("Expected results DO NOT match actual results")
-		printTestResultsMismatch(mismatchedTestCases, actualTestResults, expectedTestResults)
+		if len(mismatchedTestCases) > 0 {
+			printTestResultsMismatch(mismatchedTestCases, actualTestResults, expectedResults)
+		}
+		if len(objectMismatches) > 0 {
+			printObjectsMismatch(objectMismatches)
+		}
 		os.Exit(1)
 	}
 
@@ -90,67 +145,248 @@ func checkResults(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func getTestResultsDB(logFileName string) (map[string]string, error) {
-	resultsDB := make(map[string]string)
+func getTestResultsDB(claimFile string) (map[string]ActualResult, error) {
+	claimScheme, err := claim.Parse(claimFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse claim file %q, err: %v", claimFile, err)
+	}
 
-	file, err := os.Open(logFileName)
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
 	if err != nil {
-		return nil, fmt.Errorf("could not open file %q, err: %v", logFileName, err)
+		return nil, err
 	}
-	defer file.Close()
 
-	re := regexp.MustCompile(`.*\[(.*?)\]\s+Recording result\s+"(.*?)"`)
+	resultsDB := make(map[string]ActualResult)
+	for testCase := range claimScheme.Claim.Results {
+		result := claimScheme.Claim.Results[testCase]
+		actualResult := ActualResult{Result: strings.ToUpper(result.State)}
 
-	scanner := bufio.NewScanner(file)
-	// Fix for bufio.Scanner: token too long
-	const kBytes64 = 64 * 1024
-	const kBytes1024 = 1024 * 1024
-	buf := make([]byte, 0, kBytes64)
-	scanner.Buffer(buf, kBytes1024)
-	for scanner.Scan() {
-		line := scanner.Text()
-		match := re.FindStringSubmatch(line)
-		if match != nil {
-			testCaseName := match[1]
-			result := match[2]
-			resultsDB[testCaseName] = result
+		details := testhelper.FailureReasonOut{}
+		if err := json.Unmarshal([]byte(result.CheckDetails), &details); err == nil {
+			actualResult.NonCompliant = details.NonCompliantObjectsOut
 		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning file, err: %v", err)
+		resultsDB[testCase] = actualResult
 	}
 
 	return resultsDB, nil
 }
 
-func getExpectedTestResults(templateFileName string) (map[string]string, error) {
+// wildcardToRegex converts a text with "*" and "?" wildcards to a regular expression that matches the whole text.
+func wildcardToRegex(text string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(text)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+func hasWildcards(text string) bool {
+	return strings.ContainsAny(text, "*?")
+}
+
+// getSpecificity returns how specific a template entry is: the names without wildcards are the most specific,
+// followed by the patterns with the most characters that are not wildcards.
+func getSpecificity(pattern string) int {
+	if !hasWildcards(pattern) {
+		return len(pattern) + 1
+	}
+	return len(pattern) - strings.Count(pattern, "*") - strings.Count(pattern, "?")
+}
+
+func getExpectedTestResults(templateFileName string) (*TestResults, error) {
 	templateFile, err := os.ReadFile(templateFileName)
 	if err != nil {
 		return nil, fmt.Errorf("could not open template file %q, err: %v", templateFileName, err)
 	}
 
-	var expectedTestResultsList TestResults
-	err = yaml.Unmarshal(templateFile, &expectedTestResultsList)
+	var expectedTestResults TestResults
+	err = yaml.Unmarshal(templateFile, &expectedTestResults)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse the template YAML file, err: %v", err)
 	}
 
-	expectedTestResults := make(map[string]string)
-	for _, testCase := range expectedTestResultsList.Pass {
-		expectedTestResults[testCase] = resultPass
+	return &expectedTestResults, nil
+}
+
+// getExpectedResults returns the expected results of the template entries, from the most specific to the least.
+func getExpectedResults(expected *TestResults) []expectedResult {
+	results := []expectedResult{}
+	for _, list := range []struct {
+		testCases []string
+		result    string
+	}{{expected.Pass, resultPass}, {expected.Fail, resultFail}, {expected.Skip, resultSkip}} {
+		for _, pattern := range list.testCases {
+			results = append(results, expectedResult{pattern: pattern, regex: wildcardToRegex(pattern), result: list.result})
+		}
 	}
-	for _, testCase := range expectedTestResultsList.Skip {
-		expectedTestResults[testCase] = resultSkip
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return getSpecificity(results[i].pattern) > getSpecificity(results[j].pattern)
+	})
+	return results
+}
+
+// getExpectedResult returns the expected result of a test case, or resultMiss if it's not in the template, and
+// whether it's allowed to be skipped.
+func getExpectedResult(testCase string, expectedResults []expectedResult, allowedSkip []*regexp.Regexp) (result string, canBeSkipped bool) {
+	result = resultMiss
+	for _, expected := range expectedResults {
+		if expected.regex.MatchString(testCase) {
+			result = expected.result
+			break
+		}
 	}
-	for _, testCase := range expectedTestResultsList.Fail {
-		expectedTestResults[testCase] = resultFail
+
+	for _, regex := range allowedSkip {
+		if regex.MatchString(testCase) {
+			canBeSkipped = true
+			break
+		}
 	}
 
-	return expectedTestResults, nil
+	if canBeSkipped && result == resultMiss {
+		result = resultPass
+	}
+	return result, canBeSkipped
 }
 
-func printTestResultsMismatch(mismatchedTestCases []string, actualResults, expectedResults map[string]string) {
+// getMismatchedTestCases returns the test cases, sorted, whose result is not the expected one, and the expected
+// result of every test case in the claim or in the template.
+func getMismatchedTestCases(actualResults map[string]ActualResult, expected *TestResults) (mismatched []string, expectedResults map[string]string) {
+	sortedExpectedResults := getExpectedResults(expected)
+	allowedSkip := []*regexp.Regexp{}
+	for _, pattern := range expected.AllowedSkip {
+		allowedSkip = append(allowedSkip, wildcardToRegex(pattern))
+	}
+
+	mismatched = []string{}
+	expectedResults = map[string]string{}
+	for testCase, actualResult := range actualResults {
+		result, canBeSkipped := getExpectedResult(testCase, sortedExpectedResults, allowedSkip)
+		expectedResults[testCase] = result
+		if canBeSkipped {
+			expectedResults[testCase] += " or " + resultSkip
+		}
+		if actualResult.Result != result && (!canBeSkipped || actualResult.Result != resultSkip) {
+			mismatched = append(mismatched, testCase)
+		}
+	}
+
+	// Verify that there are no unmatched expected test results. Only the entries without wildcards must be in
+	// the claim file.
+	for _, expected := range sortedExpectedResults {
+		if _, exists := actualResults[expected.pattern]; !exists && !hasWildcards(expected.pattern) {
+			expectedResults[expected.pattern] = expected.result
+			mismatched = append(mismatched, expected.pattern)
+		}
+	}
+
+	sort.Strings(mismatched)
+	return mismatched, expectedResults
+}
+
+func getObjectFields(object *testhelper.ReportObject) map[string]string {
+	fields := map[string]string{}
+	for i := range object.ObjectFieldsKeys {
+		if i < len(object.ObjectFieldsValues) {
+			fields[object.ObjectFieldsKeys[i]] = object.ObjectFieldsValues[i]
+		}
+	}
+	return fields
+}
+
+func objectToString(object *testhelper.ReportObject) string {
+	fields := []string{}
+	// The first field is the reason of the non-compliance.
+	for i := 1; i < len(object.ObjectFieldsKeys) && i < len(object.ObjectFieldsValues); i++ {
+		fields = append(fields, object.ObjectFieldsKeys[i]+"="+object.ObjectFieldsValues[i])
+	}
+	return object.ObjectType + ": " + strings.Join(fields, ", ")
+}
+
+func (e *ExpectedObject) String() string {
+	keys := []string{}
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := []string{}
+	for _, key := range keys {
+		fields = append(fields, key+"="+e.Fields[key])
+	}
+
+	objectType := e.Type
+	if objectType == "" {
+		objectType = "*"
+	}
+	return objectType + ": " + strings.Join(fields, ", ")
+}
+
+func (e *ExpectedObject) matches(object *testhelper.ReportObject) bool {
+	if e.Type != "" && !wildcardToRegex(e.Type).MatchString(object.ObjectType) {
+		return false
+	}
+
+	fields := getObjectFields(object)
+	for key, value := range e.Fields {
+		actualValue, found := fields[key]
+		if !found || !wildcardToRegex(value).MatchString(actualValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// getObjectMismatches returns the non-compliant objects of the test cases that don't match the expected ones, and
+// the expected objects that were not found.
+func getObjectMismatches(actualResults map[string]ActualResult, expectations []ObjectsExpectation) []ObjectMismatch {
+	testCases := []string{}
+	for testCase := range actualResults {
+		testCases = append(testCases, testCase)
+	}
+	sort.Strings(testCases)
+
+	mismatches := []ObjectMismatch{}
+	for i := range expectations {
+		expectation := &expectations[i]
+		regex := wildcardToRegex(expectation.TestCase)
+		if _, exists := actualResults[expectation.TestCase]; !exists && !hasWildcards(expectation.TestCase) {
+			mismatches = append(mismatches, ObjectMismatch{TestCase: expectation.TestCase, Problem: "test case not found"})
+			continue
+		}
+
+		for _, testCase := range testCases {
+			if !regex.MatchString(testCase) {
+				continue
+			}
+
+			found := make([]bool, len(expectation.NonCompliant))
+			for _, object := range actualResults[testCase].NonCompliant {
+				expected := false
+				for j := range expectation.NonCompliant {
+					if expectation.NonCompliant[j].matches(object) {
+						found[j] = true
+						expected = true
+					}
+				}
+				if !expected {
+					mismatches = append(mismatches, ObjectMismatch{TestCase: testCase, Problem: "unexpected", Object: objectToString(object)})
+				}
+			}
+
+			for j := range expectation.NonCompliant {
+				if !found[j] {
+					mismatches = append(mismatches, ObjectMismatch{TestCase: testCase, Problem: "not found", Object: expectation.NonCompliant[j].String()})
+				}
+			}
+		}
+	}
+
+	return mismatches
+}
+
+func printTestResultsMismatch(mismatchedTestCases []string, actualResults map[string]ActualResult, expectedResults map[string]string) {
 	fmt.Printf("\n")
 	fmt.Println(strings.Repeat("-", 96)) //nolint:mnd // table line
 	fmt.Printf("| %-58s %-19s %s |\n", "TEST_CASE", "EXPECTED_RESULT", "ACTUAL_RESULT")
@@ -160,29 +396,42 @@ func printTestResultsMismatch(mismatchedTestCases []string, actualResults, expec
 		if !exist {
 			expectedResult = resultMiss
 		}
-		actualResult, exist := actualResults[testCase]
-		if !exist {
-			actualResult = resultMiss
+		actualResult := resultMiss
+		if result, exist := actualResults[testCase]; exist {
+			actualResult = result.Result
 		}
 		fmt.Printf("| %-54s %19s %17s |\n", testCase, expectedResult, actualResult)
 		fmt.Println(strings.Repeat("-", 96)) //nolint:mnd // table line
 	}
 }
 
-func generateTemplateFile(resultsDB map[string]string) error {
+func printObjectsMismatch(mismatches []ObjectMismatch) {
+	fmt.Printf("\nNon-compliant objects that DO NOT match the expected ones:\n")
+	fmt.Printf("%-58s %-20s %s\n", "TEST_CASE", "PROBLEM", "NON_COMPLIANT_OBJECT")
+	for _, mismatch := range mismatches {
+		fmt.Printf("%-58s %-20s %s\n", mismatch.TestCase, mismatch.Problem, mismatch.Object)
+	}
+}
+
+func generateTemplateFile(resultsDB map[string]ActualResult) error {
 	var resultsTemplate TestResults
 	for testCase, result := range resultsDB {
-		switch result {
+		switch result.Result {
 		case resultPass:
 			resultsTemplate.Pass = append(resultsTemplate.Pass, testCase)
 		case resultSkip:
 			resultsTemplate.Skip = append(resultsTemplate.Skip, testCase)
 		case resultFail:
 			resultsTemplate.Fail = append(resultsTemplate.Fail, testCase)
+		case resultError, resultAborted:
+			fmt.Fprintf(os.Stderr, "Warning: test case %s is left out of the template, its result is %s\n", testCase, result.Result)
 		default:
-			return fmt.Errorf("unknown test case result %q", result)
+			return fmt.Errorf("unknown test case result %q", result.Result)
 		}
 	}
+	sort.Strings(resultsTemplate.Pass)
+	sort.Strings(resultsTemplate.Skip)
+	sort.Strings(resultsTemplate.Fail)
 
 	const twoSpaces = 2
 	var yamlTemplate bytes.Buffer
@@ -203,10 +452,12 @@ func generateTemplateFile(resultsDB map[string]string) error {
 
 func NewCommand() *cobra.Command {
 	checkResultsCmd.PersistentFlags().String("template", "expected_results.yaml", "reference YAML template with the expected results")
-	checkResultsCmd.PersistentFlags().String("log-file", "certsuite.log", "log file of the Certsuite execution")
-	checkResultsCmd.PersistentFlags().Bool("generate-template", false, "generate a reference YAML template from the log file")
+	checkResultsCmd.PersistentFlags().String("claim-file", filepath.Join("results", claimFileName), "claim file of the Certsuite execution")
+	checkResultsCmd.PersistentFlags().String("log-file", "certsuite.log", "log file of the Certsuite execution, the claim file next to it is used")
+	checkResultsCmd.PersistentFlags().Bool("generate-template", false, "generate a reference YAML template from the claim file")
 
 	checkResultsCmd.MarkFlagsMutuallyExclusive("template", "generate-template")
+	_ = checkResultsCmd.PersistentFlags().MarkDeprecated("log-file", "use --claim-file instead")
 
 	return checkResultsCmd
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2020-2024 Red Hat, Inc.
+
+package results
+
+import (
+	"os"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMismatchedTestCases(t *testing.T) {
+	actualResults := map[string]ActualResult{
+		"access-control-namespace":             {Result: resultPass},
+		"access-control-sys-admin-capability":  {Result: resultFail},
+		"access-control-security-context":      {Result: resultFail},
+		"lifecycle-pod-owner-type":             {Result: resultPass},
+		"lifecycle-cpu-isolation":              {Result: resultSkip},
+		"platform-alteration-hugepages-config": {Result: resultSkip},
+		"platform-alteration-boot-params":      {Result: resultPass},
+		"networking-icmpv4-connectivity":       {Result: resultFail},
+		"observability-crd-status":             {Result: resultPass},
+	}
+	expected := &TestResults{TestCaseList: TestCaseList{
+		Pass: []string{"access-control-*", "lifecycle-*", "networking-*"},
+		// The exact names are more specific than the wildcards.
+		Fail: []string{"access-control-sys-admin-capability", "access-control-security-context", "lifecycle-pod-owner-type"},
+		Skip: []string{"lifecycle-cpu-isolation", "operator-install-status"},
+		// Passed if they're not in the other lists.
+		AllowedSkip: []string{"platform-alteration-*"},
+	}}
+
+	mismatched, expectedResults := getMismatchedTestCases(actualResults, expected)
+	assert.Equal(t, []string{"lifecycle-pod-owner-type", "networking-icmpv4-connectivity", "observability-crd-status", "operator-install-status"}, mismatched)
+	assert.Equal(t, resultFail, expectedResults["lifecycle-pod-owner-type"])
+	assert.Equal(t, resultPass, expectedResults["networking-icmpv4-connectivity"])
+	assert.Equal(t, resultMiss, expectedResults["observability-crd-status"])
+	assert.Equal(t, resultSkip, expectedResults["operator-install-status"])
+	assert.Equal(t, resultPass+" or "+resultSkip, expectedResults["platform-alteration-hugepages-config"])
+}
+
+func TestGetSpecificity(t *testing.T) {
+	assert.Greater(t, getSpecificity("lifecycle-pod"), getSpecificity("lifecycle-pod*"))
+	assert.Greater(t, getSpecificity("lifecycle-pod*"), getSpecificity("lifecycle-*"))
+	assert.Greater(t, getSpecificity("lifecycle-*"), getSpecificity("*"))
+}
+
+func TestGetObjectMismatches(t *testing.T) {
+	actualResults := map[string]ActualResult{
+		"access-control-sys-admin-capability-check": {Result: resultFail, NonCompliant: []*testhelper.ReportObject{
+			testhelper.NewContainerReportObject("tnf", "test-1", "test", "capability detected", false),
+			testhelper.NewContainerReportObject("tnf", "test-2", "test", "capability detected", false),
+			testhelper.NewContainerReportObject("other", "db-0", "db", "capability detected", false),
+		}},
+		"access-control-pod-host-network": {Result: resultFail, NonCompliant: []*testhelper.ReportObject{
+			testhelper.NewPodReportObject("tnf", "test-1", "host network", false),
+		}},
+		"access-control-pod-host-pid": {Result: resultPass},
+	}
+
+	testCases := []struct {
+		expectations       []ObjectsExpectation
+		expectedMismatches []ObjectMismatch
+	}{
+		{
+			expectations: []ObjectsExpectation{{
+				TestCase: "access-control-sys-admin-capability-check",
+				NonCompliant: []ExpectedObject{
+					{Type: "Container", Fields: map[string]string{"Namespace": "tnf", "Pod Name": "test-*"}},
+					{Fields: map[string]string{"Namespace": "other"}},
+				},
+			}},
+			expectedMismatches: []ObjectMismatch{},
+		},
+		{
+			expectations: []ObjectsExpectation{{
+				TestCase: "access-control-sys-admin-capability-check",
+				NonCompliant: []ExpectedObject{
+					{Type: "Container", Fields: map[string]string{"Namespace": "tnf", "Pod Name": "test-1"}},
+					{Type: "Pod", Fields: map[string]string{"Namespace": "other"}},
+				},
+			}},
+			expectedMismatches: []ObjectMismatch{
+				{TestCase: "access-control-sys-admin-capability-check", Problem: "unexpected", Object: "Container: Namespace=tnf, Pod Name=test-2, Container Name=test"},
+				{TestCase: "access-control-sys-admin-capability-check", Problem: "unexpected", Object: "Container: Namespace=other, Pod Name=db-0, Container Name=db"},
+				{TestCase: "access-control-sys-admin-capability-check", Problem: "not found", Object: "Pod: Namespace=other"},
+			},
+		},
+		// No non-compliant objects expected in the test cases that match the wildcard.
+		{
+			expectations: []ObjectsExpectation{{TestCase: "access-control-pod-host-*"}},
+			expectedMismatches: []ObjectMismatch{
+				{TestCase: "access-control-pod-host-network", Problem: "unexpected", Object: "Pod: Namespace=tnf, Pod Name=test-1"},
+			},
+		},
+		{
+			expectations:       []ObjectsExpectation{{TestCase: "access-control-pod-host-ipc"}},
+			expectedMismatches: []ObjectMismatch{{TestCase: "access-control-pod-host-ipc", Problem: "test case not found"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedMismatches, getObjectMismatches(actualResults, tc.expectations))
+	}
+}
+
+func TestGenerateTemplateFile(t *testing.T) {
+	workDir, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(workDir) }()
+
+	resultsDB := map[string]ActualResult{
+		"access-control-1": {Result: resultPass},
+		"access-control-2": {Result: resultFail},
+		"access-control-3": {Result: resultError},
+		"access-control-4": {Result: resultAborted},
+		"access-control-5": {Result: resultSkip},
+	}
+	assert.Nil(t, generateTemplateFile(resultsDB))
+
+	content, err := os.ReadFile(TestResultsTemplateFileName)
+	assert.Nil(t, err)
+	assert.Equal(t, "testCases:\n  pass:\n    - access-control-1\n  fail:\n    - access-control-2\n  skip:\n    - access-control-5\n", string(content))
+
+	assert.NotNil(t, generateTemplateFile(map[string]ActualResult{"access-control-1": {Result: "UNKNOWN"}}))
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/compare/configurations"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/compare/nodes"
@@ -12,6 +14,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 const longHelp = `Compares sections of both claim files and the differences are shown in a table per section.
@@ -63,6 +66,10 @@ CNIs: Only in CLAIM 2
 /clus0-1/0/plugins/0/newFakeFlag=true
 ...
 
+The test cases that ran in both claim files are also compared object by object: their compliant and non-compliant
+objects are parsed from the check details to show which objects are newly non-compliant in claim 2, which ones were
+fixed and which ones were removed.
+
  Currently, the following sections are compared, in this order:
  - claim.versions
  - claim.Results
@@ -73,16 +80,55 @@ CNIs: Only in CLAIM 2
  - claim.nodes.nodeSummary
 `
 
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+	OutputFormatYAML = "yaml"
+
+	FailOnNewFailures = "new-failures"
+	FailOnAnyDiff     = "any-diff"
+)
+
+var (
+	OutputFormats = []string{OutputFormatText, OutputFormatJSON, OutputFormatYAML}
+	FailOnOptions = []string{FailOnNewFailures, FailOnAnyDiff}
+)
+
+// DiffReport holds the differences of all the compared sections of both claim files.
+type DiffReport struct {
+	Versions       *versions.DiffReport       `json:"versions"`
+	TestCases      *testcases.DiffReport      `json:"testCases"`
+	Configurations *configurations.DiffReport `json:"configurations"`
+	Nodes          *nodes.DiffReport          `json:"nodes"`
+}
+
+func (r *DiffReport) String() string {
+	str := r.Versions.String() + "\n"
+	str += r.TestCases.String() + "\n"
+	str += r.Configurations.String() + "\n"
+	str += r.Nodes.String()
+	return str
+}
+
+// HasDifferences returns true if any of the compared sections is different in both claims.
+func (r *DiffReport) HasDifferences() bool {
+	return r.Versions.HasDifferences() || r.TestCases.HasDifferences() ||
+		r.Configurations.HasDifferences() || r.Nodes.HasDifferences()
+}
+
 var (
 	Claim1FilePathFlag string
 	Claim2FilePathFlag string
+	outputFormatFlag   string
+	failOnFlag         string
 
 	claimCompareFiles = &cobra.Command{
-		Use:     "compare",
-		Short:   "Compare two claim files.",
-		Long:    longHelp,
-		Example: "claim compare -1 claim1.json -2 claim2.json",
-		RunE:    claimCompare,
+		Use:   "compare",
+		Short: "Compare two claim files.",
+		Long:  longHelp,
+		Example: `claim compare -1 claim1.json -2 claim2.json
+claim compare -1 baseline.json -2 claim.json --output json --fail-on new-failures`,
+		RunE: claimCompare,
 	}
 )
 
@@ -95,6 +141,14 @@ func NewCommand() *cobra.Command {
 		&Claim2FilePathFlag, "claim2", "2", "",
 		"existing claim2 file. (Required) second file to compare",
 	)
+	claimCompareFiles.Flags().StringVarP(
+		&outputFormatFlag, "output", "o", OutputFormatText,
+		fmt.Sprintf("output format. Available formats: %v", OutputFormats),
+	)
+	claimCompareFiles.Flags().StringVar(
+		&failOnFlag, "fail-on", "",
+		fmt.Sprintf("exit with code 1 when claim2 has new failures or any difference with claim1. Available options: %v", FailOnOptions),
+	)
 	err := claimCompareFiles.MarkFlagRequired("claim1")
 	if err != nil {
 		log.Error("Failed to mark flag claim1 as required: %v", err)
@@ -110,55 +164,126 @@ func NewCommand() *cobra.Command {
 }
 
 func claimCompare(_ *cobra.Command, _ []string) error {
-	err := claimCompareFilesfunc(Claim1FilePathFlag, Claim2FilePathFlag)
+	if err := validateFlags(outputFormatFlag, failOnFlag); err != nil {
+		return err
+	}
+
+	report, err := claimCompareFilesfunc(Claim1FilePathFlag, Claim2FilePathFlag, outputFormatFlag)
 	if err != nil {
 		log.Fatal("Error comparing claim files: %v", err)
 	}
+
+	if reason := getFailOnReason(report, failOnFlag); reason != "" {
+		fmt.Fprintf(os.Stderr, "Claim files comparison failed: %s\n", reason)
+		os.Exit(1)
+	}
+	return nil
+}
+
+// validateFlags checks the output format and the optional fail-on option before any claim file is read.
+func validateFlags(outputFormat, failOn string) error {
+	if !slices.Contains(OutputFormats, outputFormat) {
+		return fmt.Errorf("invalid output format %q - available formats: %v", outputFormat, OutputFormats)
+	}
+	if failOn != "" && !slices.Contains(FailOnOptions, failOn) {
+		return fmt.Errorf("invalid --fail-on option %q - available options: %v", failOn, FailOnOptions)
+	}
 	return nil
 }
 
-func claimCompareFilesfunc(claim1, claim2 string) error {
+// getFailOnReason returns why the comparison must fail according to the failOn option, or an empty string.
+func getFailOnReason(report *DiffReport, failOn string) string {
+	switch failOn {
+	case FailOnNewFailures:
+		if newFailures := report.TestCases.GetNewFailures(); len(newFailures) > 0 {
+			return fmt.Sprintf("%d test case(s) with new failures in claim2: %s", len(newFailures), strings.Join(newFailures, ", "))
+		}
+	case FailOnAnyDiff:
+		if report.HasDifferences() {
+			return "the claim files have differences"
+		}
+	}
+	return ""
+}
+
+func claimCompareFilesfunc(claim1, claim2, outputFormat string) (*DiffReport, error) {
+	report, err := getDiffReport(claim1, claim2)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := formatDiffReport(report, outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Print(output)
+
+	return report, nil
+}
+
+func getDiffReport(claim1, claim2 string) (*DiffReport, error) {
 	// readfiles
 	claimdata1, err := os.ReadFile(claim1)
 	if err != nil {
-		return fmt.Errorf("failed reading claim1 file: %v", err)
+		return nil, fmt.Errorf("failed reading claim1 file: %v", err)
 	}
 
 	claimdata2, err := os.ReadFile(claim2)
 	if err != nil {
-		return fmt.Errorf("failed reading claim2 file: %v", err)
+		return nil, fmt.Errorf("failed reading claim2 file: %v", err)
 	}
 
 	// unmarshal the files
 	claimFile1Data, err := unmarshalClaimFile(claimdata1)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal claim1 file: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal claim1 file: %v", err)
 	}
 
 	claimFile2Data, err := unmarshalClaimFile(claimdata2)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal claim2 file: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal claim2 file: %v", err)
 	}
 
-	// Compare claim versions.
-	versionsDiff := versions.Compare(&claimFile1Data.Claim.Versions, &claimFile2Data.Claim.Versions)
-	fmt.Println(versionsDiff)
-
-	// Show test cases results summary and differences.
-	tcsDiffReport := testcases.GetDiffReport(claimFile1Data.Claim.Results, claimFile2Data.Claim.Results)
-	fmt.Println(tcsDiffReport)
-
-	// Show CNF Certification Suite configuration differences.
-	claim1Configurations := &claimFile1Data.Claim.Configurations
-	claim2Configurations := &claimFile2Data.Claim.Configurations
-	configurationsDiffReport := configurations.GetDiffReport(claim1Configurations, claim2Configurations)
-	fmt.Println(configurationsDiffReport)
-
-	// Show the cluster differences.
-	nodesDiff := nodes.GetDiffReport(&claimFile1Data.Claim.Nodes, &claimFile2Data.Claim.Nodes)
-	fmt.Print(nodesDiff)
+	return &DiffReport{
+		// Compare claim versions.
+		Versions: versions.Compare(&claimFile1Data.Claim.Versions, &claimFile2Data.Claim.Versions),
+		// Test cases results summary and differences.
+		TestCases: testcases.GetDiffReport(claimFile1Data.Claim.Results, claimFile2Data.Claim.Results),
+		// CNF Certification Suite configuration differences.
+		Configurations: configurations.GetDiffReport(&claimFile1Data.Claim.Configurations, &claimFile2Data.Claim.Configurations),
+		// Cluster differences.
+		Nodes: nodes.GetDiffReport(&claimFile1Data.Claim.Nodes, &claimFile2Data.Claim.Nodes),
+	}, nil
+}
 
-	return nil
+func formatDiffReport(report *DiffReport, outputFormat string) (string, error) {
+	switch outputFormat {
+	case OutputFormatText:
+		return report.String(), nil
+	case OutputFormatJSON:
+		bytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the differences to json: %v", err)
+		}
+		return string(bytes) + "\n", nil
+	case OutputFormatYAML:
+		// Use the json field names in the yaml output.
+		bytes, err := json.Marshal(report)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the differences to json: %v", err)
+		}
+		var data interface{}
+		if err := json.Unmarshal(bytes, &data); err != nil {
+			return "", fmt.Errorf("failed to unmarshal the differences: %v", err)
+		}
+		bytes, err = yaml.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal the differences to yaml: %v", err)
+		}
+		return string(bytes), nil
+	default:
+		return "", fmt.Errorf("invalid output format %q - available formats: %v", outputFormat, OutputFormats)
+	}
 }
 
 func unmarshalClaimFile(claimdata []byte) (claim.Schema, error) {
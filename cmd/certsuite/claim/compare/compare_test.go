@@ -1,6 +1,7 @@
 package compare
 
 import (
+	"encoding/json"
 	"io"
 	"os"
 	"testing"
@@ -74,7 +75,7 @@ func Test_claimCompareFilesfunc(t *testing.T) {
 
 			os.Stdout = w
 			// Run function under test.
-			err = claimCompareFilesfunc(tc.claim1Path, tc.claim2Path)
+			_, err = claimCompareFilesfunc(tc.claim1Path, tc.claim2Path, OutputFormatText)
 			// Close write pipe. Needed so the io.ReadAll can detect the EOF.
 			w.Close()
 
@@ -100,3 +101,48 @@ func Test_claimCompareFilesfunc(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatDiffReport(t *testing.T) {
+	report, err := getDiffReport("testdata/claim_observability.json", "testdata/claim_access_control.json")
+	assert.Nil(t, err)
+
+	output, err := formatDiffReport(report, OutputFormatJSON)
+	assert.Nil(t, err)
+	parsed := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &parsed))
+	for _, section := range []string{"versions", "testCases", "configurations", "nodes"} {
+		assert.Contains(t, parsed, section)
+	}
+	testCases := parsed["testCases"].(map[string]interface{})
+	assert.Equal(t, float64(report.TestCases.DifferentTestCasesResults), testCases["differentTestCasesResults"])
+
+	output, err = formatDiffReport(report, OutputFormatYAML)
+	assert.Nil(t, err)
+	assert.Contains(t, output, "\ntestCases:\n")
+	assert.Contains(t, output, "- claim1Value: 4.13.1\n              claim2Value: 4.13.0\n              field: /ocp\n")
+
+	_, err = formatDiffReport(report, "xml")
+	assert.NotNil(t, err)
+}
+
+func TestGetFailOnReason(t *testing.T) {
+	diffReport, err := getDiffReport("testdata/claim_observability.json", "testdata/claim_access_control.json")
+	assert.Nil(t, err)
+	sameReport, err := getDiffReport("testdata/claim_observability.json", "testdata/claim_observability.json")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "", getFailOnReason(diffReport, ""))
+	assert.Equal(t, "the claim files have differences", getFailOnReason(diffReport, FailOnAnyDiff))
+	assert.Contains(t, getFailOnReason(diffReport, FailOnNewFailures), "test case(s) with new failures in claim2: access-control-bpf-capability-check, ")
+
+	assert.Equal(t, "", getFailOnReason(sameReport, FailOnAnyDiff))
+	assert.Equal(t, "", getFailOnReason(sameReport, FailOnNewFailures))
+}
+
+func TestValidateFlags(t *testing.T) {
+	assert.Nil(t, validateFlags(OutputFormatText, ""))
+	assert.Nil(t, validateFlags(OutputFormatJSON, FailOnNewFailures))
+	assert.Nil(t, validateFlags(OutputFormatYAML, FailOnAnyDiff))
+	assert.NotNil(t, validateFlags("xml", ""))
+	assert.NotNil(t, validateFlags(OutputFormatText, "new-differences"))
+}
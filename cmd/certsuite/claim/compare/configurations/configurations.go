@@ -42,6 +42,11 @@ func (d *DiffReport) String() string {
 	return str
 }
 
+// HasDifferences returns true if the configurations or the abnormal events count are different in both claims.
+func (d *DiffReport) HasDifferences() bool {
+	return !d.Config.IsEmpty() || d.AbnormalEvents.Claim1 != d.AbnormalEvents.Claim2
+}
+
 func GetDiffReport(claim1Configurations, claim2Configurations *claim.Configurations) *DiffReport {
 	return &DiffReport{
 		Config: diff.Compare("CNF Cert Suite Configuration", claim1Configurations.Config, claim2Configurations.Config, nil),
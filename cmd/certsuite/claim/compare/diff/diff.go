@@ -13,12 +13,17 @@ import (
 type Diffs struct {
 	// Name of the json object whose diffs are stored here.
 	// It will be used when serializing the data in table format.
-	Name string
+	Name string `json:"name"`
 	// CNI Fields that appear in both claim Fields but their values are different.
-	Fields []FieldDiff
+	Fields []FieldDiff `json:"differences"`
 
-	FieldsInClaim1Only []string
-	FieldsInClaim2Only []string
+	FieldsInClaim1Only []string `json:"fieldsInClaim1Only"`
+	FieldsInClaim2Only []string `json:"fieldsInClaim2Only"`
+}
+
+// IsEmpty returns true if there are no differences between both objects.
+func (d *Diffs) IsEmpty() bool {
+	return d == nil || (len(d.Fields) == 0 && len(d.FieldsInClaim1Only) == 0 && len(d.FieldsInClaim2Only) == 0)
 }
 
 // FieldDIff holds the field path and the values from both claim files
@@ -51,3 +51,8 @@ func GetDiffReport(claim1Nodes, claim2Nodes *claim.Nodes) *DiffReport {
 		Hardware: diff.Compare("Hardware", claim1Nodes.NodesHwInfo, claim2Nodes.NodesHwInfo, nil),
 	}
 }
+
+// HasDifferences returns true if any of the nodes sections are different in both claims.
+func (d DiffReport) HasDifferences() bool {
+	return !d.Nodes.IsEmpty() || !d.CNI.IsEmpty() || !d.CSI.IsEmpty() || !d.Hardware.IsEmpty()
+}
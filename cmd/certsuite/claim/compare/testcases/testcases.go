@@ -1,22 +1,50 @@
 package testcases
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 )
 
 type TcResultsSummary struct {
-	Passed  int
-	Skipped int
-	Failed  int
+	Passed  int `json:"passed"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
 }
 
 type TcResultDifference struct {
-	Name         string
-	Claim1Result string
-	Claim2Result string
+	Name         string `json:"name"`
+	Claim1Result string `json:"claim1Result"`
+	Claim2Result string `json:"claim2Result"`
+}
+
+// ObjectField is a field of a compliant or non-compliant object, e.g. the namespace of a pod.
+type ObjectField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Object is a compliant or non-compliant object of a test case result, parsed from its checkDetails.
+type Object struct {
+	Type   string        `json:"type"`
+	Reason string        `json:"reason"`
+	Fields []ObjectField `json:"fields"`
+}
+
+// TcObjectsDifference holds the objects whose compliance changed between both claims for a test case that ran
+// in both of them:
+//   - NewNonCompliant: non-compliant in claim 2 but not in claim 1.
+//   - Fixed: non-compliant in claim 1 and compliant in claim 2.
+//   - Removed: non-compliant in claim 1 and not found in claim 2.
+type TcObjectsDifference struct {
+	Name            string   `json:"name"`
+	NewNonCompliant []Object `json:"newNonCompliantObjects"`
+	Fixed           []Object `json:"fixedObjects"`
+	Removed         []Object `json:"removedObjects"`
 }
 
 // Holds the results summary and the list of test cases whose result
@@ -27,6 +55,143 @@ type DiffReport struct {
 
 	TestCases                 []TcResultDifference `json:"resultsDifferences"`
 	DifferentTestCasesResults int                  `json:"differentTestCasesResults"`
+
+	ObjectsDifferences []TcObjectsDifference `json:"objectsDifferences"`
+}
+
+// String returns the object's type and fields, without the reason, e.g. "Pod: Namespace=ns1, Pod Name=pod1".
+// It identifies the object in the results of both claims.
+func (o *Object) String() string {
+	fields := []string{}
+	for _, field := range o.Fields {
+		fields = append(fields, field.Key+"="+field.Value)
+	}
+	return o.Type + ": " + strings.Join(fields, ", ")
+}
+
+func newObject(reportObject *testhelper.ReportObject) Object {
+	object := Object{Type: reportObject.ObjectType, Fields: []ObjectField{}}
+	for i, key := range reportObject.ObjectFieldsKeys {
+		if i >= len(reportObject.ObjectFieldsValues) {
+			break
+		}
+		value := reportObject.ObjectFieldsValues[i]
+		if key == testhelper.ReasonForCompliance || key == testhelper.ReasonForNonCompliance {
+			object.Reason = value
+			continue
+		}
+		object.Fields = append(object.Fields, ObjectField{Key: key, Value: value})
+	}
+	return object
+}
+
+// getObjects parses the checkDetails of a test case result into its compliant and non-compliant objects, mapped
+// by their string representation. The checkDetails of old claim files may not be in json format, in which case
+// no objects are returned.
+func getObjects(checkDetails string) (compliant, nonCompliant map[string]Object) {
+	compliant, nonCompliant = map[string]Object{}, map[string]Object{}
+
+	objects := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(checkDetails), &objects); err != nil {
+		return compliant, nonCompliant
+	}
+
+	for _, reportObject := range objects.CompliantObjectsOut {
+		if reportObject != nil {
+			object := newObject(reportObject)
+			compliant[object.String()] = object
+		}
+	}
+	for _, reportObject := range objects.NonCompliantObjectsOut {
+		if reportObject != nil {
+			object := newObject(reportObject)
+			nonCompliant[object.String()] = object
+		}
+	}
+
+	return compliant, nonCompliant
+}
+
+func getSortedObjects(objects map[string]Object) []Object {
+	names := []string{}
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := []Object{}
+	for _, name := range names {
+		sorted = append(sorted, objects[name])
+	}
+	return sorted
+}
+
+func hasRun(result *claim.TestCaseResult) bool {
+	return result.State == claim.TestCaseResultPassed || result.State == claim.TestCaseResultFailed
+}
+
+// getObjectsDifferences diffs the compliant and non-compliant objects of the test cases that ran (passed or
+// failed) in both claims. Only the test cases with any difference are returned, sorted by name.
+func getObjectsDifferences(resultsClaim1, resultsClaim2 claim.TestSuiteResults) []TcObjectsDifference {
+	claim1Results := map[string]claim.TestCaseResult{}
+	//nolint:gocritic
+	for _, testCase := range resultsClaim1 {
+		claim1Results[testCase.TestID.ID] = testCase
+	}
+
+	claim2Results := map[string]claim.TestCaseResult{}
+	//nolint:gocritic
+	for _, testCase := range resultsClaim2 {
+		claim2Results[testCase.TestID.ID] = testCase
+	}
+
+	names := []string{}
+	for name := range claim1Results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	differences := []TcObjectsDifference{}
+	for _, name := range names {
+		result1 := claim1Results[name]
+		result2, found := claim2Results[name]
+		if !found || !hasRun(&result1) || !hasRun(&result2) {
+			continue
+		}
+
+		_, nonCompliant1 := getObjects(result1.CheckDetails)
+		compliant2, nonCompliant2 := getObjects(result2.CheckDetails)
+
+		newNonCompliant, fixed, removed := map[string]Object{}, map[string]Object{}, map[string]Object{}
+		for key, object := range nonCompliant2 {
+			if _, found := nonCompliant1[key]; !found {
+				newNonCompliant[key] = object
+			}
+		}
+		for key, object := range nonCompliant1 {
+			if _, found := nonCompliant2[key]; found {
+				continue
+			}
+			if fixedObject, found := compliant2[key]; found {
+				fixed[key] = fixedObject
+			} else {
+				removed[key] = object
+			}
+		}
+
+		if len(newNonCompliant) == 0 && len(fixed) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		differences = append(differences, TcObjectsDifference{
+			Name:            name,
+			NewNonCompliant: getSortedObjects(newNonCompliant),
+			Fixed:           getSortedObjects(fixed),
+			Removed:         getSortedObjects(removed),
+		})
+	}
+
+	return differences
 }
 
 // Helper function that iterates over resultsByTestSuite, which maps a test suite name to a list
@@ -126,6 +291,8 @@ func GetDiffReport(resultsClaim1, resultsClaim2 claim.TestSuiteResults) *DiffRep
 	report.Claim1ResultsSummary = getTestCasesResultsSummary(claim1Results)
 	report.Claim2ResultsSummary = getTestCasesResultsSummary(claim2Results)
 
+	report.ObjectsDifferences = getObjectsDifferences(resultsClaim1, resultsClaim2)
+
 	return &report
 }
 
@@ -161,12 +328,74 @@ func (r *DiffReport) String() string {
 	str += "-------------------\n"
 	if len(r.TestCases) == 0 {
 		str += "<none>\n"
-		return str
+	} else {
+		str += fmt.Sprintf(tcDiffRowFmt, "TEST CASE NAME", "CLAIM-1", "CLAIM-2")
+		for _, diff := range r.TestCases {
+			str += fmt.Sprintf(tcDiffRowFmt, diff.Name, diff.Claim1Result, diff.Claim2Result)
+		}
 	}
+	str += "\n"
 
-	str += fmt.Sprintf(tcDiffRowFmt, "TEST CASE NAME", "CLAIM-1", "CLAIM-2")
+	str += r.objectsDifferencesString()
+	return str
+}
+
+// HasDifferences returns true if any test case has a different result or different objects in both claims.
+func (r *DiffReport) HasDifferences() bool {
+	return len(r.TestCases) > 0 || len(r.ObjectsDifferences) > 0
+}
+
+// GetNewFailures returns the names of the test cases that failed in claim 2 but not in claim 1, and the ones
+// that failed in both claims but have new non-compliant objects in claim 2, sorted by name.
+func (r *DiffReport) GetNewFailures() []string {
+	newFailures := map[string]struct{}{}
 	for _, diff := range r.TestCases {
-		str += fmt.Sprintf(tcDiffRowFmt, diff.Name, diff.Claim1Result, diff.Claim2Result)
+		if diff.Claim2Result == claim.TestCaseResultFailed && diff.Claim1Result != claim.TestCaseResultFailed {
+			newFailures[diff.Name] = struct{}{}
+		}
+	}
+
+	for i := range r.ObjectsDifferences {
+		if len(r.ObjectsDifferences[i].NewNonCompliant) > 0 {
+			newFailures[r.ObjectsDifferences[i].Name] = struct{}{}
+		}
+	}
+
+	names := []string{}
+	for name := range newFailures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *DiffReport) objectsDifferencesString() string {
+	const tcObjectsDiffRowFmt = "%-60s%-20s%-s\n"
+
+	str := "RESULTS OBJECTS DIFFERENCES\n"
+	str += "---------------------------\n"
+	if len(r.ObjectsDifferences) == 0 {
+		str += "<none>\n"
+		return str
+	}
+
+	str += fmt.Sprintf(tcObjectsDiffRowFmt, "TEST CASE NAME", "CHANGE", "OBJECT")
+	for _, diff := range r.ObjectsDifferences {
+		name := diff.Name
+		for _, change := range []struct {
+			name    string
+			objects []Object
+		}{
+			{"new non-compliant", diff.NewNonCompliant},
+			{"fixed", diff.Fixed},
+			{"removed", diff.Removed},
+		} {
+			for i := range change.objects {
+				str += fmt.Sprintf(tcObjectsDiffRowFmt, name, change.name, change.objects[i].String())
+				// Show the test case name only in its first row.
+				name = ""
+			}
+		}
 	}
 
 	return str
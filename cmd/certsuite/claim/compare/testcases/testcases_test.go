@@ -309,3 +309,84 @@ func TestGetDiffReport(t *testing.T) {
 		})
 	}
 }
+
+func TestGetObjectsDifferences(t *testing.T) {
+	const (
+		pod1NonCompliant = `{"ObjectType": "Pod", "ObjectFieldsKeys": ["Reason For Non Compliance", "Namespace", "Pod Name"], "ObjectFieldsValues": ["uses host network", "ns1", "pod1"]}`
+		pod1Compliant    = `{"ObjectType": "Pod", "ObjectFieldsKeys": ["Reason For Compliance", "Namespace", "Pod Name"], "ObjectFieldsValues": ["no host network", "ns1", "pod1"]}`
+		pod2NonCompliant = `{"ObjectType": "Pod", "ObjectFieldsKeys": ["Reason For Non Compliance", "Namespace", "Pod Name"], "ObjectFieldsValues": ["uses host network", "ns1", "pod2"]}`
+		pod3NonCompliant = `{"ObjectType": "Pod", "ObjectFieldsKeys": ["Reason For Non Compliance", "Namespace", "Pod Name"], "ObjectFieldsValues": ["uses host network", "ns1", "pod3"]}`
+	)
+
+	pod1 := Object{Type: "Pod", Reason: "no host network", Fields: []ObjectField{{"Namespace", "ns1"}, {"Pod Name", "pod1"}}}
+	pod2 := Object{Type: "Pod", Reason: "uses host network", Fields: []ObjectField{{"Namespace", "ns1"}, {"Pod Name", "pod2"}}}
+	pod3 := Object{Type: "Pod", Reason: "uses host network", Fields: []ObjectField{{"Namespace", "ns1"}, {"Pod Name", "pod3"}}}
+
+	testCases := []struct {
+		description         string
+		results1            claim.TestSuiteResults
+		results2            claim.TestSuiteResults
+		expectedDifferences []TcObjectsDifference
+	}{
+		{
+			description: "same non-compliant objects",
+			results1: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "failed", CheckDetails: `{"NonCompliantObjectsOut": [` + pod1NonCompliant + `]}`},
+			},
+			results2: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "failed", CheckDetails: `{"NonCompliantObjectsOut": [` + pod1NonCompliant + `]}`},
+			},
+			expectedDifferences: []TcObjectsDifference{},
+		},
+		{
+			description: "new non-compliant, fixed and removed objects",
+			results1: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "failed", CheckDetails: `{"NonCompliantObjectsOut": [` + pod1NonCompliant + `, ` + pod3NonCompliant + `]}`},
+			},
+			results2: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "failed", CheckDetails: `{"CompliantObjectsOut": [` + pod1Compliant + `], "NonCompliantObjectsOut": [` + pod2NonCompliant + `]}`},
+			},
+			expectedDifferences: []TcObjectsDifference{
+				{Name: "tc1", NewNonCompliant: []Object{pod2}, Fixed: []Object{pod1}, Removed: []Object{pod3}},
+			},
+		},
+		{
+			description: "test cases that didn't run in both claims or with invalid check details are not compared",
+			results1: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "skipped"},
+				"tc2": {TestID: claim.TestCaseID{ID: "tc2"}, State: "failed", CheckDetails: "pod1 uses host network"},
+				"tc3": {TestID: claim.TestCaseID{ID: "tc3"}, State: "failed", CheckDetails: `{"NonCompliantObjectsOut": [` + pod1NonCompliant + `]}`},
+			},
+			results2: claim.TestSuiteResults{
+				"tc1": {TestID: claim.TestCaseID{ID: "tc1"}, State: "failed", CheckDetails: `{"NonCompliantObjectsOut": [` + pod1NonCompliant + `]}`},
+				"tc2": {TestID: claim.TestCaseID{ID: "tc2"}, State: "failed", CheckDetails: "pod1 uses host network"},
+			},
+			expectedDifferences: []TcObjectsDifference{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDifferences, getObjectsDifferences(tc.results1, tc.results2))
+		})
+	}
+}
+
+func TestDiffReportObjectsDifferencesString(t *testing.T) {
+	report := DiffReport{
+		ObjectsDifferences: []TcObjectsDifference{
+			{
+				Name:            "access-control-pod-host-network",
+				NewNonCompliant: []Object{{Type: "Pod", Fields: []ObjectField{{"Namespace", "ns1"}, {"Pod Name", "pod2"}}}},
+				Fixed:           []Object{{Type: "Pod", Fields: []ObjectField{{"Namespace", "ns1"}, {"Pod Name", "pod1"}}}},
+			},
+		},
+	}
+
+	expected := "RESULTS OBJECTS DIFFERENCES\n" +
+		"---------------------------\n" +
+		"TEST CASE NAME                                              CHANGE              OBJECT\n" +
+		"access-control-pod-host-network                             new non-compliant   Pod: Namespace=ns1, Pod Name=pod2\n" +
+		"                                                            fixed               Pod: Namespace=ns1, Pod Name=pod1\n"
+	assert.Equal(t, expected, report.objectsDifferencesString())
+}
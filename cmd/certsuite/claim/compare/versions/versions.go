@@ -20,6 +20,11 @@ func (d *DiffReport) String() string {
 	return d.Diffs.String()
 }
 
+// HasDifferences returns true if the versions are different in both claims.
+func (d *DiffReport) HasDifferences() bool {
+	return !d.Diffs.IsEmpty()
+}
+
 func Compare(claim1Versions, claim2Versions *officialClaimScheme.Versions) *DiffReport {
 	// Convert the versions struct type to agnostic map[string]interface{} objects so
 	// it can be compared using the diff.Compare func.
@@ -0,0 +1,344 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package merge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Policies for the test cases with different results in several claims.
+	ConflictPolicyFail  = "fail"
+	ConflictPolicyFirst = "first"
+	ConflictPolicyLast  = "last"
+	ConflictPolicyWorst = "worst"
+
+	// ProvenanceKey is the key of the merge provenance in the configurations of the merged claim.
+	ProvenanceKey = "mergeProvenance"
+
+	configKey        = "Config"
+	nodeSummaryKey   = "nodeSummary"
+	outputFilePerms  = 0o644
+	claimFilesMinNum = 2
+)
+
+var ConflictPolicies = []string{ConflictPolicyFail, ConflictPolicyFirst, ConflictPolicyLast, ConflictPolicyWorst}
+
+// Results states from the worst to the best, used by the "worst" conflict policy.
+var statesSeverity = map[string]int{
+	"failed":  4,
+	"error":   3,
+	"aborted": 2,
+	"passed":  1,
+	"skipped": 0,
+}
+
+// Source is a merged claim file.
+type Source struct {
+	File      string `json:"file"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+// Provenance records the claim file each result of a merged claim comes from.
+type Provenance struct {
+	Sources []Source          `json:"sources"`
+	Results map[string]string `json:"results"`
+}
+
+var (
+	outputFileFlag     string
+	onConflictFlag     string
+	ignoreMismatchFlag bool
+	claimMergeFilesCmd = &cobra.Command{
+		Use:   "merge <claim-file> <claim-file>...",
+		Short: "Merges several partial claim files into one.",
+		Long: `Merges the claim files of runs with different label filters, e.g. one CI job per test suite, into a single claim
+file with the union of their results.
+
+The results of test cases that didn't match the labels filter of a run are replaced by the results of the runs
+where they did. A test case with different results in several claim files is a conflict: the merge fails unless
+the --on-conflict policy says which result to keep: the one in the first or last claim file, in the order of the
+arguments, or the worst one (failed, error, aborted, passed, skipped).
+
+The versions, the test suite configuration and the cluster nodes of all the claim files must be the same, or the
+merge fails unless --ignore-mismatches is set. The rest of the configurations and nodes sections are taken from the
+first claim file. The merged claim starts with the earliest run and ends with the latest one, and its
+configurations section records, under "` + ProvenanceKey + `", the claim file each result comes from.`,
+		Example: `./certsuite claim merge access-control.json lifecycle.json -o merged.json
+./certsuite claim merge results/*/claim.json -o merged.json --on-conflict worst`,
+		Args: cobra.MinimumNArgs(claimFilesMinNum),
+		RunE: mergeClaims,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimMergeFilesCmd.Flags().StringVarP(&outputFileFlag, "output", "o", "",
+		"Required: merged claim file path.",
+	)
+
+	err := claimMergeFilesCmd.MarkFlagRequired("output")
+	if err != nil {
+		log.Fatalf("Failed to mark output file path as required parameter: %v", err)
+		return nil
+	}
+
+	claimMergeFilesCmd.Flags().StringVar(&onConflictFlag, "on-conflict", ConflictPolicyFail,
+		fmt.Sprintf("Optional: policy for the test cases with different results. Available policies: %v", ConflictPolicies),
+	)
+	claimMergeFilesCmd.Flags().BoolVar(&ignoreMismatchFlag, "ignore-mismatches", false,
+		"Optional: merge the claim files even if their versions, configuration or nodes are different.",
+	)
+
+	return claimMergeFilesCmd
+}
+
+func mergeClaims(_ *cobra.Command, args []string) error {
+	roots := []*claim.Root{}
+	for _, claimFile := range args {
+		root, err := readClaimFile(claimFile)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, root)
+	}
+
+	merged, err := Merge(args, roots, onConflictFlag, ignoreMismatchFlag)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the merged claim: %v", err)
+	}
+
+	if err := os.WriteFile(outputFileFlag, payload, outputFilePerms); err != nil {
+		return fmt.Errorf("failed to write the merged claim file %s: %v", outputFileFlag, err)
+	}
+
+	fmt.Printf("%d claim files merged into %s (%d results)\n", len(args), outputFileFlag, len(merged.Claim.Results))
+	return nil
+}
+
+func readClaimFile(claimFile string) (*claim.Root, error) {
+	content, err := os.ReadFile(claimFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claim file %s: %v", claimFile, err)
+	}
+
+	root := claim.Root{}
+	if err := json.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claim file %s: %v", claimFile, err)
+	}
+	return &root, nil
+}
+
+// Merge merges the claims read from the given files, in that order.
+func Merge(files []string, roots []*claim.Root, onConflict string, ignoreMismatches bool) (*claim.Root, error) {
+	if len(files) != len(roots) || len(roots) == 0 {
+		return nil, errors.New("no claims to merge")
+	}
+	if !isValidPolicy(onConflict) {
+		return nil, fmt.Errorf("invalid conflict policy %q - available policies: %v", onConflict, ConflictPolicies)
+	}
+	for i, root := range roots {
+		if root.Claim == nil {
+			return nil, fmt.Errorf("claim file %s has no claim", files[i])
+		}
+	}
+
+	first := roots[0].Claim
+	for i := 1; i < len(roots); i++ {
+		mismatches := getMismatches(first, roots[i].Claim)
+		if len(mismatches) == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("claim files %s and %s have different %s", files[0], files[i], strings.Join(mismatches, ", "))
+		if !ignoreMismatches {
+			return nil, errors.New(msg)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+
+	results, resultsProvenance, err := mergeResults(files, roots, onConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations := map[string]interface{}{}
+	for key, value := range first.Configurations {
+		configurations[key] = value
+	}
+
+	provenance := Provenance{Results: resultsProvenance}
+	for i, root := range roots {
+		source := Source{File: files[i]}
+		if root.Claim.Metadata != nil {
+			source.StartTime = root.Claim.Metadata.StartTime
+			source.EndTime = root.Claim.Metadata.EndTime
+		}
+		provenance.Sources = append(provenance.Sources, source)
+	}
+	configurations[ProvenanceKey] = provenance
+
+	return &claim.Root{
+		Claim: &claim.Claim{
+			Configurations: configurations,
+			Metadata:       mergeMetadata(roots),
+			Nodes:          first.Nodes,
+			Results:        results,
+			Versions:       first.Versions,
+		},
+	}, nil
+}
+
+func isValidPolicy(policy string) bool {
+	for _, p := range ConflictPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// getMismatches returns the sections that are materially different in both claims: the versions, except the
+// oc client's one, the test suite configuration and the names of the cluster nodes.
+func getMismatches(claim1, claim2 *claim.Claim) []string {
+	mismatches := []string{}
+
+	v1, v2 := claim.Versions{}, claim.Versions{}
+	if claim1.Versions != nil {
+		v1 = *claim1.Versions
+	}
+	if claim2.Versions != nil {
+		v2 = *claim2.Versions
+	}
+	if v1.Tnf != v2.Tnf || v1.TnfGitCommit != v2.TnfGitCommit || v1.ClaimFormat != v2.ClaimFormat || v1.K8s != v2.K8s || v1.Ocp != v2.Ocp {
+		mismatches = append(mismatches, "versions")
+	}
+
+	if !reflect.DeepEqual(claim1.Configurations[configKey], claim2.Configurations[configKey]) {
+		mismatches = append(mismatches, "test suite configuration")
+	}
+
+	if !reflect.DeepEqual(getNodeNames(claim1.Nodes), getNodeNames(claim2.Nodes)) {
+		mismatches = append(mismatches, "nodes")
+	}
+
+	return mismatches
+}
+
+func getNodeNames(nodes map[string]interface{}) []string {
+	names := []string{}
+	nodeSummary, ok := nodes[nodeSummaryKey].(map[string]interface{})
+	if !ok {
+		return names
+	}
+	for name := range nodeSummary {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeResults returns the union of the results of the claims and the file each result comes from.
+func mergeResults(files []string, roots []*claim.Root, onConflict string) (results map[string]claim.Result, provenance map[string]string, err error) {
+	results = map[string]claim.Result{}
+	provenance = map[string]string{}
+	conflicts := []string{}
+
+	for i, root := range roots {
+		testIDs := []string{}
+		for testID := range root.Claim.Results {
+			testIDs = append(testIDs, testID)
+		}
+		sort.Strings(testIDs)
+
+		for _, testID := range testIDs {
+			result := root.Claim.Results[testID]
+			current, found := results[testID]
+			switch {
+			case !found, isNotRun(&current) && !isNotRun(&result):
+				// New result, or the previous claim file didn't run the test case.
+			case isNotRun(&result), current.State == result.State:
+				continue
+			case onConflict == ConflictPolicyFail:
+				conflicts = append(conflicts, fmt.Sprintf("%s (%s in %s, %s in %s)", testID, current.State, provenance[testID], result.State, files[i]))
+				continue
+			case onConflict == ConflictPolicyFirst,
+				onConflict == ConflictPolicyWorst && statesSeverity[result.State] <= statesSeverity[current.State]:
+				continue
+			}
+
+			results[testID] = result
+			provenance[testID] = files[i]
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, nil, fmt.Errorf("conflicting results for %d test case(s): %s", len(conflicts), strings.Join(conflicts, ", "))
+	}
+
+	return results, provenance, nil
+}
+
+// isNotRun returns true for the results of the test cases that didn't match the labels filter of a run.
+func isNotRun(result *claim.Result) bool {
+	return result.State == "skipped" && result.SkipReason == checksdb.SkipReasonNoMatchingLabels
+}
+
+// mergeMetadata returns the earliest start time and the latest end time of the claims. The first claim's times
+// are kept if they can't be parsed.
+func mergeMetadata(roots []*claim.Root) *claim.Metadata {
+	metadata := &claim.Metadata{}
+	if roots[0].Claim.Metadata != nil {
+		*metadata = *roots[0].Claim.Metadata
+	}
+	var start, end time.Time
+	for _, root := range roots {
+		if root.Claim.Metadata == nil {
+			continue
+		}
+
+		startTime, err := time.Parse(claimhelper.DateTimeFormatDirective, root.Claim.Metadata.StartTime)
+		if err == nil && (start.IsZero() || startTime.Before(start)) {
+			start = startTime
+			metadata.StartTime = root.Claim.Metadata.StartTime
+		}
+
+		endTime, err := time.Parse(claimhelper.DateTimeFormatDirective, root.Claim.Metadata.EndTime)
+		if err == nil && (end.IsZero() || endTime.After(end)) {
+			end = endTime
+			metadata.EndTime = root.Claim.Metadata.EndTime
+		}
+	}
+	return metadata
+}
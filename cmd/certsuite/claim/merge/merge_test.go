@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClaim(startTime, endTime string, results map[string]string) *claim.Root {
+	root := &claim.Root{Claim: &claim.Claim{
+		Configurations: map[string]interface{}{"Config": map[string]interface{}{"targetNameSpaces": []interface{}{"tnf"}}},
+		Metadata:       &claim.Metadata{StartTime: startTime, EndTime: endTime},
+		Nodes:          map[string]interface{}{"nodeSummary": map[string]interface{}{"node1": map[string]interface{}{}}},
+		Results:        map[string]claim.Result{},
+		Versions:       &claim.Versions{Tnf: "v5.3.0", ClaimFormat: "v0.4.0", K8s: "v1.29.0"},
+	}}
+	for testID, state := range results {
+		result := claim.Result{TestID: &claim.Identifier{Id: testID}, State: state}
+		if state == "not-run" {
+			result.State = "skipped"
+			result.SkipReason = "no matching labels"
+		}
+		root.Claim.Results[testID] = result
+	}
+	return root
+}
+
+func TestMerge(t *testing.T) {
+	files := []string{"a.json", "b.json"}
+	roots := []*claim.Root{
+		newClaim("2024-05-10 10:00:00 +0000 UTC", "2024-05-10 11:00:00 +0000 UTC",
+			map[string]string{"tc1": "passed", "tc2": "not-run", "tc3": "skipped"}),
+		newClaim("2024-05-10 09:00:00 +0000 UTC", "2024-05-10 10:30:00 +0000 UTC",
+			map[string]string{"tc1": "not-run", "tc2": "failed", "tc3": "skipped", "tc4": "passed"}),
+	}
+
+	merged, err := Merge(files, roots, ConflictPolicyFail, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "passed", merged.Claim.Results["tc1"].State)
+	assert.Equal(t, "failed", merged.Claim.Results["tc2"].State)
+	assert.Equal(t, "skipped", merged.Claim.Results["tc3"].State)
+	assert.Equal(t, "passed", merged.Claim.Results["tc4"].State)
+	assert.Equal(t, "2024-05-10 09:00:00 +0000 UTC", merged.Claim.Metadata.StartTime)
+	assert.Equal(t, "2024-05-10 11:00:00 +0000 UTC", merged.Claim.Metadata.EndTime)
+
+	provenance := merged.Claim.Configurations[ProvenanceKey].(Provenance)
+	assert.Equal(t, map[string]string{"tc1": "a.json", "tc2": "b.json", "tc3": "a.json", "tc4": "b.json"}, provenance.Results)
+	assert.Equal(t, Source{File: "b.json", StartTime: "2024-05-10 09:00:00 +0000 UTC", EndTime: "2024-05-10 10:30:00 +0000 UTC"},
+		provenance.Sources[1])
+}
+
+func TestMergeConflicts(t *testing.T) {
+	files := []string{"a.json", "b.json", "c.json"}
+	newRoots := func() []*claim.Root {
+		return []*claim.Root{
+			newClaim("", "", map[string]string{"tc1": "passed"}),
+			newClaim("", "", map[string]string{"tc1": "failed"}),
+			newClaim("", "", map[string]string{"tc1": "error"}),
+		}
+	}
+
+	_, err := Merge(files, newRoots(), ConflictPolicyFail, false)
+	assert.EqualError(t, err, "conflicting results for 2 test case(s): tc1 (passed in a.json, failed in b.json), tc1 (passed in a.json, error in c.json)")
+
+	testCases := []struct {
+		policy             string
+		expectedState      string
+		expectedProvenance string
+	}{
+		{policy: ConflictPolicyFirst, expectedState: "passed", expectedProvenance: "a.json"},
+		{policy: ConflictPolicyLast, expectedState: "error", expectedProvenance: "c.json"},
+		{policy: ConflictPolicyWorst, expectedState: "failed", expectedProvenance: "b.json"},
+	}
+
+	for _, tc := range testCases {
+		merged, err := Merge(files, newRoots(), tc.policy, false)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectedState, merged.Claim.Results["tc1"].State)
+		assert.Equal(t, tc.expectedProvenance, merged.Claim.Configurations[ProvenanceKey].(Provenance).Results["tc1"])
+	}
+
+	_, err = Merge(files, newRoots(), "random", false)
+	assert.NotNil(t, err)
+}
+
+func TestMergeMismatches(t *testing.T) {
+	files := []string{"a.json", "b.json"}
+	newRoots := func() []*claim.Root {
+		roots := []*claim.Root{
+			newClaim("", "", map[string]string{"tc1": "passed"}),
+			newClaim("", "", map[string]string{"tc2": "passed"}),
+		}
+		roots[1].Claim.Versions.Tnf = "v5.4.0"
+		roots[1].Claim.Nodes["nodeSummary"] = map[string]interface{}{"node2": map[string]interface{}{}}
+		return roots
+	}
+
+	_, err := Merge(files, newRoots(), ConflictPolicyFail, false)
+	assert.EqualError(t, err, "claim files a.json and b.json have different versions, nodes")
+
+	merged, err := Merge(files, newRoots(), ConflictPolicyFail, true)
+	assert.Nil(t, err)
+	assert.Len(t, merged.Claim.Results, 2)
+	assert.Equal(t, "v5.3.0", merged.Claim.Versions.Tnf)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package migrate
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/spf13/cobra"
+)
+
+const outputFilePerms = 0o644
+
+var (
+	toVersionFlag  string
+	outputFileFlag string
+
+	migrateCommand = &cobra.Command{
+		Use:   "migrate <claim-file>",
+		Short: "Migrates a claim file to a newer claim format version.",
+		Long: `Migrates a claim file produced by an older certsuite version to a newer claim format version, by default the
+one supported by the claim tools (compare, show, report...). The migrated claim is validated against the schema of
+the target version before it's written.`,
+		Example: `./certsuite claim migrate old-claim.json -o claim.json
+./certsuite claim migrate old-claim.json --to ` + claim.SupportedClaimFormatVersion + ` -o claim.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: migrateClaim,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	migrateCommand.Flags().StringVarP(&outputFileFlag, "output", "o", "",
+		"Required: migrated claim file path.",
+	)
+
+	err := migrateCommand.MarkFlagRequired("output")
+	if err != nil {
+		log.Fatalf("Failed to mark output file path as required parameter: %v", err)
+		return nil
+	}
+
+	migrateCommand.Flags().StringVar(&toVersionFlag, "to", claim.SupportedClaimFormatVersion,
+		fmt.Sprintf("Optional: target claim format version. Known versions: %v", claim.GetFormatVersions()),
+	)
+
+	return migrateCommand
+}
+
+func migrateClaim(_ *cobra.Command, args []string) error {
+	claimFile := args[0]
+	content, err := os.ReadFile(claimFile)
+	if err != nil {
+		return fmt.Errorf("failed to read claim file %s: %v", claimFile, err)
+	}
+
+	fromVersion, err := claim.GetFormatVersion(content)
+	if err != nil {
+		return fmt.Errorf("failed to get the format version of claim file %s: %v", claimFile, err)
+	}
+
+	migrated, err := claim.Migrate(content, toVersionFlag)
+	if err != nil {
+		return fmt.Errorf("failed to migrate claim file %s: %v", claimFile, err)
+	}
+
+	if err := os.WriteFile(outputFileFlag, migrated, outputFilePerms); err != nil {
+		return fmt.Errorf("failed to write the migrated claim file %s: %v", outputFileFlag, err)
+	}
+
+	fmt.Printf("Claim file %s migrated from format version %s to %s into %s\n", claimFile, fromVersion, toVersionFlag, outputFileFlag)
+	return nil
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter expressions grammar:
+//
+//	expression = and { ("or" | "||") and }
+//	and        = not { ("and" | "&&") not }
+//	not        = ("not" | "!") not | "(" expression ")" | comparison
+//	comparison = field operator value | field ["not"] "in" "(" value { "," value } ")"
+//	operator   = "==" | "!=" | "=~" | "!~" | "<" | "<=" | ">" | ">=" | "contains"
+//
+// Values are bare words or single or double quoted strings. The keywords are case insensitive.
+
+const (
+	tokenWord = iota
+	tokenString
+	tokenOperator
+	tokenEnd
+)
+
+type token struct {
+	kind  int
+	value string
+	pos   int
+}
+
+// Expression is a parsed filter expression.
+type Expression interface {
+	Match(row Row) bool
+	fields() []string
+}
+
+type orExpression struct{ left, right Expression }
+
+func (e *orExpression) Match(row Row) bool { return e.left.Match(row) || e.right.Match(row) }
+func (e *orExpression) fields() []string   { return append(e.left.fields(), e.right.fields()...) }
+
+type andExpression struct{ left, right Expression }
+
+func (e *andExpression) Match(row Row) bool { return e.left.Match(row) && e.right.Match(row) }
+func (e *andExpression) fields() []string   { return append(e.left.fields(), e.right.fields()...) }
+
+type notExpression struct{ expr Expression }
+
+func (e *notExpression) Match(row Row) bool { return !e.expr.Match(row) }
+func (e *notExpression) fields() []string   { return e.expr.fields() }
+
+type comparison struct {
+	field    string
+	operator string
+	values   []string
+	regex    *regexp.Regexp
+}
+
+func (c *comparison) fields() []string { return []string{c.field} }
+
+func (c *comparison) Match(row Row) bool {
+	value := row[c.field]
+	switch c.operator {
+	case "==":
+		return compare(value, c.values[0]) == 0
+	case "!=":
+		return compare(value, c.values[0]) != 0
+	case "=~":
+		return c.regex.MatchString(value)
+	case "!~":
+		return !c.regex.MatchString(value)
+	case "<":
+		return compare(value, c.values[0]) < 0
+	case "<=":
+		return compare(value, c.values[0]) <= 0
+	case ">":
+		return compare(value, c.values[0]) > 0
+	case ">=":
+		return compare(value, c.values[0]) >= 0
+	case "contains":
+		return strings.Contains(value, c.values[0])
+	case "in", "not in":
+		found := false
+		for _, v := range c.values {
+			if compare(value, v) == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (c.operator == "in")
+	}
+	return false
+}
+
+// compare compares both values as numbers if they're numbers, or as strings otherwise.
+func compare(a, b string) int {
+	numA, errA := strconv.ParseFloat(a, 64)
+	numB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func isWordChar(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()!=<>~,&|'"`, r)
+}
+
+func tokenize(input string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var value strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, value: value.String(), pos: start})
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, token{kind: tokenOperator, value: string(r), pos: i})
+			i++
+		case strings.ContainsRune("=!<>~&|", r):
+			start := i
+			for i < len(runes) && strings.ContainsRune("=!<>~&|", runes[i]) && i-start < 2 {
+				i++
+			}
+			op := string(runes[start:i])
+			switch op {
+			case "==", "!=", "=~", "!~", "<", "<=", ">", ">=", "&&", "||", "!":
+			default:
+				// Two symbols that are not an operator, like "!(" or "<!", the first one is alone.
+				if op[0] != '!' && op[0] != '<' && op[0] != '>' {
+					return nil, fmt.Errorf("invalid operator %q at position %d", op, start+1)
+				}
+				i = start + 1
+				op = op[:1]
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: start})
+		default:
+			start := i
+			for i < len(runes) && isWordChar(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i]), pos: start})
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the token is one of the keywords or operators.
+func (t token) isKeyword(keywords ...string) bool {
+	if t.kind != tokenWord && t.kind != tokenOperator {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(t.value, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.value)
+	default:
+		return fmt.Sprintf("%q at position %d", t.value, t.pos+1)
+	}
+}
+
+// ParseExpression parses a filter expression.
+func ParseExpression(input string) (Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return expr, nil
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or", "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and", "&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expression, error) {
+	t := p.peek()
+	switch {
+	case t.isKeyword("not", "!"):
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{expr: expr}, nil
+	case t.isKeyword("("):
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); !t.isKeyword(")") {
+			return nil, fmt.Errorf("expected \")\" instead of %s", t)
+		}
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseValue() (string, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return "", fmt.Errorf("expected a value instead of %s", t)
+	}
+	return t.value, nil
+}
+
+func (p *parser) parseComparison() (Expression, error) {
+	t := p.next()
+	if t.kind != tokenWord || t.isKeyword("and", "or", "not", "in", "contains") {
+		return nil, fmt.Errorf("expected a field name instead of %s", t)
+	}
+	c := &comparison{field: t.value}
+
+	op := p.next()
+	switch {
+	case op.isKeyword("==", "!=", "=~", "!~", "<", "<=", ">", ">=", "contains"):
+		c.operator = strings.ToLower(op.value)
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.values = []string{value}
+	case op.isKeyword("in"), op.isKeyword("not") && p.peek().isKeyword("in"):
+		c.operator = "in"
+		if op.isKeyword("not") {
+			p.next()
+			c.operator = "not in"
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		c.values = values
+	default:
+		return nil, fmt.Errorf("expected an operator after field %q instead of %s", c.field, op)
+	}
+
+	if c.operator == "=~" || c.operator == "!~" {
+		regex, err := regexp.Compile(c.values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", c.values[0], err)
+		}
+		c.regex = regex
+	}
+
+	return c, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if t := p.next(); !t.isKeyword("(") {
+		return nil, fmt.Errorf("expected \"(\" instead of %s", t)
+	}
+	values := []string{}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		t := p.next()
+		if t.isKeyword(")") {
+			return values, nil
+		}
+		if !t.isKeyword(",") {
+			return nil, fmt.Errorf("expected \",\" or \")\" instead of %s", t)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	row := Row{"id": "access-control-sys-admin-capability-check", "state": "failed", "telco": "Mandatory",
+		"duration": "12", "namespace": "tnf", "reason": "Non compliant capability detected in container"}
+
+	testCases := []struct {
+		expression    string
+		expectedMatch bool
+	}{
+		{expression: "state == failed", expectedMatch: true},
+		{expression: "state != failed", expectedMatch: false},
+		{expression: `state == "failed" and telco == 'Mandatory'`, expectedMatch: true},
+		{expression: "state == passed or telco == Mandatory", expectedMatch: true},
+		{expression: "state == passed || telco == Optional", expectedMatch: false},
+		{expression: "STATE == failed && namespace == tnf", expectedMatch: false},
+		{expression: "state == failed AND NOT namespace == other", expectedMatch: true},
+		{expression: "!(state == failed)", expectedMatch: false},
+		{expression: "state == passed and telco == Mandatory or namespace == tnf", expectedMatch: true},
+		{expression: "state == passed and (telco == Mandatory or namespace == tnf)", expectedMatch: false},
+		{expression: "id =~ ^access-control-", expectedMatch: true},
+		{expression: `id !~ "^lifecycle-"`, expectedMatch: true},
+		{expression: "duration > 9", expectedMatch: true},
+		{expression: "duration <= 9", expectedMatch: false},
+		{expression: "duration == 12.0", expectedMatch: true},
+		{expression: "reason contains capability", expectedMatch: true},
+		{expression: "state in (passed, failed)", expectedMatch: true},
+		{expression: "state not in (passed, skipped)", expectedMatch: true},
+		{expression: "containerName == ''", expectedMatch: true},
+	}
+
+	for _, tc := range testCases {
+		expr, err := ParseExpression(tc.expression)
+		assert.Nil(t, err, tc.expression)
+		assert.Equal(t, tc.expectedMatch, expr.Match(row), tc.expression)
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	testCases := []struct {
+		expression    string
+		expectedError string
+	}{
+		{expression: "state", expectedError: `expected an operator after field "state" instead of end of expression`},
+		{expression: "state = failed", expectedError: `invalid operator "=" at position 7`},
+		{expression: "state == failed and", expectedError: "expected a field name instead of end of expression"},
+		{expression: "(state == failed", expectedError: `expected ")" instead of end of expression`},
+		{expression: "state == failed)", expectedError: `unexpected ")" at position 16`},
+		{expression: `state == "failed`, expectedError: "unterminated string at position 10"},
+		{expression: "state in passed", expectedError: `expected "(" instead of "passed" at position 10`},
+		{expression: "state in (passed failed)", expectedError: `expected "," or ")" instead of "failed" at position 18`},
+		{expression: "id =~ (", expectedError: `expected a value instead of "(" at position 7`},
+		{expression: "id =~ '['", expectedError: "invalid regular expression \"[\": error parsing regexp: missing closing ]: `[`"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseExpression(tc.expression)
+		if assert.NotNil(t, err, tc.expression) {
+			assert.Equal(t, tc.expectedError, err.Error(), tc.expression)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/spf13/cobra"
+)
+
+const (
+	OutputFormatTable = "table"
+	OutputFormatCSV   = "csv"
+	OutputFormatJSON  = "json"
+
+	// Kinds of rows the results are flattened into.
+	RowsTestCases = "testcases"
+	RowsObjects   = "objects"
+
+	// Column with all the fields of a non-compliant object.
+	objectFieldsColumn = "fields"
+)
+
+var (
+	OutputFormats = []string{OutputFormatTable, OutputFormatCSV, OutputFormatJSON}
+	RowKinds      = []string{RowsTestCases, RowsObjects}
+
+	// Fields of the test case rows. The rows have a field for each category classification of the test case too.
+	testCaseFields = []string{"id", "suite", "state", "tags", "description", "remediation", "bestPracticeReference",
+		"exceptionProcess", "skipReason", "duration", "startTime", "endTime", "compliantObjects", "nonCompliantObjects"}
+	// Fields of the non-compliant object rows, added to the fields of their test case. The rows have a field for each
+	// field of the object too, e.g. "Pod Name" is podName.
+	objectFields = []string{"objectType", "reason", objectFieldsColumn}
+
+	defaultColumns = map[string][]string{
+		RowsTestCases: {"id", "state", "telco", "nonCompliantObjects"},
+		RowsObjects:   {"id", "objectType", "reason", objectFieldsColumn},
+	}
+)
+
+// Row is a test case or a non-compliant object of a test case, by field name.
+type Row map[string]string
+
+var (
+	filterFlag  string
+	columnsFlag string
+	rowsFlag    string
+	formatFlag  string
+
+	claimQueryCmd = &cobra.Command{
+		Use:   "query <claim-file>",
+		Short: "Queries the results of a claim file.",
+		Long: `Flattens the results of a claim file into rows, one per test case or one per non-compliant object of the
+test cases, and shows the rows that match a filter expression.
+
+Test case rows have these fields: ` + strings.Join(testCaseFields, ", ") + `, and one field per category
+classification of the test case: telco, nonTelco, farEdge and extended. Non-compliant object rows have the fields of
+their test case plus ` + strings.Join(objectFields, ", ") + ` and one field per field of the object, in camel case,
+e.g. namespace, podName or containerName.
+
+The filter expression compares fields with values, using the operators ==, !=, =~ and !~ (regular expressions),
+<, <=, >, >= (numbers or strings), contains, in and not in, and combines the comparisons with and, or, not and
+parentheses. Values with spaces or symbols must be quoted.`,
+		Example: `./certsuite claim query claim.json --filter 'state == failed and telco == Mandatory'
+./certsuite claim query claim.json --rows objects --filter 'state == failed and telco == Mandatory and objectType == Container and namespace == tnf' --columns id,podName,containerName,reason
+./certsuite claim query claim.json --filter 'suite in (lifecycle, networking) and duration > 10' --format csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: queryClaim,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimQueryCmd.Flags().StringVar(&filterFlag, "filter", "",
+		"Optional: filter expression. All the rows are shown by default.",
+	)
+	claimQueryCmd.Flags().StringVar(&columnsFlag, "columns", "",
+		"Optional: comma separated list of the fields to show. Default: "+
+			fmt.Sprintf("%v for test case rows, %v for non-compliant object rows.", defaultColumns[RowsTestCases], defaultColumns[RowsObjects]),
+	)
+	claimQueryCmd.Flags().StringVar(&rowsFlag, "rows", RowsTestCases,
+		fmt.Sprintf("Optional: kind of rows. Available kinds: %v", RowKinds),
+	)
+	claimQueryCmd.Flags().StringVarP(&formatFlag, "format", "f", OutputFormatTable,
+		fmt.Sprintf("Optional: output format. Available formats: %v", OutputFormats),
+	)
+
+	return claimQueryCmd
+}
+
+func queryClaim(_ *cobra.Command, args []string) error {
+	claimScheme, err := claim.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %v", args[0], err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{}
+	if columnsFlag != "" {
+		for _, column := range strings.Split(columnsFlag, ",") {
+			columns = append(columns, strings.TrimSpace(column))
+		}
+	}
+
+	rows, columns, err := Query(claimScheme, rowsFlag, filterFlag, columns)
+	if err != nil {
+		return err
+	}
+
+	switch formatFlag {
+	case OutputFormatTable:
+		return WriteTable(os.Stdout, rows, columns)
+	case OutputFormatCSV:
+		return WriteCSV(os.Stdout, rows, columns)
+	case OutputFormatJSON:
+		return WriteJSON(os.Stdout, rows, columns)
+	default:
+		return fmt.Errorf("invalid output format %q - available formats: %v", formatFlag, OutputFormats)
+	}
+}
+
+// Query returns the rows of the given kind that match the filter expression, and the columns to show. The filter
+// and the columns can only use the fields of that kind of rows.
+func Query(claimScheme *claim.Schema, rowsKind, filter string, columns []string) ([]Row, []string, error) {
+	var rows []Row
+	switch rowsKind {
+	case RowsTestCases:
+		rows = GetTestCaseRows(claimScheme)
+	case RowsObjects:
+		rows = GetObjectRows(claimScheme)
+	default:
+		return nil, nil, fmt.Errorf("invalid kind of rows %q - available kinds: %v", rowsKind, RowKinds)
+	}
+
+	knownFields := getKnownFields(rowsKind, rows)
+	if len(columns) == 0 {
+		columns = defaultColumns[rowsKind]
+	}
+	for _, column := range columns {
+		if !knownFields[column] {
+			return nil, nil, fmt.Errorf("unknown column %q - available fields: %v", column, getSortedFields(knownFields))
+		}
+	}
+
+	if filter == "" {
+		return rows, columns, nil
+	}
+
+	expr, err := ParseExpression(filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid filter expression: %v", err)
+	}
+	for _, field := range expr.fields() {
+		if !knownFields[field] {
+			return nil, nil, fmt.Errorf("unknown field %q in the filter expression - available fields: %v", field, getSortedFields(knownFields))
+		}
+	}
+
+	matchingRows := []Row{}
+	for _, row := range rows {
+		if expr.Match(row) {
+			matchingRows = append(matchingRows, row)
+		}
+	}
+
+	return matchingRows, columns, nil
+}
+
+// getKnownFields returns the fixed fields of the kind of rows, the category classification fields and, for the
+// object rows, the fields of the objects found in the claim.
+func getKnownFields(rowsKind string, rows []Row) map[string]bool {
+	known := map[string]bool{"telco": true, "nonTelco": true, "farEdge": true, "extended": true}
+	for _, field := range testCaseFields {
+		known[field] = true
+	}
+	if rowsKind == RowsObjects {
+		for _, field := range objectFields {
+			known[field] = true
+		}
+	}
+	for _, row := range rows {
+		for field := range row {
+			known[field] = true
+		}
+	}
+	return known
+}
+
+func getSortedFields(fields map[string]bool) []string {
+	sorted := []string{}
+	for field := range fields {
+		sorted = append(sorted, field)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// toCamelCase converts a category or object field name like "Pod Name", "SCC Capability" or "NonTelco" to a field
+// name like podName, sccCapability or nonTelco.
+func toCamelCase(name string) string {
+	var camelCase strings.Builder
+	for i, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(word)
+		switch {
+		case i == 0 && strings.ToUpper(word) == word:
+			runes = []rune(strings.ToLower(word))
+		case i == 0:
+			runes[0] = unicode.ToLower(runes[0])
+		default:
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		camelCase.WriteString(string(runes))
+	}
+	return camelCase.String()
+}
+
+func getCheckDetails(checkDetails string) *testhelper.FailureReasonOut {
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(checkDetails), &details); err != nil {
+		return &testhelper.FailureReasonOut{}
+	}
+	return &details
+}
+
+func getSortedTestIDs(claimScheme *claim.Schema) []string {
+	testIDs := []string{}
+	for testID := range claimScheme.Claim.Results {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Slice(testIDs, func(i, j int) bool {
+		suiteI := claimScheme.Claim.Results[testIDs[i]].TestID.Suite
+		suiteJ := claimScheme.Claim.Results[testIDs[j]].TestID.Suite
+		if suiteI != suiteJ {
+			return suiteI < suiteJ
+		}
+		return testIDs[i] < testIDs[j]
+	})
+	return testIDs
+}
+
+func getTestCaseRow(testID string, result *claim.TestCaseResult) Row {
+	details := getCheckDetails(result.CheckDetails)
+	row := Row{
+		"id":                    testID,
+		"suite":                 result.TestID.Suite,
+		"state":                 result.State,
+		"tags":                  result.TestID.Tags,
+		"description":           result.CatalogInfo.Description,
+		"remediation":           result.CatalogInfo.Remediation,
+		"bestPracticeReference": result.CatalogInfo.BestPracticeReference,
+		"exceptionProcess":      result.CatalogInfo.ExceptionProcess,
+		"skipReason":            result.SkipReason,
+		"duration":              strconv.Itoa(result.Duration),
+		"startTime":             result.StartTime,
+		"endTime":               result.EndTime,
+		"compliantObjects":      strconv.Itoa(len(details.CompliantObjectsOut)),
+		"nonCompliantObjects":   strconv.Itoa(len(details.NonCompliantObjectsOut)),
+	}
+	for category, classification := range result.CategoryClassification {
+		row[toCamelCase(category)] = classification
+	}
+	return row
+}
+
+// GetTestCaseRows returns a row per test case of the claim, sorted by suite and id.
+func GetTestCaseRows(claimScheme *claim.Schema) []Row {
+	rows := []Row{}
+	for _, testID := range getSortedTestIDs(claimScheme) {
+		result := claimScheme.Claim.Results[testID]
+		rows = append(rows, getTestCaseRow(testID, &result))
+	}
+	return rows
+}
+
+// GetObjectRows returns a row per non-compliant object of the test cases of the claim, with the fields of the test
+// case too.
+func GetObjectRows(claimScheme *claim.Schema) []Row {
+	rows := []Row{}
+	for _, testID := range getSortedTestIDs(claimScheme) {
+		result := claimScheme.Claim.Results[testID]
+		testCaseRow := getTestCaseRow(testID, &result)
+		for _, object := range getCheckDetails(result.CheckDetails).NonCompliantObjectsOut {
+			row := Row{}
+			for field, value := range testCaseRow {
+				row[field] = value
+			}
+			row["objectType"] = object.ObjectType
+
+			// The first field is the reason of the non-compliance.
+			fields := []string{}
+			for i := range object.ObjectFieldsKeys {
+				if i >= len(object.ObjectFieldsValues) {
+					break
+				}
+				if i == 0 {
+					row["reason"] = object.ObjectFieldsValues[i]
+					continue
+				}
+				// The fields of the test case are not overwritten.
+				field := toCamelCase(object.ObjectFieldsKeys[i])
+				if _, found := testCaseRow[field]; !found && field != "" {
+					row[field] = object.ObjectFieldsValues[i]
+				}
+				fields = append(fields, object.ObjectFieldsKeys[i]+": "+object.ObjectFieldsValues[i])
+			}
+			row[objectFieldsColumn] = strings.Join(fields, ", ")
+
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// WriteTable writes the columns of the rows in a table, followed by the number of rows.
+func WriteTable(w io.Writer, rows []Row, columns []string) error {
+	const tabPadding = 3
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	header := []string{}
+	for _, column := range columns {
+		header = append(header, strings.ToUpper(column))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		values := []string{}
+		for _, column := range columns {
+			// Keep the table aligned with multiline values.
+			values = append(values, strings.Join(strings.Fields(row[column]), " "))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%d rows\n", len(rows))
+	return nil
+}
+
+// WriteCSV writes the columns of the rows in CSV format, with a header.
+func WriteCSV(w io.Writer, rows []Row, columns []string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		values := []string{}
+		for _, column := range columns {
+			values = append(values, row[column])
+		}
+		if err := writer.Write(values); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the columns of the rows as a list of json objects.
+func WriteJSON(w io.Writer, rows []Row, columns []string) error {
+	objects := []map[string]string{}
+	for _, row := range rows {
+		object := map[string]string{}
+		for _, column := range columns {
+			object[column] = row[column]
+		}
+		objects = append(objects, object)
+	}
+
+	payload, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the query results: %v", err)
+	}
+	_, err = fmt.Fprintln(w, string(payload))
+	return err
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResult(t *testing.T, suite, state, telco string, compliant, nonCompliant []*testhelper.ReportObject) claim.TestCaseResult {
+	result := claim.TestCaseResult{State: state, Duration: 2}
+	result.TestID.Suite = suite
+	result.CategoryClassification = map[string]string{"Telco": telco, "NonTelco": "Optional"}
+	if compliant != nil || nonCompliant != nil {
+		checkDetails, err := json.Marshal(testhelper.FailureReasonOut{CompliantObjectsOut: compliant, NonCompliantObjectsOut: nonCompliant})
+		assert.Nil(t, err)
+		result.CheckDetails = string(checkDetails)
+	}
+	return result
+}
+
+func getTestClaim(t *testing.T) *claim.Schema {
+	schema := claim.Schema{}
+	schema.Claim.Results = claim.TestSuiteResults{
+		"lifecycle-pod-owner-type": newResult(t, "lifecycle", claim.TestCaseResultFailed, "Mandatory", nil,
+			[]*testhelper.ReportObject{testhelper.NewPodReportObject("other", "pod1", "Pod has no owner", false)}),
+		"access-control-sys-admin-capability-check": newResult(t, "access-control", claim.TestCaseResultFailed, "Mandatory",
+			[]*testhelper.ReportObject{testhelper.NewContainerReportObject("tnf", "pod1", "c1", "No SYS_ADMIN", true)},
+			[]*testhelper.ReportObject{
+				testhelper.NewContainerReportObject("tnf", "pod1", "c2", "SYS_ADMIN detected", false).
+					AddField(testhelper.SCCCapability, "SYS_ADMIN"),
+				testhelper.NewContainerReportObject("other", "pod2", "c1", "SYS_ADMIN detected", false),
+			}),
+		"access-control-namespace": newResult(t, "access-control", claim.TestCaseResultPassed, "Optional", nil, nil),
+	}
+	return &schema
+}
+
+func TestQueryTestCases(t *testing.T) {
+	schema := getTestClaim(t)
+
+	rows, columns, err := Query(schema, RowsTestCases, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultColumns[RowsTestCases], columns)
+	// Sorted by suite and id.
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "access-control-namespace", rows[0]["id"])
+	assert.Equal(t, "access-control-sys-admin-capability-check", rows[1]["id"])
+	assert.Equal(t, "lifecycle-pod-owner-type", rows[2]["id"])
+	assert.Equal(t, "1", rows[1]["compliantObjects"])
+	assert.Equal(t, "2", rows[1]["nonCompliantObjects"])
+	assert.Equal(t, "Optional", rows[1]["nonTelco"])
+
+	rows, columns, err = Query(schema, RowsTestCases, "state == failed and telco == Mandatory and nonCompliantObjects > 1", []string{"id", "suite"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "suite"}, columns)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "access-control", rows[0]["suite"])
+
+	_, _, err = Query(schema, RowsTestCases, "namespace == tnf", nil)
+	assert.NotNil(t, err)
+	_, _, err = Query(schema, RowsTestCases, "", []string{"podName"})
+	assert.NotNil(t, err)
+	_, _, err = Query(schema, "pods", "", nil)
+	assert.NotNil(t, err)
+}
+
+func TestQueryObjects(t *testing.T) {
+	schema := getTestClaim(t)
+
+	rows, _, err := Query(schema, RowsObjects, "", nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, Row{
+		"id": "access-control-sys-admin-capability-check", "suite": "access-control", "state": "failed", "tags": "",
+		"description": "", "remediation": "", "bestPracticeReference": "", "exceptionProcess": "", "skipReason": "",
+		"duration": "2", "startTime": "", "endTime": "", "compliantObjects": "1", "nonCompliantObjects": "2",
+		"telco": "Mandatory", "nonTelco": "Optional", "objectType": "Container", "reason": "SYS_ADMIN detected",
+		"namespace": "tnf", "podName": "pod1", "containerName": "c2", "sccCapability": "SYS_ADMIN",
+		"fields": "Namespace: tnf, Pod Name: pod1, Container Name: c2, SCC Capability: SYS_ADMIN",
+	}, rows[0])
+
+	rows, _, err = Query(schema, RowsObjects, "telco == Mandatory and objectType == Container and namespace == other", []string{"id", "podName"})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "pod2", rows[0]["podName"])
+}
+
+func TestWriteRows(t *testing.T) {
+	rows := []Row{
+		{"id": "test1", "state": "failed", "reason": "multi\nline"},
+		{"id": "test2", "state": "passed"},
+	}
+	columns := []string{"id", "reason"}
+
+	var out bytes.Buffer
+	assert.Nil(t, WriteTable(&out, rows, columns))
+	assert.Equal(t, "ID      REASON\ntest1   multi line\ntest2   \n\n2 rows\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, WriteCSV(&out, rows, columns))
+	assert.Equal(t, "id,reason\ntest1,\"multi\nline\"\ntest2,\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, WriteJSON(&out, rows, columns))
+	assert.JSONEq(t, `[{"id": "test1", "reason": "multi\nline"}, {"id": "test2", "reason": ""}]`, out.String())
+}
+
+func TestToCamelCase(t *testing.T) {
+	assert.Equal(t, "podName", toCamelCase("Pod Name"))
+	assert.Equal(t, "nonTelco", toCamelCase("NonTelco"))
+	assert.Equal(t, "sccCapability", toCamelCase("SCC Capability"))
+	assert.Equal(t, "cpuPinningPolicy", toCamelCase("CPU pinning-policy"))
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package report
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/results"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claimFilePathFlag string
+	formatFlag        string
+	outputFlag        string
+
+	reportCommand = &cobra.Command{
+		Use:   "report",
+		Short: "Generates a standalone report from a claim file.",
+		Long: `Generates a single self-contained html or markdown file from a claim file, that can be attached to
+tickets or emails. The report contains a summary of the results, a table per test suite, the failed test cases
+with their non compliant objects, remediation and documentation links, and the nodes info.`,
+		Example: `./certsuite claim report --claim-file path/to/claim.json --format html --output report.html
+./certsuite claim report -c path/to/claim.json -f md -o report.md`,
+		RunE: createReport,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	reportCommand.Flags().StringVarP(&claimFilePathFlag, "claim-file", "c", "",
+		"Required: Existing claim file path.",
+	)
+
+	err := reportCommand.MarkFlagRequired("claim-file")
+	if err != nil {
+		log.Fatalf("Failed to mark claim file path as required parameter: %v", err)
+		return nil
+	}
+
+	reportCommand.Flags().StringVarP(&formatFlag, "format", "f", results.ReportFormatHTML,
+		fmt.Sprintf("Optional: report format. Available formats: %v", results.ReportFormats),
+	)
+
+	reportCommand.Flags().StringVarP(&outputFlag, "output", "o", "",
+		"Optional: report file path. Defaults to "+results.ReportFileName+".<format> in the current folder.",
+	)
+
+	return reportCommand
+}
+
+func createReport(_ *cobra.Command, _ []string) error {
+	outputFile := outputFlag
+	if outputFile == "" {
+		outputFile = results.ReportFileName + "." + formatFlag
+	}
+
+	err := results.CreateReportFile(claimFilePathFlag, outputFile, formatFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Report created at %s\n", outputFile)
+	return nil
+}
@@ -0,0 +1,410 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/spf13/cobra"
+)
+
+const (
+	OutputFormatText     = "text"
+	OutputFormatJSON     = "json"
+	OutputFormatMarkdown = "markdown"
+
+	CheckPassed = "passed"
+	CheckFailed = "failed"
+
+	replicaSetKind = "ReplicaSet"
+)
+
+var availableOutputFormats = []string{OutputFormatText, OutputFormatJSON, OutputFormatMarkdown}
+
+// The pod template hash suffix of the ReplicaSets created by a Deployment.
+var replicaSetHashRegex = regexp.MustCompile(`-[a-z0-9]+$`)
+
+// CheckResult is the result of a check for an object. A check fails for the object if the object is one of its
+// non-compliant objects.
+type CheckResult struct {
+	TestID  string   `json:"testID"`
+	Suite   string   `json:"suite"`
+	Result  string   `json:"result"`
+	Reasons []string `json:"reasons"`
+}
+
+// ObjectCompliance is the list of checks an object passed and failed.
+type ObjectCompliance struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name"`
+	Owner     string        `json:"owner,omitempty"`
+	Failed    []CheckResult `json:"failed"`
+	Passed    []CheckResult `json:"passed"`
+}
+
+func (o *ObjectCompliance) String() string {
+	if o.Namespace == "" {
+		return o.Kind + " " + o.Name
+	}
+	return o.Kind + " " + o.Namespace + "/" + o.Name
+}
+
+var (
+	claimFilePathFlag string
+	namespacesFlag    string
+	ownerFlag         string
+	outputFormatFlag  string
+
+	showObjectsCommand = &cobra.Command{
+		Use:   "objects",
+		Short: "Shows the checks passed and failed by each object of a claim file.",
+		Long: `Parses a claim.json file and shows, for every object found in the results of the test cases, the checks it
+passed and failed, with their reasons. The objects are the containers, pods, deployments, statefulsets, operators,
+CRDs and the rest of the objects with a name in the compliant and non-compliant objects of the test cases.
+
+The owner of the pods and their containers is their Deployment, StatefulSet or other controller, as found in the
+pods under test of the claim. Deployments and StatefulSets are their own owners.
+
+The objects can be filtered by a comma separated list of namespaces with "--namespaces", and by owner with
+"--owner", either as Kind/name or just the name. The output format can be text, json or markdown.`,
+		Example: `./certsuite claim show objects --claim path/to/claim.json --namespaces tnf
+Container tnf/test-887998557-8gwwm/test (owner: Deployment/test)
+  Failed: 1, Passed: 1
+  FAILED access-control-sys-admin-capability-check
+    Non compliant capability detected in container (SCC Capability: SYS_ADMIN)
+  PASSED access-control-sys-nice-realtime-capability
+    Container is not running on a realtime kernel enabled node
+
+./certsuite claim show objects --claim path/to/claim.json --owner Deployment/test --output markdown > test.md`,
+		RunE: showObjects,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	showObjectsCommand.Flags().StringVarP(&claimFilePathFlag, "claim", "c", "",
+		"Required: Existing claim file path.",
+	)
+
+	err := showObjectsCommand.MarkFlagRequired("claim")
+	if err != nil {
+		log.Fatalf("Failed to mark claim file path as required parameter: %v", err)
+		return nil
+	}
+
+	showObjectsCommand.Flags().StringVarP(&namespacesFlag, "namespaces", "n", "",
+		"Optional: comma separated list of namespaces whose objects will be shown.",
+	)
+	showObjectsCommand.Flags().StringVar(&ownerFlag, "owner", "",
+		"Optional: owner of the objects to show, as Kind/name or name, e.g. Deployment/test.",
+	)
+	showObjectsCommand.Flags().StringVarP(&outputFormatFlag, "output", "o", OutputFormatText,
+		fmt.Sprintf("Optional: output format. Available formats: %v", availableOutputFormats),
+	)
+
+	return showObjectsCommand
+}
+
+func showObjects(_ *cobra.Command, _ []string) error {
+	if !isValidOutputFormat(outputFormatFlag) {
+		return fmt.Errorf("invalid output format flag %q - available formats: %v", outputFormatFlag, availableOutputFormats)
+	}
+
+	claimScheme, err := claim.Parse(claimFilePathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %v", claimFilePathFlag, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return err
+	}
+
+	objects := FilterObjects(GetObjectsCompliance(claimScheme), parseNamespacesFlag(), ownerFlag)
+
+	switch outputFormatFlag {
+	case OutputFormatJSON:
+		return WriteJSON(os.Stdout, objects)
+	case OutputFormatMarkdown:
+		return WriteMarkdown(os.Stdout, objects)
+	default:
+		return WriteText(os.Stdout, objects)
+	}
+}
+
+func isValidOutputFormat(format string) bool {
+	for _, f := range availableOutputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNamespacesFlag() map[string]bool {
+	if namespacesFlag == "" {
+		return nil
+	}
+
+	namespaces := map[string]bool{}
+	for _, namespace := range strings.Split(namespacesFlag, ",") {
+		namespaces[strings.TrimSpace(namespace)] = true
+	}
+	return namespaces
+}
+
+// getPodOwners returns the owner of each pod under test, by namespace/name. Pods owned by a ReplicaSet are owned
+// by its Deployment.
+func getPodOwners(claimScheme *claim.Schema) map[string]string {
+	owners := map[string]string{}
+	for i := range claimScheme.Claim.Configurations.TestPods {
+		metadata := &claimScheme.Claim.Configurations.TestPods[i].Metadata
+		if len(metadata.OwnerReferences) == 0 {
+			continue
+		}
+		owner := metadata.OwnerReferences[0]
+		if owner.Kind == replicaSetKind {
+			owner = claim.OwnerReference{Kind: testhelper.DeploymentType, Name: replicaSetHashRegex.ReplaceAllString(owner.Name, "")}
+		}
+		owners[metadata.Namespace+"/"+metadata.Name] = owner.Kind + "/" + owner.Name
+	}
+	return owners
+}
+
+// getObjectIdentity returns the kind, namespace and name of a report object, or an empty name for the objects
+// that can't be identified, like the cluster version.
+func getObjectIdentity(object *testhelper.ReportObject) (kind, namespace, name string) {
+	fields := map[string]string{}
+	for i := range object.ObjectFieldsKeys {
+		if i < len(object.ObjectFieldsValues) {
+			fields[object.ObjectFieldsKeys[i]] = object.ObjectFieldsValues[i]
+		}
+	}
+
+	namespace = fields[testhelper.Namespace]
+	switch {
+	case fields[testhelper.PodName] != "" && fields[testhelper.ContainerName] != "":
+		return testhelper.ContainerType, namespace, fields[testhelper.PodName] + "/" + fields[testhelper.ContainerName]
+	case fields[testhelper.PodName] != "":
+		return testhelper.PodType, namespace, fields[testhelper.PodName]
+	case fields[testhelper.DeploymentName] != "":
+		return testhelper.DeploymentType, namespace, fields[testhelper.DeploymentName]
+	case fields[testhelper.StatefulSetName] != "":
+		return testhelper.StatefulSetType, namespace, fields[testhelper.StatefulSetName]
+	case fields[testhelper.CustomResourceDefinitionName] != "":
+		return testhelper.CustomResourceDefinitionType, "", fields[testhelper.CustomResourceDefinitionName]
+	case fields[testhelper.Name] != "":
+		return object.ObjectType, namespace, fields[testhelper.Name]
+	default:
+		return object.ObjectType, namespace, ""
+	}
+}
+
+// getReason returns the reason of a report object followed by its fields that are not part of its identity.
+func getReason(object *testhelper.ReportObject) string {
+	if len(object.ObjectFieldsValues) == 0 {
+		return ""
+	}
+
+	identityFields := map[string]bool{testhelper.Namespace: true, testhelper.Name: true, testhelper.PodName: true,
+		testhelper.ContainerName: true, testhelper.DeploymentName: true, testhelper.StatefulSetName: true,
+		testhelper.CustomResourceDefinitionName: true}
+	details := []string{}
+	for i := 1; i < len(object.ObjectFieldsKeys) && i < len(object.ObjectFieldsValues); i++ {
+		if !identityFields[object.ObjectFieldsKeys[i]] {
+			details = append(details, object.ObjectFieldsKeys[i]+": "+object.ObjectFieldsValues[i])
+		}
+	}
+
+	reason := object.ObjectFieldsValues[0]
+	if len(details) > 0 {
+		reason += " (" + strings.Join(details, ", ") + ")"
+	}
+	return reason
+}
+
+func appendReason(reasons []string, reason string) []string {
+	for _, r := range reasons {
+		if r == reason {
+			return reasons
+		}
+	}
+	return append(reasons, reason)
+}
+
+// GetObjectsCompliance inverts the results of the claim: it returns the checks passed and failed by each object
+// found in the compliant and non-compliant objects of the test cases, sorted by namespace, kind and name.
+func GetObjectsCompliance(claimScheme *claim.Schema) []*ObjectCompliance {
+	podOwners := getPodOwners(claimScheme)
+	objectsByID := map[string]*ObjectCompliance{}
+	// Results of the checks by object id and test id.
+	results := map[string]map[string]*CheckResult{}
+
+	testIDs := []string{}
+	for testID := range claimScheme.Claim.Results {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Strings(testIDs)
+
+	for _, testID := range testIDs {
+		result := claimScheme.Claim.Results[testID]
+		details := testhelper.FailureReasonOut{}
+		if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil {
+			continue
+		}
+
+		addObjects := func(reportObjects []*testhelper.ReportObject, checkResult string) {
+			for _, reportObject := range reportObjects {
+				kind, namespace, name := getObjectIdentity(reportObject)
+				if name == "" {
+					continue
+				}
+
+				id := kind + "/" + namespace + "/" + name
+				object, found := objectsByID[id]
+				if !found {
+					object = &ObjectCompliance{Kind: kind, Namespace: namespace, Name: name}
+					switch kind {
+					case testhelper.ContainerType, testhelper.PodType:
+						object.Owner = podOwners[namespace+"/"+strings.Split(name, "/")[0]]
+					case testhelper.DeploymentType, testhelper.StatefulSetType:
+						object.Owner = kind + "/" + name
+					}
+					objectsByID[id] = object
+					results[id] = map[string]*CheckResult{}
+				}
+
+				check, found := results[id][testID]
+				if !found {
+					check = &CheckResult{TestID: testID, Suite: result.TestID.Suite, Result: checkResult}
+					results[id][testID] = check
+				}
+				// A check fails for the object if it's non-compliant at least once, only those reasons are kept.
+				if checkResult == CheckFailed && check.Result == CheckPassed {
+					check.Result = CheckFailed
+					check.Reasons = nil
+				}
+				if checkResult == check.Result {
+					check.Reasons = appendReason(check.Reasons, getReason(reportObject))
+				}
+			}
+		}
+		addObjects(details.NonCompliantObjectsOut, CheckFailed)
+		addObjects(details.CompliantObjectsOut, CheckPassed)
+	}
+
+	objects := []*ObjectCompliance{}
+	for id, object := range objectsByID {
+		object.Failed, object.Passed = []CheckResult{}, []CheckResult{}
+		for _, testID := range testIDs {
+			check, found := results[id][testID]
+			switch {
+			case !found:
+			case check.Result == CheckFailed:
+				object.Failed = append(object.Failed, *check)
+			default:
+				object.Passed = append(object.Passed, *check)
+			}
+		}
+		objects = append(objects, object)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Namespace != objects[j].Namespace {
+			return objects[i].Namespace < objects[j].Namespace
+		}
+		if objects[i].Kind != objects[j].Kind {
+			return objects[i].Kind < objects[j].Kind
+		}
+		return objects[i].Name < objects[j].Name
+	})
+
+	return objects
+}
+
+// FilterObjects returns the objects in the namespaces, if any, and with the owner, if set. The owner can be
+// Kind/name or just the name.
+func FilterObjects(objects []*ObjectCompliance, namespaces map[string]bool, owner string) []*ObjectCompliance {
+	filtered := []*ObjectCompliance{}
+	for _, object := range objects {
+		if namespaces != nil && !namespaces[object.Namespace] {
+			continue
+		}
+		if owner != "" && object.Owner != owner && !strings.HasSuffix(object.Owner, "/"+owner) {
+			continue
+		}
+		filtered = append(filtered, object)
+	}
+	return filtered
+}
+
+func writeChecks(w io.Writer, checks []CheckResult) {
+	for _, check := range checks {
+		fmt.Fprintf(w, "  %s %s\n", strings.ToUpper(check.Result), check.TestID)
+		for _, reason := range check.Reasons {
+			fmt.Fprintf(w, "    %s\n", reason)
+		}
+	}
+}
+
+// WriteText writes the failed and passed checks of each object.
+func WriteText(w io.Writer, objects []*ObjectCompliance) error {
+	for _, object := range objects {
+		fmt.Fprint(w, object.String())
+		if object.Owner != "" {
+			fmt.Fprintf(w, " (owner: %s)", object.Owner)
+		}
+		fmt.Fprintf(w, "\n  Failed: %d, Passed: %d\n", len(object.Failed), len(object.Passed))
+		writeChecks(w, object.Failed)
+		writeChecks(w, object.Passed)
+	}
+	return nil
+}
+
+// WriteJSON writes the objects in json format.
+func WriteJSON(w io.Writer, objects []*ObjectCompliance) error {
+	type claimObjects struct {
+		Objects []*ObjectCompliance `json:"objects"`
+	}
+
+	payload, err := json.MarshalIndent(claimObjects{Objects: objects}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the objects: %v", err)
+	}
+	_, err = fmt.Fprintln(w, string(payload))
+	return err
+}
+
+func escapeMarkdown(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", `\|`)
+}
+
+// WriteMarkdown writes a section per object with a table of its failed and passed checks.
+func WriteMarkdown(w io.Writer, objects []*ObjectCompliance) error {
+	fmt.Fprintf(w, "# Objects compliance\n")
+	for _, object := range objects {
+		fmt.Fprintf(w, "\n## %s\n\n", escapeMarkdown(object.String()))
+		if object.Owner != "" {
+			fmt.Fprintf(w, "Owner: %s\n\n", escapeMarkdown(object.Owner))
+		}
+		fmt.Fprintf(w, "Failed: %d, Passed: %d\n\n", len(object.Failed), len(object.Passed))
+		fmt.Fprintf(w, "| Check | Suite | Result | Reasons |\n|---|---|---|---|\n")
+		for _, checks := range [][]CheckResult{object.Failed, object.Passed} {
+			for _, check := range checks {
+				reasons := []string{}
+				for _, reason := range check.Reasons {
+					reasons = append(reasons, escapeMarkdown(reason))
+				}
+				fmt.Fprintf(w, "| %s | %s | %s | %s |\n", check.TestID, check.Suite, check.Result, strings.Join(reasons, "<br>"))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResult(t *testing.T, suite string, compliant, nonCompliant []*testhelper.ReportObject) claim.TestCaseResult {
+	result := claim.TestCaseResult{State: claim.TestCaseResultPassed}
+	if len(nonCompliant) > 0 {
+		result.State = claim.TestCaseResultFailed
+	}
+	result.TestID.Suite = suite
+	checkDetails, err := json.Marshal(testhelper.FailureReasonOut{CompliantObjectsOut: compliant, NonCompliantObjectsOut: nonCompliant})
+	assert.Nil(t, err)
+	result.CheckDetails = string(checkDetails)
+	return result
+}
+
+func getTestClaim(t *testing.T) *claim.Schema {
+	schema := claim.Schema{}
+	pod := claim.TestPod{}
+	pod.Metadata.Name = "test-5d4f8b7c9-abcde"
+	pod.Metadata.Namespace = "tnf"
+	pod.Metadata.OwnerReferences = []claim.OwnerReference{{Kind: "ReplicaSet", Name: "test-5d4f8b7c9"}}
+	schema.Claim.Configurations.TestPods = []claim.TestPod{pod}
+
+	schema.Claim.Results = claim.TestSuiteResults{
+		"access-control-sys-admin-capability-check": newResult(t, "access-control",
+			[]*testhelper.ReportObject{testhelper.NewContainerReportObject("other", "db-0", "db", "No SYS_ADMIN", true)},
+			[]*testhelper.ReportObject{
+				testhelper.NewContainerReportObject("tnf", "test-5d4f8b7c9-abcde", "test", "Non compliant capability detected in container", false).
+					AddField(testhelper.SCCCapability, "SYS_ADMIN"),
+			}),
+		"access-control-requests-and-limits": newResult(t, "access-control",
+			[]*testhelper.ReportObject{
+				testhelper.NewContainerReportObject("tnf", "test-5d4f8b7c9-abcde", "test", "Container has resource requests and limits", true),
+				testhelper.NewContainerReportObject("other", "db-0", "db", "Container has resource requests and limits", true),
+			}, nil),
+		"lifecycle-pod-scheduling": newResult(t, "lifecycle",
+			[]*testhelper.ReportObject{
+				testhelper.NewDeploymentReportObject("tnf", "test", "Deployment has no node selector", true),
+				testhelper.NewCrdReportObject("crd.example.com", "v1", "CRD is fine", true),
+				testhelper.NewClusterVersionReportObject("4.14", "Cluster version is not identified", true),
+			}, nil),
+	}
+	return &schema
+}
+
+func TestGetObjectsCompliance(t *testing.T) {
+	objects := GetObjectsCompliance(getTestClaim(t))
+
+	names := []string{}
+	for _, object := range objects {
+		names = append(names, object.String())
+	}
+	// The cluster version is not an object with a name.
+	assert.Equal(t, []string{"Custom Resource Definition crd.example.com", "Container other/db-0/db", "Container tnf/test-5d4f8b7c9-abcde/test",
+		"Deployment tnf/test"}, names)
+
+	container := objects[2]
+	assert.Equal(t, "Deployment/test", container.Owner)
+	assert.Equal(t, []CheckResult{{TestID: "access-control-sys-admin-capability-check", Suite: "access-control", Result: CheckFailed,
+		Reasons: []string{"Non compliant capability detected in container (SCC Capability: SYS_ADMIN)"}}}, container.Failed)
+	assert.Equal(t, []CheckResult{{TestID: "access-control-requests-and-limits", Suite: "access-control", Result: CheckPassed,
+		Reasons: []string{"Container has resource requests and limits"}}}, container.Passed)
+
+	assert.Equal(t, "", objects[1].Owner)
+	assert.Len(t, objects[1].Passed, 2)
+	assert.Equal(t, "Deployment/test", objects[3].Owner)
+}
+
+func TestFilterObjects(t *testing.T) {
+	objects := GetObjectsCompliance(getTestClaim(t))
+
+	assert.Len(t, FilterObjects(objects, nil, ""), 4)
+	assert.Len(t, FilterObjects(objects, map[string]bool{"tnf": true}, ""), 2)
+	assert.Len(t, FilterObjects(objects, map[string]bool{"tnf": true, "other": true}, ""), 3)
+	assert.Len(t, FilterObjects(objects, nil, "Deployment/test"), 2)
+	assert.Len(t, FilterObjects(objects, nil, "test"), 2)
+	assert.Len(t, FilterObjects(objects, nil, "StatefulSet/test"), 0)
+}
+
+func TestWriteObjects(t *testing.T) {
+	objects := FilterObjects(GetObjectsCompliance(getTestClaim(t)), nil, "Deployment/test")
+
+	var out bytes.Buffer
+	assert.Nil(t, WriteText(&out, objects))
+	assert.Equal(t, `Container tnf/test-5d4f8b7c9-abcde/test (owner: Deployment/test)
+  Failed: 1, Passed: 1
+  FAILED access-control-sys-admin-capability-check
+    Non compliant capability detected in container (SCC Capability: SYS_ADMIN)
+  PASSED access-control-requests-and-limits
+    Container has resource requests and limits
+Deployment tnf/test (owner: Deployment/test)
+  Failed: 0, Passed: 1
+  PASSED lifecycle-pod-scheduling
+    Deployment has no node selector
+`, out.String())
+
+	out.Reset()
+	assert.Nil(t, WriteMarkdown(&out, objects[1:]))
+	assert.Equal(t, `# Objects compliance
+
+## Deployment tnf/test
+
+Owner: Deployment/test
+
+Failed: 0, Passed: 1
+
+| Check | Suite | Result | Reasons |
+|---|---|---|---|
+| lifecycle-pod-scheduling | lifecycle | passed | Deployment has no node selector |
+`, out.String())
+
+	out.Reset()
+	assert.Nil(t, WriteJSON(&out, objects[1:]))
+	assert.JSONEq(t, `{"objects": [{"kind": "Deployment", "namespace": "tnf", "name": "test", "owner": "Deployment/test", "failed": [],
+		"passed": [{"testID": "lifecycle-pod-scheduling", "suite": "lifecycle", "result": "passed", "reasons": ["Deployment has no node selector"]}]}]}`,
+		out.String())
+}
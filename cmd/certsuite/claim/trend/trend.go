@@ -0,0 +1,398 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package trend
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/spf13/cobra"
+)
+
+const (
+	OutputFormatText = "text"
+	OutputFormatCSV  = "csv"
+	OutputFormatJSON = "json"
+
+	// StateMissing is the state of a test case in the runs whose claim doesn't have it.
+	StateMissing = "missing"
+
+	// Layout of the test cases' start and end times, without the monotonic clock suffix.
+	resultTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+	// A test case is flaky when its result changes between passed and failed more than once.
+	flakyMinFlips = 2
+)
+
+var OutputFormats = []string{OutputFormatText, OutputFormatCSV, OutputFormatJSON}
+
+// Symbols of the states in the results history of the text output.
+var stateSymbols = map[string]string{
+	claim.TestCaseResultPassed:  "P",
+	claim.TestCaseResultFailed:  "F",
+	claim.TestCaseResultSkipped: "S",
+	"error":                     "E",
+	"aborted":                   "A",
+	StateMissing:                "-",
+}
+
+// Run is a claim file of the trend, the runs are sorted by start time.
+type Run struct {
+	File      string `json:"file"`
+	StartTime string `json:"startTime"`
+	start     time.Time
+}
+
+// RunResult is the result of a test case in a run.
+type RunResult struct {
+	State               string  `json:"state"`
+	DurationSeconds     float64 `json:"durationSeconds"`
+	NonCompliantObjects int     `json:"nonCompliantObjects"`
+}
+
+// TestTrend is the results history of a test case. The regression and fix fields hold the index of the
+// run where the test case first changed from passed to failed and from failed to passed, or -1.
+type TestTrend struct {
+	TestID          string      `json:"testID"`
+	Suite           string      `json:"suite"`
+	Results         []RunResult `json:"results"`
+	FirstRegression int         `json:"firstRegression"`
+	FirstFix        int         `json:"firstFix"`
+	Flips           int         `json:"flips"`
+	Flaky           bool        `json:"flaky"`
+}
+
+type Trend struct {
+	Runs  []Run       `json:"runs"`
+	Tests []TestTrend `json:"tests"`
+}
+
+var (
+	formatFlag string
+
+	claimTrendCmd = &cobra.Command{
+		Use:   "trend <claims-dir>",
+		Short: "Shows the results trend of the claim files in a folder.",
+		Long: `Shows the results history of each test case across the claim files found in a folder and its subfolders,
+e.g. the claims of nightly runs, sorted by their start time. For each test case it shows:
+ - The results history: P (passed), F (failed), S (skipped), E (error), A (aborted), - (not in the claim).
+ - The first run where it regressed (passed -> failed) and the first one where it was fixed (failed -> passed).
+ - The number of flips between passed and failed. Test cases with more than one flip are flaky.
+ - The duration and the number of non-compliant objects in the first and the last runs.
+
+The csv format has a row per test case and run, and the json format has the full history of each test case.`,
+		Example: `./certsuite claim trend nightly-claims/
+./certsuite claim trend nightly-claims/ --format csv > trend.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: showTrend,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimTrendCmd.Flags().StringVarP(&formatFlag, "format", "f", OutputFormatText,
+		fmt.Sprintf("Optional: output format. Available formats: %v", OutputFormats),
+	)
+
+	return claimTrendCmd
+}
+
+func showTrend(_ *cobra.Command, args []string) error {
+	trend, err := GetTrend(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch formatFlag {
+	case OutputFormatText:
+		return trend.WriteText(os.Stdout)
+	case OutputFormatCSV:
+		return trend.WriteCSV(os.Stdout)
+	case OutputFormatJSON:
+		return trend.WriteJSON(os.Stdout)
+	default:
+		return fmt.Errorf("invalid output format %q - available formats: %v", formatFlag, OutputFormats)
+	}
+}
+
+// parseStartTime parses the start time of the current and older claim files.
+func parseStartTime(startTime string) (time.Time, error) {
+	t, err := time.Parse(claimhelper.DateTimeFormatDirective, startTime)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, startTime)
+}
+
+// GetTrend parses the claim files in claimsDir and its subfolders. Json files that are not claims are skipped.
+func GetTrend(claimsDir string) (*Trend, error) {
+	claims := map[string]*claim.Schema{}
+	runs := []Run{}
+	err := filepath.WalkDir(claimsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		claimFile, err := claim.Parse(path)
+		if err != nil || claimFile.Claim.Results == nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: not a claim file\n", path)
+			return nil
+		}
+
+		run := Run{File: path, StartTime: claimFile.Claim.Metadata.StartTime}
+		run.start, _ = parseStartTime(run.StartTime)
+		runs = append(runs, run)
+		claims[path] = claimFile
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the claim files in %s: %v", claimsDir, err)
+	}
+
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no claim files found in %s", claimsDir)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if runs[i].start.Equal(runs[j].start) {
+			return runs[i].File < runs[j].File
+		}
+		return runs[i].start.Before(runs[j].start)
+	})
+
+	orderedClaims := []*claim.Schema{}
+	for _, run := range runs {
+		orderedClaims = append(orderedClaims, claims[run.File])
+	}
+
+	return &Trend{Runs: runs, Tests: getTestTrends(orderedClaims)}, nil
+}
+
+func getTestTrends(claims []*claim.Schema) []TestTrend {
+	suites := map[string]string{}
+	for _, claimFile := range claims {
+		for testID, result := range claimFile.Claim.Results {
+			suites[testID] = result.TestID.Suite
+		}
+	}
+
+	testIDs := []string{}
+	for testID := range suites {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Strings(testIDs)
+
+	trends := []TestTrend{}
+	for _, testID := range testIDs {
+		trend := TestTrend{TestID: testID, Suite: suites[testID], FirstRegression: -1, FirstFix: -1}
+		lastState := ""
+		for i, claimFile := range claims {
+			result, found := claimFile.Claim.Results[testID]
+			if !found {
+				trend.Results = append(trend.Results, RunResult{State: StateMissing})
+				continue
+			}
+
+			trend.Results = append(trend.Results, RunResult{
+				State:               result.State,
+				DurationSeconds:     getDuration(&result).Seconds(),
+				NonCompliantObjects: countNonCompliantObjects(result.CheckDetails),
+			})
+
+			// Only the changes between passed and failed are taken into account.
+			if result.State != claim.TestCaseResultPassed && result.State != claim.TestCaseResultFailed {
+				continue
+			}
+			if lastState != "" && lastState != result.State {
+				trend.Flips++
+				if result.State == claim.TestCaseResultFailed && trend.FirstRegression == -1 {
+					trend.FirstRegression = i
+				}
+				if result.State == claim.TestCaseResultPassed && trend.FirstFix == -1 {
+					trend.FirstFix = i
+				}
+			}
+			lastState = result.State
+		}
+		trend.Flaky = trend.Flips >= flakyMinFlips
+		trends = append(trends, trend)
+	}
+
+	return trends
+}
+
+// getDuration returns the duration of the test case from its start and end times, falling back to the
+// duration in seconds of the result.
+func getDuration(result *claim.TestCaseResult) time.Duration {
+	start, startErr := time.Parse(resultTimeLayout, strings.Split(result.StartTime, " m=")[0])
+	end, endErr := time.Parse(resultTimeLayout, strings.Split(result.EndTime, " m=")[0])
+	if startErr != nil || endErr != nil {
+		return time.Duration(result.Duration) * time.Second
+	}
+	return end.Sub(start)
+}
+
+func countNonCompliantObjects(checkDetails string) int {
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(checkDetails), &details); err != nil {
+		return 0
+	}
+	return len(details.NonCompliantObjectsOut)
+}
+
+// getFirstAndLast returns the first and last results of the runs where the test case was found.
+func (t *TestTrend) getFirstAndLast() (first, last *RunResult) {
+	for i := range t.Results {
+		if t.Results[i].State == StateMissing {
+			continue
+		}
+		if first == nil {
+			first = &t.Results[i]
+		}
+		last = &t.Results[i]
+	}
+	return first, last
+}
+
+func (t *TestTrend) getHistory() string {
+	history := ""
+	for _, result := range t.Results {
+		symbol, found := stateSymbols[result.State]
+		if !found {
+			symbol = "?"
+		}
+		history += symbol
+	}
+	return history
+}
+
+func getRunName(runIndex int) string {
+	if runIndex == -1 {
+		return ""
+	}
+	return "#" + strconv.Itoa(runIndex+1)
+}
+
+// WriteText writes the list of runs, a table with the trend of each test case, and the flaky test cases and the
+// test cases that regressed in the last run.
+func (t *Trend) WriteText(w io.Writer) error {
+	const tabPadding = 3
+
+	fmt.Fprintf(w, "Runs:\n")
+	for i, run := range t.Runs {
+		fmt.Fprintf(w, "  %-4s %s  %s\n", getRunName(i), run.StartTime, run.File)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "TEST ID\tHISTORY\tREGRESSED\tFIXED\tFLIPS\tFLAKY\tDURATION\tNON-COMPLIANT")
+	flaky, regressed := []string{}, []string{}
+	for i := range t.Tests {
+		test := &t.Tests[i]
+		first, last := test.getFirstAndLast()
+		duration, nonCompliant := "", ""
+		if first != nil {
+			duration = fmt.Sprintf("%.3fs -> %.3fs", first.DurationSeconds, last.DurationSeconds)
+			nonCompliant = fmt.Sprintf("%d -> %d", first.NonCompliantObjects, last.NonCompliantObjects)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%t\t%s\t%s\n", test.TestID, test.getHistory(), getRunName(test.FirstRegression),
+			getRunName(test.FirstFix), test.Flips, test.Flaky, duration, nonCompliant)
+
+		if test.Flaky {
+			flaky = append(flaky, test.TestID)
+		}
+		if isLastRunRegression(test) {
+			regressed = append(regressed, test.TestID)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nFlaky test cases: %d\n", len(flaky))
+	for _, testID := range flaky {
+		fmt.Fprintf(w, "  %s\n", testID)
+	}
+	fmt.Fprintf(w, "\nTest cases that regressed in the last run: %d\n", len(regressed))
+	for _, testID := range regressed {
+		fmt.Fprintf(w, "  %s\n", testID)
+	}
+
+	return nil
+}
+
+// isLastRunRegression returns true if the test case failed in the last run and passed in the previous one.
+func isLastRunRegression(test *TestTrend) bool {
+	n := len(test.Results)
+	const minRuns = 2
+	if n < minRuns {
+		return false
+	}
+	return test.Results[n-1].State == claim.TestCaseResultFailed && test.Results[n-2].State == claim.TestCaseResultPassed
+}
+
+// WriteCSV writes a row for each test case and run.
+func (t *Trend) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"test_id", "suite", "run", "claim_file", "start_time", "state", "duration_seconds",
+		"non_compliant_objects", "regressed", "fixed", "flaky"})
+	if err != nil {
+		return err
+	}
+
+	for i := range t.Tests {
+		test := &t.Tests[i]
+		for runIndex, result := range test.Results {
+			run := t.Runs[runIndex]
+			err := writer.Write([]string{test.TestID, test.Suite, strconv.Itoa(runIndex + 1), run.File, run.StartTime, result.State,
+				strconv.FormatFloat(result.DurationSeconds, 'f', 3, 64), strconv.Itoa(result.NonCompliantObjects),
+				strconv.FormatBool(runIndex == test.FirstRegression), strconv.FormatBool(runIndex == test.FirstFix),
+				strconv.FormatBool(test.Flaky)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the whole trend in json format.
+func (t *Trend) WriteJSON(w io.Writer) error {
+	payload, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the trend: %v", err)
+	}
+	_, err = fmt.Fprintln(w, strings.TrimSpace(string(payload)))
+	return err
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package trend
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const nonCompliantDetails = `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":[{"ObjectType":"Pod"},{"ObjectType":"Pod"}]}`
+
+func writeClaimFile(t *testing.T, path, startTime string, states map[string]string) {
+	results := map[string]interface{}{}
+	for testID, state := range states {
+		result := map[string]interface{}{
+			"state":     state,
+			"startTime": "2024-05-10 10:00:00 +0000 UTC m=+1.0",
+			"endTime":   "2024-05-10 10:00:01.5 +0000 UTC m=+2.5",
+			"testID":    map[string]string{"id": testID, "suite": "suite1"},
+		}
+		if state == "failed" {
+			result["checkDetails"] = nonCompliantDetails
+		}
+		results[testID] = result
+	}
+
+	content, err := json.Marshal(map[string]interface{}{
+		"claim": map[string]interface{}{
+			"metadata": map[string]string{"startTime": startTime},
+			"results":  results,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.Nil(t, os.WriteFile(path, content, 0o600))
+}
+
+func createClaimsDir(t *testing.T) string {
+	dir := t.TempDir()
+	// File names don't follow the runs order on purpose.
+	writeClaimFile(t, filepath.Join(dir, "c.json"), "2024-05-12 10:00:00 +0000 UTC",
+		map[string]string{"tc1": "passed", "tc2": "failed", "tc3": "passed"})
+	writeClaimFile(t, filepath.Join(dir, "nightly", "a.json"), "2024-05-10 10:00:00 +0000 UTC",
+		map[string]string{"tc1": "passed", "tc2": "passed"})
+	writeClaimFile(t, filepath.Join(dir, "b.json"), "2024-05-11 10:00:00 +0000 UTC",
+		map[string]string{"tc1": "failed", "tc2": "skipped", "tc3": "failed"})
+	writeClaimFile(t, filepath.Join(dir, "d.json"), "2024-05-13 10:00:00 +0000 UTC",
+		map[string]string{"tc1": "failed", "tc2": "failed", "tc3": "passed"})
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "not-a-claim.json"), []byte(`{"field":1}`), 0o600))
+	return dir
+}
+
+func TestGetTrend(t *testing.T) {
+	dir := createClaimsDir(t)
+
+	trend, err := GetTrend(dir)
+	assert.Nil(t, err)
+
+	runFiles := []string{}
+	for _, run := range trend.Runs {
+		runFiles = append(runFiles, filepath.Base(run.File))
+	}
+	assert.Equal(t, []string{"a.json", "b.json", "c.json", "d.json"}, runFiles)
+
+	assert.Len(t, trend.Tests, 3)
+	tc1, tc2, tc3 := trend.Tests[0], trend.Tests[1], trend.Tests[2]
+
+	// passed, failed, passed, failed
+	assert.Equal(t, "PFPF", tc1.getHistory())
+	assert.Equal(t, 1, tc1.FirstRegression)
+	assert.Equal(t, 2, tc1.FirstFix)
+	assert.Equal(t, 3, tc1.Flips)
+	assert.True(t, tc1.Flaky)
+	assert.Equal(t, 2, tc1.Results[1].NonCompliantObjects)
+	assert.Equal(t, 1.5, tc1.Results[0].DurationSeconds)
+
+	// Skipped results don't count as flips.
+	assert.Equal(t, "PSFF", tc2.getHistory())
+	assert.Equal(t, 2, tc2.FirstRegression)
+	assert.Equal(t, -1, tc2.FirstFix)
+	assert.False(t, tc2.Flaky)
+
+	assert.Equal(t, "-FPP", tc3.getHistory())
+	assert.Equal(t, -1, tc3.FirstRegression)
+	assert.Equal(t, 2, tc3.FirstFix)
+	assert.Equal(t, 1, tc3.Flips)
+
+	_, err = GetTrend(t.TempDir())
+	assert.NotNil(t, err)
+}
+
+func TestWriteTrend(t *testing.T) {
+	trend, err := GetTrend(createClaimsDir(t))
+	assert.Nil(t, err)
+
+	var text bytes.Buffer
+	assert.Nil(t, trend.WriteText(&text))
+	assert.Contains(t, text.String(), "Flaky test cases: 1\n  tc1\n")
+	assert.Contains(t, text.String(), "Test cases that regressed in the last run: 1\n  tc1\n")
+
+	var csv bytes.Buffer
+	assert.Nil(t, trend.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	// Header plus a row per test case and run.
+	assert.Len(t, lines, 1+3*4)
+	assert.True(t, strings.HasPrefix(lines[2], "tc1,suite1,2,"))
+	assert.True(t, strings.HasSuffix(lines[2], ",failed,1.500,2,true,false,true"))
+
+	var jsonOutput bytes.Buffer
+	assert.Nil(t, trend.WriteJSON(&jsonOutput))
+	parsed := Trend{}
+	assert.Nil(t, json.Unmarshal(jsonOutput.Bytes(), &parsed))
+	assert.Equal(t, trend.Tests, parsed.Tests)
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package unredact
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/redaction"
+	"github.com/spf13/cobra"
+)
+
+const outputFilePerms = 0o600
+
+var (
+	mappingFileFlag string
+	outputFileFlag  string
+
+	unredactCommand = &cobra.Command{
+		Use:   "unredact <file>",
+		Short: "Replaces the redaction tokens of a claim or results file by their original values.",
+		Long: `Replaces the tokens of a claim, log or any other results file redacted by "certsuite run", like redacted-ip-1 or
+redacted-host-2, by the original values in the redaction mapping file. Tokens not found in the mapping file are kept.`,
+		Example: `./certsuite claim unredact results/claim.json --mapping results/` + redaction.MappingFileName + ` -o claim-unredacted.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    unredactFile,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	unredactCommand.Flags().StringVarP(&mappingFileFlag, "mapping", "m", "",
+		"Required: redaction mapping file path.",
+	)
+	unredactCommand.Flags().StringVarP(&outputFileFlag, "output", "o", "",
+		"Required: unredacted file path.",
+	)
+
+	for _, flag := range []string{"mapping", "output"} {
+		err := unredactCommand.MarkFlagRequired(flag)
+		if err != nil {
+			log.Fatalf("Failed to mark %s as required parameter: %v", flag, err)
+			return nil
+		}
+	}
+
+	return unredactCommand
+}
+
+func unredactFile(_ *cobra.Command, args []string) error {
+	mapping, err := redaction.LoadMapping(mappingFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to load the redaction mapping file: %v", err)
+	}
+
+	content, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %v", args[0], err)
+	}
+
+	unredacted := []byte(redaction.Unredact(string(content), mapping))
+	if json.Valid(content) {
+		unredacted, err = redaction.UnredactJSON(content, mapping)
+		if err != nil {
+			return fmt.Errorf("failed to unredact file %s: %v", args[0], err)
+		}
+	}
+
+	if err := os.WriteFile(outputFileFlag, unredacted, outputFilePerms); err != nil {
+		return fmt.Errorf("failed to write the unredacted file %s: %v", outputFileFlag, err)
+	}
+
+	fmt.Printf("File %s unredacted into %s\n", args[0], outputFileFlag)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package validate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/spf13/cobra"
+)
+
+var (
+	formatVersionFlag string
+
+	validateCommand = &cobra.Command{
+		Use:   "validate <claim-file>",
+		Short: "Validates a claim file against the JSON schema of its format version.",
+		Long: `Validates a claim file against the JSON schema of its claim format version (claim.versions.claimFormat), or of
+the version set with --format-version, and reports every field that doesn't fit the schema: missing required
+fields, unexpected fields and fields with wrong types or values.`,
+		Example: `./certsuite claim validate claim.json
+./certsuite claim validate claim.json --format-version ` + claim.SupportedClaimFormatVersion,
+		Args: cobra.ExactArgs(1),
+		RunE: validateClaim,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	validateCommand.Flags().StringVar(&formatVersionFlag, "format-version", "",
+		fmt.Sprintf("Optional: claim format version of the schema to validate with. Known versions: %v", claim.GetFormatVersions()),
+	)
+
+	return validateCommand
+}
+
+func validateClaim(_ *cobra.Command, args []string) error {
+	claimFile := args[0]
+	content, err := os.ReadFile(claimFile)
+	if err != nil {
+		return fmt.Errorf("failed to read claim file %s: %v", claimFile, err)
+	}
+
+	formatVersion := formatVersionFlag
+	var validationErrors []claim.ValidationError
+	if formatVersion == "" {
+		formatVersion, validationErrors, err = claim.Validate(content)
+	} else {
+		validationErrors, err = claim.ValidateVersion(content, formatVersion)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to validate claim file %s: %v", claimFile, err)
+	}
+
+	if len(validationErrors) > 0 {
+		for i := range validationErrors {
+			fmt.Println(validationErrors[i].String())
+		}
+		return fmt.Errorf("claim file %s is not valid for the %s schema: %d error(s)", claimFile, formatVersion, len(validationErrors))
+	}
+
+	fmt.Printf("Claim file %s is valid for the %s schema.\n", claimFile, formatVersion)
+	return nil
+}
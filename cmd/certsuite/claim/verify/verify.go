@@ -0,0 +1,82 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package verify
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keyFileFlag      string
+	manifestFileFlag string
+
+	verifyCommand = &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the signature of the claim and the results artifacts files.",
+		Long: `Verifies, with the public key matching the private key used in "certsuite run --sign-key", that the signed
+manifest has not been modified and that the digests of the files it lists (the claim and the results artifacts
+file) match. The files must be in the same folder as the manifest. Tampered and missing files are reported.`,
+		Example: `./certsuite claim verify --key pub.pem
+./certsuite claim verify --key pub.pem --manifest path/to/` + claimhelper.ManifestFileName,
+		RunE: verifyFiles,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	verifyCommand.Flags().StringVarP(&keyFileFlag, "key", "k", "",
+		"Required: ed25519 or ECDSA public key file path (PEM).",
+	)
+
+	err := verifyCommand.MarkFlagRequired("key")
+	if err != nil {
+		log.Fatalf("Failed to mark key file path as required parameter: %v", err)
+		return nil
+	}
+
+	verifyCommand.Flags().StringVarP(&manifestFileFlag, "manifest", "m", filepath.Join("results", claimhelper.ManifestFileName),
+		"Optional: signed manifest file path. Its signature is read from the same path with the "+claimhelper.SignatureFileSuffix+" suffix.",
+	)
+
+	return verifyCommand
+}
+
+func verifyFiles(_ *cobra.Command, _ []string) error {
+	verifications, err := claimhelper.VerifyFiles(keyFileFlag, manifestFileFlag)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, verification := range verifications {
+		fmt.Printf("%-10s %s\n", verification.Status, verification.FileName)
+		if verification.Status != claimhelper.FileStatusOK {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) failed the verification", failed)
+	}
+
+	fmt.Println("All the files have been verified successfully.")
+	return nil
+}
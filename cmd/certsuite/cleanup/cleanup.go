@@ -0,0 +1,239 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/scale"
+	retry "k8s.io/client-go/util/retry"
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Reverts the changes left in the cluster by an interrupted or crashed run",
+	Long: `Reverts the changes recorded in the side effects journal of a run that were not reverted by the
+run itself: nodes left cordoned, and Deployments/StatefulSets/CRs and HPAs left with a different number
+of replicas. The debug DaemonSet and its namespace, which are kept on purpose to be reused by the next
+runs, are only deleted with --delete-debug-daemonset.`,
+	RunE: runCleanup,
+}
+
+func NewCommand() *cobra.Command {
+	cleanupCmd.Flags().StringP("journal", "j", filepath.Join("results", journal.FileName), "The side effects journal file of the run to clean up")
+	cleanupCmd.Flags().StringP("kubeconfig", "k", "", "The target cluster's Kubeconfig file")
+	cleanupCmd.Flags().Bool("dry-run", false, "Show the changes that would be reverted without modifying the cluster")
+	cleanupCmd.Flags().Bool("delete-debug-daemonset", false, "Delete the debug DaemonSet and its namespace too")
+
+	return cleanupCmd
+}
+
+func runCleanup(cmd *cobra.Command, _ []string) error {
+	journalFile, _ := cmd.Flags().GetString("journal")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	deleteDebugDaemonSet, _ := cmd.Flags().GetBool("delete-debug-daemonset")
+
+	var pending []journal.SideEffect
+	if dryRun {
+		entries, err := journal.Load(journalFile)
+		if err != nil {
+			return err
+		}
+		pending = journal.GetPending(entries)
+	} else {
+		var err error
+		pending, err = journal.Open(journalFile)
+		if err != nil {
+			return err
+		}
+		defer journal.Close()
+	}
+
+	if !deleteDebugDaemonSet {
+		pending = skipDebugDaemonSet(pending)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("Nothing to clean up: all the side effects in %s were reverted.\n", journalFile)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("The following %d side effect(s) would be reverted:\n", len(pending))
+		for i := len(pending) - 1; i >= 0; i-- {
+			fmt.Printf("  - %s\n", pending[i].ToString())
+		}
+		return nil
+	}
+
+	clients := clientsholder.GetClientsHolder(clientsholder.GetKubeconfigFileNames(kubeconfig)...)
+	failed := revertSideEffects(clients.K8sClient, clients.ScalingClient, pending)
+	if failed > 0 {
+		return fmt.Errorf("%d side effect(s) could not be reverted, run the cleanup again or revert them manually", failed)
+	}
+
+	return nil
+}
+
+// skipDebugDaemonSet leaves out the debug DaemonSet from the side effects to revert. It stays pending
+// in the journal so it can be deleted by a later cleanup.
+func skipDebugDaemonSet(sideEffects []journal.SideEffect) []journal.SideEffect {
+	toRevert := []journal.SideEffect{}
+	for i := range sideEffects {
+		if sideEffects[i].IsLeftOnPurpose() {
+			fmt.Printf("Kept %s, use --delete-debug-daemonset to delete it\n", sideEffects[i].ToString())
+			continue
+		}
+		toRevert = append(toRevert, sideEffects[i])
+	}
+	return toRevert
+}
+
+// revertSideEffects reverts the side effects, starting by the most recent ones so the objects changed
+// more than once get their oldest values back. Each reverted side effect is marked in the journal.
+// Returns the number of side effects that could not be reverted.
+func revertSideEffects(client kubernetes.Interface, scalingClient scale.ScalesGetter, sideEffects []journal.SideEffect) int {
+	failed := 0
+	for i := len(sideEffects) - 1; i >= 0; i-- {
+		sideEffect := &sideEffects[i]
+		err := revertSideEffect(client, scalingClient, sideEffect)
+		switch {
+		case k8serrors.IsNotFound(err):
+			fmt.Printf("Skipped %s: the object no longer exists\n", sideEffect.ToString())
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Failed to revert %s: %v\n", sideEffect.ToString(), err)
+			failed++
+			continue
+		default:
+			fmt.Printf("Reverted %s\n", sideEffect.ToString())
+		}
+
+		journal.MarkReverted(sideEffect.ID)
+	}
+
+	return failed
+}
+
+func revertSideEffect(client kubernetes.Interface, scalingClient scale.ScalesGetter, sideEffect *journal.SideEffect) error {
+	switch sideEffect.Type {
+	case journal.TypeNodeCordoned:
+		return uncordonNode(client, sideEffect.Name)
+	case journal.TypeReplicasChanged:
+		return restoreReplicas(client, scalingClient, sideEffect)
+	case journal.TypeHpaChanged:
+		return restoreHpa(client, sideEffect)
+	case journal.TypeDebugDaemonSetDeployed:
+		return deleteDebugDaemonSet(client, sideEffect.Namespace, sideEffect.Name)
+	default:
+		return fmt.Errorf("unsupported side effect type %q", sideEffect.Type)
+	}
+}
+
+func uncordonNode(client kubernetes.Interface, nodeName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		node.Spec.Unschedulable = false
+		_, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func restoreReplicas(client kubernetes.Interface, scalingClient scale.ScalesGetter, sideEffect *journal.SideEffect) error {
+	if sideEffect.Replicas == nil {
+		return fmt.Errorf("the original number of replicas was not recorded")
+	}
+
+	namespace, name := sideEffect.Namespace, sideEffect.Name
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		switch {
+		case sideEffect.Resource != "":
+			groupResource := schema.GroupResource{Group: sideEffect.Group, Resource: sideEffect.Resource}
+			crScale, err := scalingClient.Scales(namespace).Get(context.TODO(), groupResource, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			crScale.Spec.Replicas = *sideEffect.Replicas
+			_, err = scalingClient.Scales(namespace).Update(context.TODO(), groupResource, crScale, metav1.UpdateOptions{})
+			return err
+		case sideEffect.Kind == "Deployment":
+			deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			deployment.Spec.Replicas = sideEffect.Replicas
+			_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+			return err
+		case sideEffect.Kind == "StatefulSet":
+			statefulSet, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			statefulSet.Spec.Replicas = sideEffect.Replicas
+			_, err = client.AppsV1().StatefulSets(namespace).Update(context.TODO(), statefulSet, metav1.UpdateOptions{})
+			return err
+		default:
+			return fmt.Errorf("unsupported kind %q", sideEffect.Kind)
+		}
+	})
+}
+
+func restoreHpa(client kubernetes.Interface, sideEffect *journal.SideEffect) error {
+	if sideEffect.MaxReplicas == nil {
+		return fmt.Errorf("the original max replicas was not recorded")
+	}
+
+	hpaClient := client.AutoscalingV1().HorizontalPodAutoscalers(sideEffect.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		hpa, err := hpaClient.Get(context.TODO(), sideEffect.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		hpa.Spec.MinReplicas = sideEffect.MinReplicas
+		hpa.Spec.MaxReplicas = *sideEffect.MaxReplicas
+		_, err = hpaClient.Update(context.TODO(), hpa, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// deleteDebugDaemonSet removes the debug DaemonSet and its namespace, which is only used by the suite.
+func deleteDebugDaemonSet(client kubernetes.Interface, namespace, name string) error {
+	err := client.AppsV1().DaemonSets(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete daemonset %s/%s: %v", namespace, name, err)
+	}
+
+	err = client.CoreV1().Namespaces().Delete(context.TODO(), namespace, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete namespace %s: %v", namespace, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package cleanup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRevertSideEffects(t *testing.T) {
+	changedReplicas := int32(2)
+	changedMin := int32(2)
+	client := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment1", Namespace: "ns1"}, Spec: appsv1.DeploymentSpec{Replicas: &changedReplicas}},
+		&autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: "hpa1", Namespace: "ns1"},
+			Spec: autoscalingv1.HorizontalPodAutoscalerSpec{MinReplicas: &changedMin, MaxReplicas: 2}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cnf-suite"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "tnf-debug", Namespace: "cnf-suite"}},
+	)
+
+	originalReplicas := int32(3)
+	originalMax := int32(5)
+	sideEffects := []journal.SideEffect{
+		{ID: 1, Type: journal.TypeDebugDaemonSetDeployed, Namespace: "cnf-suite", Name: "tnf-debug"},
+		{ID: 2, Type: journal.TypeNodeCordoned, Name: "node1"},
+		{ID: 3, Type: journal.TypeReplicasChanged, Kind: "Deployment", Namespace: "ns1", Name: "deployment1", Replicas: &originalReplicas},
+		{ID: 4, Type: journal.TypeHpaChanged, Namespace: "ns1", Name: "hpa1", MaxReplicas: &originalMax},
+		{ID: 5, Type: journal.TypeNodeCordoned, Name: "node2"},
+		{ID: 6, Type: journal.TypeReplicasChanged, Kind: "Pod", Namespace: "ns1", Name: "pod1", Replicas: &originalReplicas},
+	}
+
+	// The missing node is skipped and the unsupported kind fails.
+	assert.Equal(t, 1, revertSideEffects(client, nil, sideEffects))
+
+	node, err := client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.False(t, node.Spec.Unschedulable)
+
+	deployment, err := client.AppsV1().Deployments("ns1").Get(context.TODO(), "deployment1", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+	hpa, err := client.AutoscalingV1().HorizontalPodAutoscalers("ns1").Get(context.TODO(), "hpa1", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+
+	_, err = client.AppsV1().DaemonSets("cnf-suite").Get(context.TODO(), "tnf-debug", metav1.GetOptions{})
+	assert.NotNil(t, err)
+	_, err = client.CoreV1().Namespaces().Get(context.TODO(), "cnf-suite", metav1.GetOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestSkipDebugDaemonSet(t *testing.T) {
+	sideEffects := []journal.SideEffect{
+		{ID: 1, Type: journal.TypeDebugDaemonSetDeployed, Namespace: "cnf-suite", Name: "tnf-debug"},
+		{ID: 2, Type: journal.TypeNodeCordoned, Name: "node1"},
+	}
+
+	toRevert := skipDebugDaemonSet(sideEffects)
+	assert.Len(t, toRevert, 1)
+	assert.Equal(t, journal.TypeNodeCordoned, toRevert[0].Type)
+}
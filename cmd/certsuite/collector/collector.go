@@ -0,0 +1,19 @@
+package collector
+
+import (
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/collector/serve"
+	"github.com/spf13/cobra"
+)
+
+var (
+	collectorCommand = &cobra.Command{
+		Use:   "collector",
+		Short: "Self-hosted claims collector.",
+	}
+)
+
+func NewCommand() *cobra.Command {
+	collectorCommand.AddCommand(serve.NewCommand())
+
+	return collectorCommand
+}
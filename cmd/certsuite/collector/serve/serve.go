@@ -0,0 +1,115 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/collector"
+	"github.com/spf13/cobra"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 30 * time.Second
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Runs a claims collector that stores the claims sent by certsuite run",
+	Long: `Runs a claims collector that receives the claim files sent by "certsuite run --enable-data-collection"
+and stores them in a SQLite database with the partner name and the executor. The claims can be listed, searched,
+summarized and downloaded with a REST API and a web page.
+
+The users of the credentials file can upload claims with their name as partner name, and only access their own
+claims, except the admins, which can access all of them. Without credentials file, anyone can upload and access
+all the claims.`,
+	Example: `./certsuite collector serve --address :8080 --database collector.db --credentials users.yaml`,
+	RunE:    runServe,
+}
+
+func NewCommand() *cobra.Command {
+	serveCmd.Flags().String("address", ":8080", "Address the collector listens on")
+	serveCmd.Flags().String("database", "collector.db", "SQLite database file the claims are stored in")
+	serveCmd.Flags().String("credentials", "", "YAML file with the users allowed to upload and access the claims")
+	serveCmd.Flags().String("log-level", "info", "Log level")
+
+	return serveCmd
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	address, _ := cmd.Flags().GetString("address")
+	databaseFile, _ := cmd.Flags().GetString("database")
+	credentialsFile, _ := cmd.Flags().GetString("credentials")
+	logLevel, _ := cmd.Flags().GetString("log-level")
+
+	log.SetupLogger(os.Stderr, logLevel)
+
+	var credentials *collector.Credentials
+	if credentialsFile != "" {
+		var err error
+		credentials, err = collector.LoadCredentials(credentialsFile)
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Warn("No credentials file set: anyone can upload and access all the claims")
+	}
+
+	store, err := collector.OpenStore(databaseFile)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           collector.NewServer(store, credentials).Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info("Claims collector listening on %s, database %s", address, databaseFile)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("claims collector stopped: %v", err)
+	case <-ctx.Done():
+	}
+
+	log.Info("Stopping the claims collector")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop the claims collector: %v", err)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/check"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/cleanup"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/collector"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/generate"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/info"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/run"
@@ -24,6 +26,8 @@ func newRootCmd() *cobra.Command {
 	rootCmd.AddCommand(generate.NewCommand())
 	rootCmd.AddCommand(check.NewCommand())
 	rootCmd.AddCommand(run.NewCommand())
+	rootCmd.AddCommand(cleanup.NewCommand())
+	rootCmd.AddCommand(collector.NewCommand())
 	rootCmd.AddCommand(info.NewCommand())
 	rootCmd.AddCommand(version.NewCommand())
 
@@ -10,7 +10,9 @@ import (
 )
 
 const (
-	supportedClaimFormatVersion = "v0.4.0"
+	// SupportedClaimFormatVersion is the claim format version the claim tools work with. Older claim files can be
+	// migrated to it.
+	SupportedClaimFormatVersion = "v0.4.0"
 )
 
 const (
@@ -70,10 +72,24 @@ type TestOperator struct {
 	Version   string `json:"version"`
 }
 
+type OwnerReference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type TestPod struct {
+	Metadata struct {
+		Name            string           `json:"name"`
+		Namespace       string           `json:"namespace"`
+		OwnerReferences []OwnerReference `json:"ownerReferences"`
+	} `json:"metadata"`
+}
+
 type Configurations struct {
 	Config         interface{}    `json:"Config"`
 	AbnormalEvents []interface{}  `json:"AbnormalEvents"`
 	TestOperators  []TestOperator `json:"testOperators"`
+	TestPods       []TestPod      `json:"testPods"`
 }
 
 type Schema struct {
@@ -82,6 +98,11 @@ type Schema struct {
 
 		Nodes Nodes `json:"nodes"`
 
+		Metadata struct {
+			StartTime string `json:"startTime"`
+			EndTime   string `json:"endTime"`
+		} `json:"metadata"`
+
 		RawResults struct {
 			Cnfcertificationtest struct {
 				Testsuites struct {
@@ -103,9 +124,9 @@ func CheckVersion(version string) error {
 		return fmt.Errorf("claim file version %q is not valid: %v", version, err)
 	}
 
-	supportedSemVersion, err := semver.NewVersion(supportedClaimFormatVersion)
+	supportedSemVersion, err := semver.NewVersion(SupportedClaimFormatVersion)
 	if err != nil {
-		return fmt.Errorf("supported claim file version v%v is not valid: v%v", supportedClaimFormatVersion, err)
+		return fmt.Errorf("supported claim file version v%v is not valid: v%v", SupportedClaimFormatVersion, err)
 	}
 
 	if claimSemVersion.Compare(supportedSemVersion) != 0 {
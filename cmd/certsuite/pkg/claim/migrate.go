@@ -0,0 +1,164 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+)
+
+// migration upgrades a claim, unmarshalled as a generic map, from a format version to the next known one.
+type migration struct {
+	from    string
+	to      string
+	migrate func(claim map[string]interface{}) error
+}
+
+var migrations = []migration{
+	{from: "v0.1.0", to: "v0.4.0", migrate: migrateV010ToV040},
+}
+
+// Migrate upgrades the claim file's content to the toVersion claim format, applying the migrations from its
+// current format version in order, and returns the migrated content. The migrated claim must be valid for the
+// toVersion schema.
+func Migrate(content []byte, toVersion string) ([]byte, error) {
+	fromVersion, err := GetFormatVersion(content)
+	if err != nil {
+		return nil, err
+	}
+
+	toSemVersion, err := semver.NewVersion(toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("target claim format version %q is not valid: %v", toVersion, err)
+	}
+
+	root := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keep the numbers as they are in the claim file.
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the claim file: %v", err)
+	}
+	claim, ok := root["claim"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("claim section not found")
+	}
+
+	version := fromVersion
+	for version != toVersion {
+		step := getMigration(version)
+		if step == nil || semver.MustParse(step.to).GreaterThan(toSemVersion) {
+			return nil, fmt.Errorf("no migration from claim format version %s to %s", version, toVersion)
+		}
+
+		if err := step.migrate(claim); err != nil {
+			return nil, fmt.Errorf("failed to migrate the claim from format version %s to %s: %v", step.from, step.to, err)
+		}
+
+		versions, ok := claim["versions"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("versions section not found")
+		}
+		versions["claimFormat"] = step.to
+		version = step.to
+	}
+
+	migrated, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the migrated claim: %v", err)
+	}
+
+	validationErrors, err := ValidateVersion(migrated, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(validationErrors) > 0 {
+		return nil, fmt.Errorf("the migrated claim is not valid for the %s schema, %d error(s), first one: %s",
+			toVersion, len(validationErrors), validationErrors[0].String())
+	}
+
+	return migrated, nil
+}
+
+func getMigration(fromVersion string) *migration {
+	for i := range migrations {
+		if migrations[i].from == fromVersion {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// migrateV010ToV040 migrates a v0.1.0 claim:
+//   - The raw JUnit results are removed.
+//   - The metadata times are converted from RFC3339 to the claimhelper.DateTimeFormatDirective layout.
+//   - The check details of the test cases that ran are moved from the skipReason field to the checkDetails one.
+//   - The results duration is converted from nanoseconds to seconds.
+func migrateV010ToV040(claim map[string]interface{}) error {
+	delete(claim, "rawResults")
+
+	for _, section := range []string{"configurations", "nodes"} {
+		if _, found := claim[section]; !found {
+			claim[section] = map[string]interface{}{}
+		}
+	}
+
+	if metadata, ok := claim["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"startTime", "endTime"} {
+			value, _ := metadata[field].(string)
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return fmt.Errorf("invalid metadata %s %q: %v", field, value, err)
+			}
+			metadata[field] = t.UTC().Format(claimhelper.DateTimeFormatDirective)
+		}
+	}
+
+	results, _ := claim["results"].(map[string]interface{})
+	for testID, value := range results {
+		result, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid result for test case %s", testID)
+		}
+
+		// The skipReason field holds the check details of the test cases that ran.
+		if _, found := result["checkDetails"]; !found {
+			result["checkDetails"] = ""
+			if state, _ := result["state"].(string); state != TestCaseResultSkipped {
+				skipReason, _ := result["skipReason"].(string)
+				result["checkDetails"] = skipReason
+				result["skipReason"] = ""
+			}
+		}
+
+		if duration, ok := result["duration"].(json.Number); ok {
+			nanoseconds, err := duration.Int64()
+			if err != nil {
+				return fmt.Errorf("invalid duration %q for test case %s: %v", duration, testID, err)
+			}
+			result["duration"] = int(time.Duration(nanoseconds).Seconds())
+		}
+	}
+
+	return nil
+}
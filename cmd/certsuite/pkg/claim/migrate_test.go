@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package claim
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	officialClaimScheme "github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	oldClaim, err := os.ReadFile("testdata/claim-v0.1.0.json")
+	assert.Nil(t, err)
+
+	migrated, err := Migrate(oldClaim, SupportedClaimFormatVersion)
+	assert.Nil(t, err)
+
+	// The migrated claim can be read with the official claim scheme.
+	root := officialClaimScheme.Root{}
+	assert.Nil(t, json.Unmarshal(migrated, &root))
+	assert.Equal(t, SupportedClaimFormatVersion, root.Claim.Versions.ClaimFormat)
+	assert.Equal(t, "2023-09-04 14:18:31 +0000 UTC", root.Claim.Metadata.StartTime)
+	assert.Equal(t, "2023-09-04 14:19:06 +0000 UTC", root.Claim.Metadata.EndTime)
+
+	failed := root.Claim.Results["access-control-pod-automount-service-account-token"]
+	assert.Equal(t, "Pod has been found with default service account name.", failed.CheckDetails)
+	assert.Equal(t, "", failed.SkipReason)
+	assert.Equal(t, 0, failed.Duration)
+
+	passed := root.Claim.Results["access-control-ssh-daemons"]
+	assert.Equal(t, 7, passed.Duration)
+
+	skipped := root.Claim.Results["observability-crd-status"]
+	assert.Equal(t, "no CRDs to check", skipped.SkipReason)
+	assert.Equal(t, "", skipped.CheckDetails)
+
+	var migratedMap map[string]map[string]interface{}
+	assert.Nil(t, json.Unmarshal(migrated, &migratedMap))
+	assert.NotContains(t, migratedMap["claim"], "rawResults")
+
+	// Nothing to do when the claim already has the target format.
+	current := getCurrentClaim(t)
+	migrated, err = Migrate(current, SupportedClaimFormatVersion)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(current), string(migrated))
+}
+
+func TestMigrateErrors(t *testing.T) {
+	oldClaim, err := os.ReadFile("testdata/claim-v0.1.0.json")
+	assert.Nil(t, err)
+
+	_, err = Migrate(oldClaim, "v0.3.0")
+	assert.Equal(t, "no migration from claim format version v0.1.0 to v0.3.0", err.Error())
+
+	_, err = Migrate(getCurrentClaim(t), "v0.1.0")
+	assert.Equal(t, "no migration from claim format version v0.4.0 to v0.1.0", err.Error())
+
+	_, err = Migrate(oldClaim, "latest")
+	assert.NotNil(t, err)
+}
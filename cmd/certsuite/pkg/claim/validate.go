@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claim
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// JSON schemas of the known claim format versions, named claim-<version>.schema.json.
+//
+//go:embed schemas/*.schema.json
+var schemasFS embed.FS
+
+const (
+	schemasDir       = "schemas"
+	schemaFilePrefix = "claim-"
+	schemaFileSuffix = ".schema.json"
+)
+
+// ValidationError is an error found when validating a claim file against the schema of its format version.
+type ValidationError struct {
+	// Path of the wrong field, like claim.results.<test-id>.state.
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+func (e *ValidationError) String() string {
+	return e.Field + ": " + e.Description
+}
+
+// GetFormatVersions returns the claim format versions with a known schema, from the oldest to the newest one.
+func GetFormatVersions() []string {
+	entries, err := schemasFS.ReadDir(schemasDir)
+	if err != nil {
+		return nil
+	}
+
+	versions := []*semver.Version{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), schemaFilePrefix), schemaFileSuffix)
+		if version, err := semver.NewVersion(name); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Sort(semver.Collection(versions))
+
+	names := []string{}
+	for _, version := range versions {
+		names = append(names, version.Original())
+	}
+	return names
+}
+
+func getSchema(formatVersion string) ([]byte, error) {
+	schema, err := schemasFS.ReadFile(schemasDir + "/" + schemaFilePrefix + formatVersion + schemaFileSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("no schema for claim format version %q - known versions: %v", formatVersion, GetFormatVersions())
+	}
+	return schema, nil
+}
+
+// GetFormatVersion returns the claimFormat version of the claim file's content.
+func GetFormatVersion(content []byte) (string, error) {
+	root := struct {
+		Claim *struct {
+			Versions *struct {
+				ClaimFormat string `json:"claimFormat"`
+			} `json:"versions"`
+		} `json:"claim"`
+	}{}
+	if err := json.Unmarshal(content, &root); err != nil {
+		return "", fmt.Errorf("failed to unmarshal the claim file: %v", err)
+	}
+
+	if root.Claim == nil || root.Claim.Versions == nil || root.Claim.Versions.ClaimFormat == "" {
+		return "", errors.New("claim format version not found in claim.versions.claimFormat")
+	}
+	return root.Claim.Versions.ClaimFormat, nil
+}
+
+// Validate validates the claim file's content against the schema of its claim format version, which is
+// returned along with the list of validation errors. An error is returned when the validation can't be done,
+// e.g. for unknown format versions.
+func Validate(content []byte) (formatVersion string, validationErrors []ValidationError, err error) {
+	formatVersion, err = GetFormatVersion(content)
+	if err != nil {
+		return "", nil, err
+	}
+
+	validationErrors, err = ValidateVersion(content, formatVersion)
+	return formatVersion, validationErrors, err
+}
+
+// ValidateVersion validates the claim file's content against the schema of the given claim format version. The
+// validation errors are sorted by field.
+func ValidateVersion(content []byte, formatVersion string) ([]ValidationError, error) {
+	schema, err := getSchema(formatVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate the claim file against the %s schema: %v", formatVersion, err)
+	}
+
+	validationErrors := []ValidationError{}
+	for _, resultError := range result.Errors() {
+		validationErrors = append(validationErrors, ValidationError{Field: resultError.Field(), Description: resultError.Description()})
+	}
+
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		return validationErrors[i].Field < validationErrors[j].Field
+	})
+	return validationErrors, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+package claim
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	officialClaimScheme "github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+// getCurrentClaim returns a claim file content with the current format, as written by the test suite.
+func getCurrentClaim(t *testing.T) []byte {
+	root := officialClaimScheme.Root{
+		Claim: &officialClaimScheme.Claim{
+			Configurations: map[string]interface{}{"Config": map[string]interface{}{}},
+			Metadata:       &officialClaimScheme.Metadata{StartTime: "2024-05-10 10:00:00 +0000 UTC", EndTime: "2024-05-10 10:05:00 +0000 UTC"},
+			Nodes:          map[string]interface{}{},
+			Results: map[string]officialClaimScheme.Result{
+				"access-control-ssh-daemons": {
+					CatalogInfo: &officialClaimScheme.CatalogInfo{Description: "Check that pods do not run SSH daemons."},
+					CategoryClassification: &officialClaimScheme.CategoryClassification{
+						Extended: "Mandatory", FarEdge: "Mandatory", NonTelco: "Optional", Telco: "Mandatory",
+					},
+					CheckDetails: `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":null}`,
+					Duration:     2,
+					StartTime:    "2024-05-10 10:00:01.5 +0000 UTC m=+1.5",
+					EndTime:      "2024-05-10 10:00:03.5 +0000 UTC m=+3.5",
+					State:        "passed",
+					TestID:       &officialClaimScheme.Identifier{Id: "access-control-ssh-daemons", Suite: "access-control"},
+				},
+			},
+			Versions: &officialClaimScheme.Versions{ClaimFormat: SupportedClaimFormatVersion, Tnf: "v5.2.0"},
+		},
+	}
+
+	content, err := json.Marshal(root)
+	assert.Nil(t, err)
+	return content
+}
+
+func TestGetFormatVersions(t *testing.T) {
+	assert.Equal(t, []string{"v0.1.0", "v0.4.0"}, GetFormatVersions())
+}
+
+func TestValidate(t *testing.T) {
+	formatVersion, validationErrors, err := Validate(getCurrentClaim(t))
+	assert.Nil(t, err)
+	assert.Equal(t, SupportedClaimFormatVersion, formatVersion)
+	assert.Empty(t, validationErrors)
+
+	oldClaim, err := os.ReadFile("testdata/claim-v0.1.0.json")
+	assert.Nil(t, err)
+	formatVersion, validationErrors, err = Validate(oldClaim)
+	assert.Nil(t, err)
+	assert.Equal(t, "v0.1.0", formatVersion)
+	assert.Empty(t, validationErrors)
+
+	// The old claim doesn't fit the current schema.
+	validationErrors, err = ValidateVersion(oldClaim, SupportedClaimFormatVersion)
+	assert.Nil(t, err)
+	assert.Contains(t, validationErrors, ValidationError{Field: "claim", Description: "Additional property rawResults is not allowed"})
+	assert.Contains(t, validationErrors, ValidationError{Field: "claim.results.access-control-ssh-daemons", Description: "checkDetails is required"})
+
+	_, _, err = Validate([]byte(`{"claim": {"versions": {"claimFormat": "v0.0.1"}}}`))
+	assert.Equal(t, `no schema for claim format version "v0.0.1" - known versions: [v0.1.0 v0.4.0]`, err.Error())
+
+	_, _, err = Validate([]byte(`{"claim": {}}`))
+	assert.Equal(t, "claim format version not found in claim.versions.claimFormat", err.Error())
+}
+
+func TestValidateErrors(t *testing.T) {
+	content := []byte(`{
+  "claim": {
+    "configurations": {},
+    "metadata": {"startTime": "2024-05-10T10:00:00Z", "endTime": "2024-05-10 10:05:00 +0000 UTC"},
+    "results": {"test1": {"state": 1}},
+    "versions": {"claimFormat": "v0.4.0", "tnf": "v5.2.0"}
+  }
+}`)
+
+	_, validationErrors, err := Validate(content)
+	assert.Nil(t, err)
+	assert.Contains(t, validationErrors, ValidationError{Field: "claim", Description: "nodes is required"})
+	assert.Contains(t, validationErrors, ValidationError{Field: "claim.metadata.startTime", Description: "Does not match pattern '^[0-9]{4}-[0-9]{2}-[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2} [+-][0-9]{4} [A-Za-z0-9+-]+$'"})
+	assert.Contains(t, validationErrors, ValidationError{Field: "claim.results.test1.state", Description: "Invalid type. Expected: string, given: integer"})
+	for _, validationError := range validationErrors {
+		assert.NotEqual(t, "claim.metadata.endTime", validationError.Field)
+	}
+}
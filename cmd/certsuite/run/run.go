@@ -30,6 +30,14 @@ func NewCommand() *cobra.Command {
 	runCmd.PersistentFlags().StringP("config-file", "c", "config/tnf_config.yml", "The workload configuration file")
 	runCmd.PersistentFlags().StringP("kubeconfig", "k", "", "The target cluster's Kubeconfig file")
 	runCmd.PersistentFlags().Bool("server-mode", false, "Run the certsuite in web server mode")
+	runCmd.PersistentFlags().String("server-address", ":8084", "Address the web server listens on in web server mode")
+	runCmd.PersistentFlags().String("server-tls-cert", "", "Certificate file (PEM) to serve HTTPS in web server mode")
+	runCmd.PersistentFlags().String("server-tls-key", "", "Private key file (PEM) of the web server certificate")
+	runCmd.PersistentFlags().Bool("server-self-signed-cert", false, "Serve HTTPS with a generated self-signed certificate in web server mode")
+	runCmd.PersistentFlags().String("server-tokens-file", "", "File with the bearer tokens allowed to access the web server, one per line")
+	runCmd.PersistentFlags().String("server-htpasswd", "", "htpasswd file with the users allowed to access the web server (bcrypt or SHA1 passwords)")
+	runCmd.PersistentFlags().StringSlice("server-allowed-origins", nil, "Origins, besides the web server one, allowed to open the log stream (e.g. https://lab.example.com)")
+	runCmd.PersistentFlags().Int("max-concurrent-runs", 1, "Maximum number of runs at the same time in web server mode, the rest are queued")
 	runCmd.PersistentFlags().Bool("omit-artifacts-zip-file", false, "Prevents the creation of a zip file with the result artifacts")
 	runCmd.PersistentFlags().String("log-level", "debug", "Sets the log level")
 	runCmd.PersistentFlags().String("offline-db", "", "Set the location of an offline DB to check the certification status of for container images, operators and helm charts")
@@ -39,6 +47,13 @@ func NewCommand() *cobra.Command {
 	runCmd.PersistentFlags().Bool("include-web-files", false, "Save web files in the configured output folder")
 	runCmd.PersistentFlags().Bool("enable-data-collection", false, "Allow sending test results to an external data collector")
 	runCmd.PersistentFlags().Bool("create-xml-junit-file", false, "Create a JUnit file with the test results")
+	runCmd.PersistentFlags().Bool("junit-file-per-suite", false, "Create a JUnit file per test suite instead of a single one")
+	runCmd.PersistentFlags().Bool("create-sarif-file", false, "Create a SARIF file with the test results")
+	runCmd.PersistentFlags().Bool("create-metrics-file", false, "Create a Prometheus textfile collector file with the test results metrics")
+	runCmd.PersistentFlags().Bool("enable-tracing", false, "Export OpenTelemetry traces of the discovery and the checks")
+	runCmd.PersistentFlags().String("otlp-endpoint", "", "URL of the OTLP gRPC endpoint to export the traces to. If not set, the traces are saved in the output folder")
+	runCmd.PersistentFlags().String("sign-key", "", "Path to an ed25519 or ECDSA private key (PEM) to sign the claim and the results artifacts file")
+	runCmd.PersistentFlags().String("report-format", "", "Create a standalone report file with the test results in this format (html or md)")
 	runCmd.PersistentFlags().String("tnf-image-repository", "quay.io/redhat-best-practices-for-k8s", "The repository where TNF images are stored")
 	runCmd.PersistentFlags().String("tnf-debug-image", "certsuite-probe:v0.0.5", "Name of the certsuite-probe image")
 	runCmd.PersistentFlags().String("daemonset-cpu-req", "100m", "CPU request for the debug DaemonSet container")
@@ -46,6 +61,7 @@ func NewCommand() *cobra.Command {
 	runCmd.PersistentFlags().String("daemonset-mem-req", "100M", "Memory request for the debug DaemonSet container")
 	runCmd.PersistentFlags().String("daemonset-mem-lim", "100M", "Memory limit for the debug DaemonSet container")
 	runCmd.PersistentFlags().Bool("sanitize-claim", false, "Sanitize the claim.json file before sending it to the collector")
+	runCmd.PersistentFlags().Bool("skip-checks-missing-permissions", false, "Skip the checks whose required API permissions are missing instead of setting them as error")
 
 	return runCmd
 }
@@ -57,6 +73,14 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 	testParams.OutputDir, _ = cmd.Flags().GetString("output-dir")
 	testParams.LabelsFilter, _ = cmd.Flags().GetString("label-filter")
 	testParams.ServerMode, _ = cmd.Flags().GetBool("server-mode")
+	testParams.MaxConcurrentRuns, _ = cmd.Flags().GetInt("max-concurrent-runs")
+	testParams.ServerAddress, _ = cmd.Flags().GetString("server-address")
+	testParams.ServerTLSCertFile, _ = cmd.Flags().GetString("server-tls-cert")
+	testParams.ServerTLSKeyFile, _ = cmd.Flags().GetString("server-tls-key")
+	testParams.ServerSelfSignedCert, _ = cmd.Flags().GetBool("server-self-signed-cert")
+	testParams.ServerTokensFile, _ = cmd.Flags().GetString("server-tokens-file")
+	testParams.ServerHtpasswdFile, _ = cmd.Flags().GetString("server-htpasswd")
+	testParams.ServerAllowedOrigins, _ = cmd.Flags().GetStringSlice("server-allowed-origins")
 	testParams.ConfigFile, _ = cmd.Flags().GetString("config-file")
 	testParams.Kubeconfig, _ = cmd.Flags().GetString("kubeconfig")
 	testParams.OmitArtifactsZipFile, _ = cmd.Flags().GetBool("omit-artifacts-zip-file")
@@ -68,6 +92,13 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 	testParams.IncludeWebFilesInOutputFolder, _ = cmd.Flags().GetBool("include-web-files")
 	testParams.EnableDataCollection, _ = cmd.Flags().GetBool("enable-data-collection")
 	testParams.EnableXMLCreation, _ = cmd.Flags().GetBool("create-xml-junit-file")
+	testParams.EnableJUnitFilePerSuite, _ = cmd.Flags().GetBool("junit-file-per-suite")
+	testParams.EnableSARIFCreation, _ = cmd.Flags().GetBool("create-sarif-file")
+	testParams.EnableMetricsFile, _ = cmd.Flags().GetBool("create-metrics-file")
+	testParams.EnableTracing, _ = cmd.Flags().GetBool("enable-tracing")
+	testParams.OTLPEndpoint, _ = cmd.Flags().GetString("otlp-endpoint")
+	testParams.SignKeyFile, _ = cmd.Flags().GetString("sign-key")
+	testParams.ReportFormat, _ = cmd.Flags().GetString("report-format")
 	testParams.TnfImageRepo, _ = cmd.Flags().GetString("tnf-image-repository")
 	testParams.TnfDebugImage, _ = cmd.Flags().GetString("tnf-debug-image")
 	testParams.DaemonsetCPUReq, _ = cmd.Flags().GetString("daemonset-cpu-req")
@@ -75,6 +106,7 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 	testParams.DaemonsetMemReq, _ = cmd.Flags().GetString("daemonset-mem-req")
 	testParams.DaemonsetMemLim, _ = cmd.Flags().GetString("daemonset-mem-lim")
 	testParams.SanitizeClaim, _ = cmd.Flags().GetBool("sanitize-claim")
+	testParams.SkipChecksMissingPermissions, _ = cmd.Flags().GetBool("skip-checks-missing-permissions")
 	timeoutStr, _ := cmd.Flags().GetString("timeout")
 
 	// Check if the output directory exists and, if not, create it
@@ -112,7 +144,9 @@ func runTestSuite(cmd *cobra.Command, _ []string) error {
 	testParams := configuration.GetTestParameters()
 	if testParams.ServerMode {
 		log.Info("Running CNF Certification Suite in web server mode")
-		webserver.StartServer(testParams.OutputDir)
+		if err := webserver.StartServer(testParams.OutputDir); err != nil {
+			log.Fatal("Failed to run the web server: %v", err) //nolint:gocritic // exitAfterDefer
+		}
 	} else {
 		log.Info("Running CNF Certification Suite in stand-alone mode")
 		err := certsuite.Run(testParams.LabelsFilter, testParams.OutputDir)
@@ -0,0 +1,178 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package audit keeps a trail of the commands run by the suite inside the cluster's containers, so
+// it can be shared along with the claim file.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	// FileName is the name of the audit trail file that is saved in the results artifacts.
+	FileName = "exec-audit.json"
+	// MaxOutputLength is the max number of bytes of stdout/stderr kept in each record.
+	MaxOutputLength = 2048
+
+	KindExec    = "exec"
+	KindNsenter = "nsenter"
+
+	ExitStatusUnknown = -1
+
+	auditFilePermissions = 0o644
+	truncatedSuffix      = "...[truncated]"
+)
+
+// Target identifies where a command was run.
+type Target struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Node      string `json:"node,omitempty"`
+}
+
+// ExecRecord is an entry of the audit trail.
+type ExecRecord struct {
+	ID              int     `json:"id"`
+	Kind            string  `json:"kind"`
+	CheckID         string  `json:"checkID,omitempty"`
+	Target          Target  `json:"target"`
+	Via             *Target `json:"via,omitempty"`
+	Command         string  `json:"command"`
+	StartTime       string  `json:"startTime"`
+	DurationMs      int64   `json:"durationMs"`
+	ExitStatus      int     `json:"exitStatus"`
+	Error           string  `json:"error,omitempty"`
+	Stdout          string  `json:"stdout"`
+	Stderr          string  `json:"stderr"`
+	StdoutTruncated bool    `json:"stdoutTruncated,omitempty"`
+	StderrTruncated bool    `json:"stderrTruncated,omitempty"`
+}
+
+// Trail is the content of the audit trail file.
+type Trail struct {
+	Records []ExecRecord `json:"records"`
+}
+
+var (
+	mutex   sync.Mutex
+	records = []ExecRecord{}
+)
+
+// RecordExec adds a new record to the audit trail and returns its ID. The check ID is the one of the
+// check that ran the command, so a check still running after being aborted can't attribute its commands
+// to another one. An empty ID means the command was not run by a check (e.g. autodiscovery or diagnostics).
+func RecordExec(checkID, kind string, target Target, via *Target, command string, startTime time.Time, stdout, stderr string, err error) int {
+	record := ExecRecord{
+		Kind:       kind,
+		CheckID:    checkID,
+		Target:     target,
+		Via:        via,
+		Command:    command,
+		StartTime:  startTime.UTC().Format(time.RFC3339Nano),
+		DurationMs: time.Since(startTime).Milliseconds(),
+		ExitStatus: GetExitStatus(err),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	record.Stdout, record.StdoutTruncated = truncate(stdout)
+	record.Stderr, record.StderrTruncated = truncate(stderr)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	record.ID = len(records) + 1
+	records = append(records, record)
+
+	return record.ID
+}
+
+// GetExitStatus returns the exit status of a command given the error returned by the exec call.
+func GetExitStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus()
+	}
+
+	return ExitStatusUnknown
+}
+
+func truncate(output string) (string, bool) {
+	if len(output) <= MaxOutputLength {
+		return output, false
+	}
+
+	return output[:MaxOutputLength] + truncatedSuffix, true
+}
+
+// GetRecords returns a copy of all the records of the audit trail.
+func GetRecords() []ExecRecord {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return append([]ExecRecord{}, records...)
+}
+
+// GetCheckRecordIDs returns the IDs of the records owned by a check.
+func GetCheckRecordIDs(checkID string) []int {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	ids := []int{}
+	for i := range records {
+		if records[i].CheckID == checkID {
+			ids = append(ids, records[i].ID)
+		}
+	}
+
+	return ids
+}
+
+// Reset removes all the records.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	records = []ExecRecord{}
+}
+
+// WriteTrailFile saves the audit trail in a json file.
+func WriteTrailFile(filePath string) error {
+	payload, err := json.MarshalIndent(Trail{Records: GetRecords()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the audit trail: %v", err)
+	}
+
+	err = os.WriteFile(filePath, payload, auditFilePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to write audit trail file %s: %v", filePath, err)
+	}
+
+	return nil
+}
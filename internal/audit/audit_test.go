@@ -0,0 +1,95 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+func TestGetExitStatus(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected int
+	}{
+		{err: nil, expected: 0},
+		{err: utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}, expected: 2},
+		{err: errors.New("connection refused"), expected: ExitStatusUnknown},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, GetExitStatus(tc.err))
+	}
+}
+
+func TestRecordExec(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	target := Target{Namespace: "ns1", Pod: "pod1", Container: "container1"}
+	longOutput := strings.Repeat("a", MaxOutputLength+1)
+
+	assert.Equal(t, 1, RecordExec("", KindExec, target, nil, "ls", time.Now(), "out", "", nil))
+	assert.Equal(t, 2, RecordExec("check1", KindExec, target, nil, "cat file", time.Now(), longOutput, "err",
+		utilexec.CodeExitError{Err: errors.New("exit code 1"), Code: 1}))
+
+	records := GetRecords()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "", records[0].CheckID)
+	assert.Equal(t, 0, records[0].ExitStatus)
+	assert.Equal(t, "out", records[0].Stdout)
+	assert.False(t, records[0].StdoutTruncated)
+
+	assert.Equal(t, "check1", records[1].CheckID)
+	assert.Equal(t, 1, records[1].ExitStatus)
+	assert.Equal(t, "exit code 1", records[1].Error)
+	assert.True(t, records[1].StdoutTruncated)
+	assert.Equal(t, MaxOutputLength+len(truncatedSuffix), len(records[1].Stdout))
+
+	assert.Equal(t, []int{2}, GetCheckRecordIDs("check1"))
+	assert.Empty(t, GetCheckRecordIDs("check2"))
+}
+
+func TestWriteTrailFile(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	target := Target{Namespace: "ns1", Pod: "pod1", Container: "container1", Node: "node1"}
+	via := &Target{Namespace: "cnf-suite", Pod: "tnf-debug-abcde", Container: "container-00", Node: "node1"}
+	RecordExec("", KindNsenter, target, via, "ip a", time.Now(), "out", "", nil)
+
+	filePath := filepath.Join(t.TempDir(), FileName)
+	assert.Nil(t, WriteTrailFile(filePath))
+
+	payload, err := os.ReadFile(filePath)
+	assert.Nil(t, err)
+
+	var trail Trail
+	assert.Nil(t, json.Unmarshal(payload, &trail))
+	assert.Len(t, trail.Records, 1)
+	assert.Equal(t, KindNsenter, trail.Records[0].Kind)
+	assert.Equal(t, target, trail.Records[0].Target)
+	assert.Equal(t, via, trail.Records[0].Via)
+}
@@ -19,12 +19,15 @@ package clientsholder
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	clientconfigv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	olmClient "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	olmFakeClient "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/tracing"
 
 	apiextv1c "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -174,6 +177,24 @@ func GetNewClientsHolder(kubeconfigFile string) *ClientsHolder {
 	return &clientsHolder
 }
 
+// GetKubeconfigFileNames returns the kubeconfig files of the standalone commands: the kubeconfig flag when set,
+// otherwise the files of the KUBECONFIG env var or the default $HOME/.kube/config.
+func GetKubeconfigFileNames(kubeconfig string) []string {
+	if kubeconfig != "" {
+		return []string{kubeconfig}
+	}
+
+	if envKubeconfig := os.Getenv("KUBECONFIG"); envKubeconfig != "" {
+		return filepath.SplitList(envKubeconfig)
+	}
+
+	if homeDir := os.Getenv("HOME"); homeDir != "" {
+		return []string{filepath.Join(homeDir, ".kube", "config")}
+	}
+
+	return []string{}
+}
+
 func createByteArrayKubeConfig(kubeConfig *clientcmdapi.Config) ([]byte, error) {
 	yamlBytes, err := clientcmd.Write(*kubeConfig)
 	if err != nil {
@@ -272,6 +293,8 @@ func newClientsHolder(filenames ...string) (*ClientsHolder, error) { //nolint:fu
 		return nil, fmt.Errorf("failed to get rest.Config: %v", err)
 	}
 	clientsHolder.RestConfig.Timeout = DefaultTimeout
+	// Every API request is traced.
+	clientsHolder.RestConfig.Wrap(tracing.WrapTransport)
 
 	clientsHolder.DynamicClient, err = dynamic.NewForConfig(clientsHolder.RestConfig)
 	if err != nil {
@@ -338,6 +361,8 @@ type Context struct {
 	namespace     string
 	podName       string
 	containerName string
+	// ID of the check that runs the commands, recorded in the audit trail.
+	checkID string
 }
 
 func NewContext(namespace, podName, containerName string) Context {
@@ -359,3 +384,13 @@ func (c *Context) GetPodName() string {
 func (c *Context) GetContainerName() string {
 	return c.containerName
 }
+
+// WithCheckID returns a copy of the context whose commands are recorded in the audit trail as run by the given check.
+func (c Context) WithCheckID(checkID string) Context {
+	c.checkID = checkID
+	return c
+}
+
+func (c *Context) GetCheckID() string {
+	return c.checkID
+}
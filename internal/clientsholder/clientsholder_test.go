@@ -15,3 +15,35 @@
 // 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
 
 package clientsholder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKubeconfigFileNames(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+
+	t.Setenv("KUBECONFIG", "")
+	assert.Equal(t, []string{"/tmp/kubeconfig"}, GetKubeconfigFileNames("/tmp/kubeconfig"))
+	assert.Equal(t, []string{filepath.Join("/home/user", ".kube", "config")}, GetKubeconfigFileNames(""))
+
+	t.Setenv("KUBECONFIG", "/tmp/config1"+string(filepath.ListSeparator)+"/tmp/config2")
+	assert.Equal(t, []string{"/tmp/config1", "/tmp/config2"}, GetKubeconfigFileNames(""))
+	assert.Equal(t, []string{"/tmp/kubeconfig"}, GetKubeconfigFileNames("/tmp/kubeconfig"))
+
+	t.Setenv("KUBECONFIG", "")
+	t.Setenv("HOME", "")
+	assert.Empty(t, GetKubeconfigFileNames(""))
+}
+
+func TestContextWithCheckID(t *testing.T) {
+	ctx := NewContext("ns1", "pod1", "container1")
+	checkCtx := ctx.WithCheckID("check1")
+
+	assert.Equal(t, "check1", checkCtx.GetCheckID())
+	assert.Equal(t, "pod1", checkCtx.GetPodName())
+	assert.Equal(t, "", ctx.GetCheckID())
+}
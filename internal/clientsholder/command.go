@@ -21,8 +21,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/kubectl/pkg/scheme"
@@ -36,6 +40,30 @@ type Command interface {
 // ExecCommand runs command in the pod and returns buffer output.
 func (clientsholder *ClientsHolder) ExecCommandContainer(
 	ctx Context, command string) (stdout, stderr string, err error) {
+	// Every command is recorded in the audit trail.
+	startTime := time.Now()
+	defer func() {
+		target := audit.Target{Namespace: ctx.GetNamespace(), Pod: ctx.GetPodName(), Container: ctx.GetContainerName()}
+		audit.RecordExec(ctx.GetCheckID(), audit.KindExec, target, nil, command, startTime, stdout, stderr, err)
+	}()
+
+	return clientsholder.ExecCommandContainerUnaudited(ctx, command)
+}
+
+// ExecCommandContainerUnaudited runs command in the pod like ExecCommandContainer, without recording it in the
+// audit trail. It's used by the callers that record the command themselves, like the nsenter ones.
+func (clientsholder *ClientsHolder) ExecCommandContainerUnaudited(
+	ctx Context, command string) (stdout, stderr string, err error) {
+	span := tracing.StartLeafSpan("exec",
+		attribute.String("k8s.namespace.name", ctx.GetNamespace()),
+		attribute.String("k8s.pod.name", ctx.GetPodName()),
+		attribute.String("k8s.container.name", ctx.GetContainerName()),
+		attribute.String("certsuite.exec.command", command))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	commandStr := []string{"sh", "-c", command}
 	var buffOut bytes.Buffer
 	var buffErr bytes.Buffer
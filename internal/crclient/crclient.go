@@ -21,7 +21,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
@@ -56,6 +58,13 @@ func GetNodeDebugPodContext(node string, env *provider.TestEnvironment) (clients
 }
 
 func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (int, error) {
+	return getPidFromContainer(cut, ctx, clientsholder.GetClientsHolder().ExecCommandContainer)
+}
+
+// getPidFromContainer gets the PID of the container with the given exec function, so the nsenter commands can get
+// it without recording it in the audit trail.
+func getPidFromContainer(cut *provider.Container, ctx clientsholder.Context,
+	execCommand func(clientsholder.Context, string) (string, string, error)) (int, error) {
 	var pidCmd string
 
 	switch cut.Runtime {
@@ -70,10 +79,9 @@ func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (in
 		return 0, fmt.Errorf("container runtime %s not supported", cut.Runtime)
 	}
 
-	ch := clientsholder.GetClientsHolder()
-	outStr, errStr, err := ch.ExecCommandContainer(ctx, pidCmd)
+	outStr, errStr, err := execCommand(ctx, pidCmd)
 	if err != nil {
-		return 0, fmt.Errorf("cannot execute command: \" %s \"  on %s err:%s", pidCmd, cut, err)
+		return 0, fmt.Errorf("cannot execute command: \" %s \"  on %s err:%w", pidCmd, cut, err)
 	}
 	if errStr != "" {
 		return 0, fmt.Errorf("cmd: \" %s \" on %s returned %s", pidCmd, cut, errStr)
@@ -82,13 +90,14 @@ func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (in
 	return strconv.Atoi(strings.TrimSuffix(outStr, "\n"))
 }
 
-// To get the pid namespace of the container
-func GetContainerPidNamespace(testContainer *provider.Container, env *provider.TestEnvironment) (string, error) {
+// To get the pid namespace of the container. The commands are recorded in the audit trail as run by the given check.
+func GetContainerPidNamespace(testContainer *provider.Container, env *provider.TestEnvironment, checkID string) (string, error) {
 	// Get the container pid
 	ocpContext, err := GetNodeDebugPodContext(testContainer.NodeName, env)
 	if err != nil {
 		return "", fmt.Errorf("failed to get debug pod's context for container %s: %v", testContainer, err)
 	}
+	ocpContext = ocpContext.WithCheckID(checkID)
 
 	pid, err := GetPidFromContainer(testContainer, ocpContext)
 	if err != nil {
@@ -105,51 +114,63 @@ func GetContainerPidNamespace(testContainer *provider.Container, env *provider.T
 	return strings.Fields(stdout)[0], nil
 }
 
-func GetContainerProcesses(container *provider.Container, env *provider.TestEnvironment) ([]*Process, error) {
-	pidNs, err := GetContainerPidNamespace(container, env)
+func GetContainerProcesses(container *provider.Container, env *provider.TestEnvironment, checkID string) ([]*Process, error) {
+	pidNs, err := GetContainerPidNamespace(container, env, checkID)
 	if err != nil {
 		return nil, fmt.Errorf("could not get the containers' pid namespace, err: %v", err)
 	}
 
-	return GetPidsFromPidNamespace(pidNs, container)
+	return GetPidsFromPidNamespace(pidNs, container, checkID)
 }
 
-// ExecCommandContainerNSEnter executes a command in the specified container namespace using nsenter
+// ExecCommandContainerNSEnter executes a command in the specified container namespace using nsenter.
+// The command is recorded once in the audit trail as run by the given check, with the container as
+// target, the debug pod it was run from and its output, even when it fails.
 func ExecCommandContainerNSEnter(command string,
-	aContainer *provider.Container) (outStr, errStr string, err error) {
+	aContainer *provider.Container, checkID string) (outStr, errStr string, err error) {
+	startTime := time.Now()
+	var via *audit.Target
+	var stdout, stderr string
+	defer func() {
+		target := audit.Target{Namespace: aContainer.Namespace, Pod: aContainer.Podname, Container: aContainer.Name, Node: aContainer.NodeName}
+		audit.RecordExec(checkID, audit.KindNsenter, target, via, command, startTime, stdout, stderr, err)
+	}()
+
 	env := provider.GetTestEnvironment()
 	ctx, err := GetNodeDebugPodContext(aContainer.NodeName, &env)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get debug pod's context for container %s: %v", aContainer, err)
+		return "", "", fmt.Errorf("failed to get debug pod's context for container %s: %w", aContainer, err)
 	}
+	via = &audit.Target{Namespace: ctx.GetNamespace(), Pod: ctx.GetPodName(), Container: ctx.GetContainerName(), Node: aContainer.NodeName}
 
 	ch := clientsholder.GetClientsHolder()
 
 	// Get the container PID to build the nsenter command
-	containerPid, err := GetPidFromContainer(aContainer, ctx)
+	containerPid, err := getPidFromContainer(aContainer, ctx, ch.ExecCommandContainerUnaudited)
 	if err != nil {
-		return "", "", fmt.Errorf("cannot get PID from: %s, err: %v", aContainer, err)
+		return "", "", fmt.Errorf("cannot get PID from: %s, err: %w", aContainer, err)
 	}
 
 	// Add the container PID and the specific command to run with nsenter
 	nsenterCommand := "nsenter -t " + strconv.Itoa(containerPid) + " -n " + command
 
 	// Run the nsenter command on the debug pod
-	outStr, errStr, err = ch.ExecCommandContainer(ctx, nsenterCommand)
+	stdout, stderr, err = ch.ExecCommandContainerUnaudited(ctx, nsenterCommand)
 	if err != nil {
-		return "", "", fmt.Errorf("cannot execute command: \" %s \"  on %s err:%s", command, aContainer, err)
+		return "", "", fmt.Errorf("cannot execute command: \" %s \"  on %s err:%w", command, aContainer, err)
 	}
 
-	return outStr, errStr, err
+	return stdout, stderr, nil
 }
 
-func GetPidsFromPidNamespace(pidNamespace string, container *provider.Container) (p []*Process, err error) {
+func GetPidsFromPidNamespace(pidNamespace string, container *provider.Container, checkID string) (p []*Process, err error) {
 	const command = "trap \"\" SIGURG ; ps -e -o pidns,pid,ppid,args"
 	env := provider.GetTestEnvironment()
 	ctx, err := GetNodeDebugPodContext(container.NodeName, &env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get debug pod's context for container %s: %v", container, err)
 	}
+	ctx = ctx.WithCheckID(checkID)
 
 	stdout, stderr, err := clientsholder.GetClientsHolder().ExecCommandContainer(ctx, command)
 	if err != nil || stderr != "" {
@@ -15,3 +15,38 @@
 // 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
 
 package crclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+func TestGetPidFromContainer(t *testing.T) {
+	cut := &provider.Container{Container: &corev1.Container{Name: "container1"}, Runtime: "cri-o", UID: "abcdef"}
+	ctx := clientsholder.NewContext("cnf-suite", "tnf-debug-abcde", "container-00")
+
+	pid, err := getPidFromContainer(cut, ctx, func(_ clientsholder.Context, command string) (string, string, error) {
+		assert.Contains(t, command, "crictl inspect")
+		return "1234\n", "", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1234, pid)
+
+	// The exit status of the command can still be read from the error.
+	_, err = getPidFromContainer(cut, ctx, func(_ clientsholder.Context, _ string) (string, string, error) {
+		return "", "", utilexec.CodeExitError{Err: errors.New("command terminated with exit code 2"), Code: 2}
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, audit.GetExitStatus(err))
+
+	cut.Runtime = "unknown"
+	_, err = getPidFromContainer(cut, ctx, nil)
+	assert.NotNil(t, err)
+}
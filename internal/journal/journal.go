@@ -0,0 +1,290 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package journal keeps track of the changes made by the suite in the cluster, so the ones that
+// were not reverted by a crashed or interrupted run can be undone later with "certsuite cleanup".
+//
+// The journal is a json lines file: each change is appended (and synced to disk) before it's done,
+// and a second line referencing it is appended once the change has been reverted.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+const (
+	// FileName is the name of the journal file that is saved in the output folder.
+	FileName = "side-effects-journal.jsonl"
+
+	TypeNodeCordoned           = "nodeCordoned"
+	TypeReplicasChanged        = "replicasChanged"
+	TypeHpaChanged             = "hpaChanged"
+	TypeDebugDaemonSetDeployed = "debugDaemonSetDeployed"
+	TypeReverted               = "reverted"
+
+	journalFilePermissions = 0o644
+)
+
+// SideEffect is an entry of the journal. The replicas fields hold the values the object had
+// before the suite changed it.
+type SideEffect struct {
+	ID          int    `json:"id"`
+	Time        string `json:"time"`
+	Type        string `json:"type"`
+	Kind        string `json:"kind,omitempty"`
+	Group       string `json:"group,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Replicas    *int32 `json:"replicas,omitempty"`
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// ID of the side effect reverted by a TypeReverted entry.
+	RevertedID int `json:"revertedID,omitempty"`
+}
+
+var (
+	mutex       sync.Mutex
+	journalFile *os.File
+	lastID      int
+)
+
+// Open sets the file where the side effects will be appended and returns the ones already in it
+// that have not been reverted. The side effects of a previous crashed run are kept until they're
+// cleaned up, and the reverted ones are removed from the file so it doesn't grow across runs.
+func Open(filePath string) ([]SideEffect, error) {
+	entries, err := readEntries(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read journal file %s: %v", filePath, err)
+	}
+
+	pending := GetPending(entries)
+	if err == nil {
+		if err := rewrite(filePath, pending); err != nil {
+			return nil, fmt.Errorf("failed to compact journal file %s: %v", filePath, err)
+		}
+	}
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, journalFilePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal file %s: %v", filePath, err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	journalFile = file
+	lastID = 0
+	for i := range entries {
+		lastID = max(lastID, entries[i].ID)
+	}
+
+	return pending, nil
+}
+
+// rewrite replaces the content of the journal file with the given entries. A temporary file is
+// renamed so the pending side effects are not lost if the run crashes meanwhile.
+func rewrite(filePath string, entries []SideEffect) error {
+	var content []byte
+	for i := range entries {
+		line, err := json.Marshal(&entries[i])
+		if err != nil {
+			return err
+		}
+		content = append(content, line...)
+		content = append(content, '\n')
+	}
+
+	tmpFilePath := filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, content, journalFilePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// Close closes the journal file. Side effects recorded afterwards are not saved.
+func Close() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if journalFile == nil {
+		return nil
+	}
+
+	err := journalFile.Close()
+	journalFile = nil
+	return err
+}
+
+// Record appends a side effect to the journal and returns its ID. It must be called before
+// changing the cluster.
+func Record(sideEffect SideEffect) int {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	lastID++
+	sideEffect.ID = lastID
+	sideEffect.Time = time.Now().UTC().Format(time.RFC3339)
+	write(&sideEffect)
+
+	return sideEffect.ID
+}
+
+// RecordNodeCordoned records that a node is going to be cordoned.
+func RecordNodeCordoned(nodeName string) int {
+	return Record(SideEffect{Type: TypeNodeCordoned, Kind: "Node", Name: nodeName})
+}
+
+// RecordReplicasChanged records the original replicas of an object that is going to be scaled. The
+// group and resource are only needed for the objects scaled through the scale subresource.
+func RecordReplicasChanged(kind, group, resource, namespace, name string, replicas int32) int {
+	return Record(SideEffect{Type: TypeReplicasChanged, Kind: kind, Group: group, Resource: resource,
+		Namespace: namespace, Name: name, Replicas: &replicas})
+}
+
+// RecordHpaChanged records the original min and max replicas of an HPA that is going to be changed.
+func RecordHpaChanged(namespace, name string, minReplicas *int32, maxReplicas int32) int {
+	var minCopy *int32
+	if minReplicas != nil {
+		minValue := *minReplicas
+		minCopy = &minValue
+	}
+	return Record(SideEffect{Type: TypeHpaChanged, Kind: "HorizontalPodAutoscaler", Namespace: namespace, Name: name,
+		MinReplicas: minCopy, MaxReplicas: &maxReplicas})
+}
+
+// RecordDebugDaemonSetDeployed records that the debug DaemonSet and its namespace are going to be created.
+func RecordDebugDaemonSetDeployed(namespace, name string) int {
+	return Record(SideEffect{Type: TypeDebugDaemonSetDeployed, Kind: "DaemonSet", Namespace: namespace, Name: name})
+}
+
+// MarkReverted records that the side effect with the given ID has been reverted.
+func MarkReverted(id int) {
+	Record(SideEffect{Type: TypeReverted, RevertedID: id})
+}
+
+func write(sideEffect *SideEffect) {
+	if journalFile == nil {
+		return
+	}
+
+	line, err := json.Marshal(sideEffect)
+	if err != nil {
+		log.Warn("Failed to marshal journal entry %+v: %v", sideEffect, err)
+		return
+	}
+
+	if _, err := journalFile.Write(append(line, '\n')); err != nil {
+		log.Warn("Failed to write journal entry %s: %v", string(line), err)
+		return
+	}
+
+	if err := journalFile.Sync(); err != nil {
+		log.Warn("Failed to sync journal file: %v", err)
+	}
+}
+
+// Load reads all the entries of a journal file.
+func Load(filePath string) ([]SideEffect, error) {
+	entries, err := readEntries(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal file %s: %v", filePath, err)
+	}
+
+	return entries, nil
+}
+
+func readEntries(filePath string) ([]SideEffect, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := []SideEffect{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry SideEffect
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line may be incomplete if the run crashed while writing it.
+			log.Warn("Skipping invalid journal entry %q: %v", scanner.Text(), err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// GetPending returns the side effects that have not been reverted, in the order they were recorded.
+func GetPending(entries []SideEffect) []SideEffect {
+	reverted := map[int]bool{}
+	for i := range entries {
+		if entries[i].Type == TypeReverted {
+			reverted[entries[i].RevertedID] = true
+		}
+	}
+
+	pending := []SideEffect{}
+	for i := range entries {
+		if entries[i].Type != TypeReverted && !reverted[entries[i].ID] {
+			pending = append(pending, entries[i])
+		}
+	}
+
+	return pending
+}
+
+// IsLeftOnPurpose returns true for the side effects that the test suite does not revert, like the
+// debug DaemonSet that is kept deployed to be reused by the next runs.
+func (s *SideEffect) IsLeftOnPurpose() bool {
+	return s.Type == TypeDebugDaemonSetDeployed
+}
+
+// ToString returns a human readable description of the side effect.
+func (s *SideEffect) ToString() string {
+	switch s.Type {
+	case TypeNodeCordoned:
+		return fmt.Sprintf("node %s cordoned", s.Name)
+	case TypeReplicasChanged:
+		return fmt.Sprintf("%s %s/%s replicas changed from %s", s.Kind, s.Namespace, s.Name, int32PtrToString(s.Replicas))
+	case TypeHpaChanged:
+		return fmt.Sprintf("HorizontalPodAutoscaler %s/%s changed from min=%s max=%s", s.Namespace, s.Name,
+			int32PtrToString(s.MinReplicas), int32PtrToString(s.MaxReplicas))
+	case TypeDebugDaemonSetDeployed:
+		return fmt.Sprintf("debug DaemonSet %s deployed in namespace %s", s.Name, s.Namespace)
+	default:
+		return fmt.Sprintf("%s %+v", s.Type, *s)
+	}
+}
+
+func int32PtrToString(value *int32) string {
+	if value == nil {
+		return "<unset>"
+	}
+	return fmt.Sprint(*value)
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPending(t *testing.T) {
+	entries := []SideEffect{
+		{ID: 1, Type: TypeDebugDaemonSetDeployed, Namespace: "cnf-suite", Name: "tnf-debug"},
+		{ID: 2, Type: TypeNodeCordoned, Name: "node1"},
+		{ID: 3, Type: TypeReverted, RevertedID: 2},
+		{ID: 4, Type: TypeNodeCordoned, Name: "node2"},
+	}
+
+	pending := GetPending(entries)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, 1, pending[0].ID)
+	assert.Equal(t, 4, pending[1].ID)
+}
+
+func TestJournalFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), FileName)
+
+	pending, err := Open(filePath)
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+
+	minReplicas := int32(2)
+	cordonID := RecordNodeCordoned("node1")
+	hpaID := RecordHpaChanged("ns1", "hpa1", &minReplicas, 5)
+	RecordReplicasChanged("Deployment", "", "", "ns1", "deployment1", 3)
+	MarkReverted(cordonID)
+	assert.Nil(t, Close())
+
+	// Simulate a run that crashed while writing a line.
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0)
+	assert.Nil(t, err)
+	_, err = file.WriteString(`{"id":5,"type":"nodeCor`)
+	assert.Nil(t, err)
+	file.Close()
+
+	pending, err = Open(filePath)
+	assert.Nil(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, hpaID, pending[0].ID)
+	assert.Equal(t, int32(2), *pending[0].MinReplicas)
+	assert.Equal(t, int32(5), *pending[0].MaxReplicas)
+	assert.Equal(t, "Deployment ns1/deployment1 replicas changed from 3", pending[1].ToString())
+
+	// New entries are appended after the ones of the previous run.
+	assert.Equal(t, 5, RecordNodeCordoned("node2"))
+	assert.Nil(t, Close())
+
+	// The reverted side effects of the previous run were removed.
+	entries, err := Load(filePath)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Len(t, GetPending(entries), 3)
+	assert.NoFileExists(t, filePath+".tmp")
+}
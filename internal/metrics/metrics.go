@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package metrics exposes the results of the last run as Prometheus metrics, either in a file for the
+// node exporter's textfile collector or in the /metrics endpoint of the web server.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+const (
+	namespace = "certsuite"
+
+	// TextfileName is the name of the metrics file saved in the output folder.
+	TextfileName = "certsuite.prom"
+
+	// Layout of the checks' start and end times in the results, without the monotonic clock suffix.
+	resultTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// States of the checks, all of them are exposed for each check so alerts can be set on any of them.
+var checkStates = []string{"passed", "failed", "skipped", "error", "aborted"}
+
+var checkLabels = []string{"suite", "test_id", "far_edge", "telco", "non_telco", "extended"}
+
+var (
+	registry *prometheus.Registry
+	lock     sync.Mutex
+)
+
+func init() {
+	registry = prometheus.NewRegistry()
+}
+
+// Update replaces the metrics with the ones of the given results.
+func Update(results map[string]claim.Result, startTime, endTime time.Time) {
+	newRegistry := NewRegistry(results, startTime, endTime)
+
+	lock.Lock()
+	defer lock.Unlock()
+	registry = newRegistry
+}
+
+// UpdateFromClaimFile replaces the metrics with the results of a claim file, for the runs done by
+// another process like the jobs of the web server.
+func UpdateFromClaimFile(claimFile string) error {
+	content, err := os.ReadFile(claimFile)
+	if err != nil {
+		return err
+	}
+
+	// Only the fields used by the metrics are parsed.
+	var root struct {
+		Claim struct {
+			Metadata *claim.Metadata         `json:"metadata"`
+			Results  map[string]claim.Result `json:"results"`
+		} `json:"claim"`
+	}
+	if err := json.Unmarshal(content, &root); err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %v", claimFile, err)
+	}
+	if root.Claim.Metadata == nil {
+		return fmt.Errorf("claim file %s has no metadata", claimFile)
+	}
+
+	startTime, err := time.Parse(resultTimeLayout, root.Claim.Metadata.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid start time in claim file %s: %v", claimFile, err)
+	}
+	endTime, err := time.Parse(resultTimeLayout, root.Claim.Metadata.EndTime)
+	if err != nil {
+		return fmt.Errorf("invalid end time in claim file %s: %v", claimFile, err)
+	}
+
+	Update(root.Claim.Results, startTime, endTime)
+	return nil
+}
+
+// NewRegistry creates a registry with the metrics of the given results.
+//
+//nolint:funlen
+func NewRegistry(results map[string]claim.Result, startTime, endTime time.Time) *prometheus.Registry {
+	checkState := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "check_state",
+		Help:      "State of the check, 1 for the current state and 0 for the others.",
+	}, append(checkLabels, "state"))
+	checkDuration := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "check_duration_seconds",
+		Help:      "Duration of the check.",
+	}, checkLabels)
+	checkNonCompliantObjects := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "check_non_compliant_objects",
+		Help:      "Number of non-compliant objects found by the check.",
+	}, checkLabels)
+	suiteNonCompliantObjects := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "suite_non_compliant_objects",
+		Help:      "Number of non-compliant objects found by the checks of the suite.",
+	}, []string{"suite"})
+	checks := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "checks",
+		Help:      "Number of checks per state.",
+	}, []string{"state"})
+	runDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "run_duration_seconds",
+		Help:      "Duration of the run.",
+	})
+	score := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "score",
+		Help:      "Ratio of passed checks to the checks that were not skipped, from 0 to 1.",
+	})
+	lastRun := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Unix time of the end of the run.",
+	})
+
+	stateCounts := map[string]int{}
+	for _, state := range checkStates {
+		stateCounts[state] = 0
+	}
+
+	for testID, result := range results {
+		labels := getCheckLabels(testID, &result)
+		for _, state := range checkStates {
+			value := 0.0
+			if state == result.State {
+				value = 1
+			}
+			checkState.With(mergeLabels(labels, prometheus.Labels{"state": state})).Set(value)
+		}
+		stateCounts[result.State]++
+
+		checkDuration.With(labels).Set(getDuration(&result).Seconds())
+
+		nonCompliantObjects := float64(countNonCompliantObjects(result.CheckDetails))
+		checkNonCompliantObjects.With(labels).Set(nonCompliantObjects)
+		suiteNonCompliantObjects.WithLabelValues(labels["suite"]).Add(nonCompliantObjects)
+	}
+
+	for state, count := range stateCounts {
+		checks.WithLabelValues(state).Set(float64(count))
+	}
+
+	runDuration.Set(endTime.Sub(startTime).Seconds())
+	lastRun.Set(float64(endTime.Unix()))
+	if run := len(results) - stateCounts["skipped"]; run > 0 {
+		score.Set(float64(stateCounts["passed"]) / float64(run))
+	}
+
+	newRegistry := prometheus.NewRegistry()
+	newRegistry.MustRegister(checkState, checkDuration, checkNonCompliantObjects, suiteNonCompliantObjects,
+		checks, runDuration, score, lastRun)
+	return newRegistry
+}
+
+func getCheckLabels(testID string, result *claim.Result) prometheus.Labels {
+	labels := prometheus.Labels{"suite": "", "test_id": testID, "far_edge": "", "telco": "", "non_telco": "", "extended": ""}
+	if result.TestID != nil {
+		labels["suite"] = result.TestID.Suite
+	}
+	if result.CategoryClassification != nil {
+		labels["far_edge"] = result.CategoryClassification.FarEdge
+		labels["telco"] = result.CategoryClassification.Telco
+		labels["non_telco"] = result.CategoryClassification.NonTelco
+		labels["extended"] = result.CategoryClassification.Extended
+	}
+	return labels
+}
+
+func mergeLabels(labels, extraLabels prometheus.Labels) prometheus.Labels {
+	merged := prometheus.Labels{}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range extraLabels {
+		merged[k] = v
+	}
+	return merged
+}
+
+// getDuration returns the duration of the check from its start and end times, falling back to the
+// duration in seconds of the result.
+func getDuration(result *claim.Result) time.Duration {
+	start, startErr := time.Parse(resultTimeLayout, strings.Split(result.StartTime, " m=")[0])
+	end, endErr := time.Parse(resultTimeLayout, strings.Split(result.EndTime, " m=")[0])
+	if startErr != nil || endErr != nil {
+		return time.Duration(result.Duration) * time.Second
+	}
+	return end.Sub(start)
+}
+
+func countNonCompliantObjects(checkDetails string) int {
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(checkDetails), &details); err != nil {
+		return 0
+	}
+	return len(details.NonCompliantObjectsOut)
+}
+
+func getRegistry() *prometheus.Registry {
+	lock.Lock()
+	defer lock.Unlock()
+	return registry
+}
+
+// WriteTextfile saves the metrics in a file in the format of the node exporter's textfile collector.
+func WriteTextfile(filename string) error {
+	return prometheus.WriteToTextfile(filename, getRegistry())
+}
+
+// Handler serves the metrics of the last run.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		promhttp.HandlerFor(getRegistry(), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+var testResults = map[string]claim.Result{
+	"access-control-non-root-user": {
+		TestID:       &claim.Identifier{Id: "access-control-non-root-user", Suite: "access-control"},
+		State:        "failed",
+		StartTime:    "2024-05-10 10:00:00.5 +0000 UTC m=+1.000000001",
+		EndTime:      "2024-05-10 10:00:02 +0000 UTC m=+2.500000001",
+		CheckDetails: `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":[{"ObjectType":"Container"},{"ObjectType":"Container"}]}`,
+		CategoryClassification: &claim.CategoryClassification{
+			Extended: "Mandatory",
+			FarEdge:  "Mandatory",
+			NonTelco: "Mandatory",
+			Telco:    "Mandatory",
+		},
+	},
+	"access-control-ssh-daemons": {
+		TestID:       &claim.Identifier{Id: "access-control-ssh-daemons", Suite: "access-control"},
+		State:        "passed",
+		Duration:     3,
+		CheckDetails: `{"CompliantObjectsOut":[{"ObjectType":"Pod"}],"NonCompliantObjectsOut":null}`,
+	},
+	"lifecycle-pod-scheduling": {
+		TestID: &claim.Identifier{Id: "lifecycle-pod-scheduling", Suite: "lifecycle"},
+		State:  "skipped",
+	},
+}
+
+func TestNewRegistry(t *testing.T) {
+	endTime := time.Now()
+	registry := NewRegistry(testResults, endTime.Add(-time.Minute), endTime)
+
+	expected := `
+# HELP certsuite_check_non_compliant_objects Number of non-compliant objects found by the check.
+# TYPE certsuite_check_non_compliant_objects gauge
+certsuite_check_non_compliant_objects{extended="",far_edge="",non_telco="",suite="access-control",telco="",test_id="access-control-ssh-daemons"} 0
+certsuite_check_non_compliant_objects{extended="",far_edge="",non_telco="",suite="lifecycle",telco="",test_id="lifecycle-pod-scheduling"} 0
+certsuite_check_non_compliant_objects{extended="Mandatory",far_edge="Mandatory",non_telco="Mandatory",suite="access-control",telco="Mandatory",test_id="access-control-non-root-user"} 2
+# HELP certsuite_check_duration_seconds Duration of the check.
+# TYPE certsuite_check_duration_seconds gauge
+certsuite_check_duration_seconds{extended="",far_edge="",non_telco="",suite="access-control",telco="",test_id="access-control-ssh-daemons"} 3
+certsuite_check_duration_seconds{extended="",far_edge="",non_telco="",suite="lifecycle",telco="",test_id="lifecycle-pod-scheduling"} 0
+certsuite_check_duration_seconds{extended="Mandatory",far_edge="Mandatory",non_telco="Mandatory",suite="access-control",telco="Mandatory",test_id="access-control-non-root-user"} 1.5
+# HELP certsuite_suite_non_compliant_objects Number of non-compliant objects found by the checks of the suite.
+# TYPE certsuite_suite_non_compliant_objects gauge
+certsuite_suite_non_compliant_objects{suite="access-control"} 2
+certsuite_suite_non_compliant_objects{suite="lifecycle"} 0
+# HELP certsuite_checks Number of checks per state.
+# TYPE certsuite_checks gauge
+certsuite_checks{state="aborted"} 0
+certsuite_checks{state="error"} 0
+certsuite_checks{state="failed"} 1
+certsuite_checks{state="passed"} 1
+certsuite_checks{state="skipped"} 1
+# HELP certsuite_score Ratio of passed checks to the checks that were not skipped, from 0 to 1.
+# TYPE certsuite_score gauge
+certsuite_score 0.5
+# HELP certsuite_run_duration_seconds Duration of the run.
+# TYPE certsuite_run_duration_seconds gauge
+certsuite_run_duration_seconds 60
+`
+	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"certsuite_check_non_compliant_objects", "certsuite_check_duration_seconds", "certsuite_suite_non_compliant_objects",
+		"certsuite_checks", "certsuite_score", "certsuite_run_duration_seconds"))
+
+	count, err := testutil.GatherAndCount(registry, "certsuite_check_state")
+	assert.Nil(t, err)
+	assert.Equal(t, len(testResults)*len(checkStates), count)
+}
+
+func TestWriteTextfileAndHandler(t *testing.T) {
+	endTime := time.Now()
+	Update(testResults, endTime.Add(-time.Minute), endTime)
+
+	filename := filepath.Join(t.TempDir(), TextfileName)
+	assert.Nil(t, WriteTextfile(filename))
+	content, err := os.ReadFile(filename)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `certsuite_check_state{extended="",far_edge="",non_telco="",state="passed",suite="access-control",telco="",test_id="access-control-ssh-daemons"} 1`)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", http.NoBody))
+	assert.Contains(t, recorder.Body.String(), "certsuite_score 0.5")
+}
+
+func TestUpdateFromClaimFile(t *testing.T) {
+	results, err := json.Marshal(testResults)
+	assert.Nil(t, err)
+	claimFile := filepath.Join(t.TempDir(), "claim.json")
+	content := `{"claim": {"metadata": {"startTime": "2024-05-10 10:00:00 +0000 UTC", "endTime": "2024-05-10 10:05:00 +0000 UTC"}, "results": ` +
+		string(results) + `}}`
+	assert.Nil(t, os.WriteFile(claimFile, []byte(content), 0o600))
+
+	assert.Nil(t, UpdateFromClaimFile(claimFile))
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", http.NoBody))
+	assert.Contains(t, recorder.Body.String(), "certsuite_run_duration_seconds 300")
+	assert.Contains(t, recorder.Body.String(), "certsuite_score 0.5")
+
+	assert.Nil(t, os.WriteFile(claimFile, []byte(`{"claim": {}}`), 0o600))
+	assert.NotNil(t, UpdateFromClaimFile(claimFile))
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package permissions verifies, using SelfSubjectAccessReviews, that the identity used by the suite
+// has the API permissions required by the checks.
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Namespace placeholders that are resolved once the configuration has been loaded.
+	TargetNamespaces = "<target-namespaces>"
+	DebugNamespace   = "<debug-namespace>"
+	// Resource placeholder of the scalable custom resources under test, resolved once their CRDs are found.
+	ScalableCustomResources = "<scalable-custom-resources>"
+)
+
+// Permission is an API permission. An empty namespace means all the namespaces, or a cluster
+// scoped resource.
+type Permission struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	Namespace   string
+}
+
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+	if p.Subresource != "" {
+		resource += "/" + p.Subresource
+	}
+
+	if p.Namespace == "" {
+		return fmt.Sprintf("%s %s", p.Verb, resource)
+	}
+	return fmt.Sprintf("%s %s in namespace %s", p.Verb, resource, p.Namespace)
+}
+
+// Resolve replaces the placeholders of the permissions by the actual namespaces and resources. Permissions
+// on the target namespaces are repeated for each one of them, and the ones on the scalable custom resources
+// for each of their group resources.
+func Resolve(perms []Permission, targetNamespaces []string, debugNamespace string, scalableResources []schema.GroupResource) []Permission {
+	resolved := []Permission{}
+	for _, perm := range resolveResources(perms, scalableResources) {
+		switch perm.Namespace {
+		case TargetNamespaces:
+			for _, namespace := range targetNamespaces {
+				perm.Namespace = namespace
+				resolved = append(resolved, perm)
+			}
+		case DebugNamespace:
+			perm.Namespace = debugNamespace
+			resolved = append(resolved, perm)
+		default:
+			resolved = append(resolved, perm)
+		}
+	}
+	return resolved
+}
+
+func resolveResources(perms []Permission, scalableResources []schema.GroupResource) []Permission {
+	resolved := []Permission{}
+	for _, perm := range perms {
+		if perm.Resource != ScalableCustomResources {
+			resolved = append(resolved, perm)
+			continue
+		}
+		for _, groupResource := range scalableResources {
+			perm.Group = groupResource.Group
+			perm.Resource = groupResource.Resource
+			resolved = append(resolved, perm)
+		}
+	}
+	return resolved
+}
+
+// Checker runs SelfSubjectAccessReviews, caching the results so each permission is reviewed once.
+type Checker struct {
+	client  kubernetes.Interface
+	results map[Permission]bool
+}
+
+func NewChecker(client kubernetes.Interface) *Checker {
+	return &Checker{
+		client:  client,
+		results: map[Permission]bool{},
+	}
+}
+
+// IsAllowed returns true if the current identity has the permission.
+func (c *Checker) IsAllowed(perm Permission) (bool, error) {
+	if allowed, found := c.results[perm]; found {
+		return allowed, nil
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   perm.Namespace,
+				Verb:        perm.Verb,
+				Group:       perm.Group,
+				Resource:    perm.Resource,
+				Subresource: perm.Subresource,
+			},
+		},
+	}
+
+	result, err := c.client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review permission %q: %v", perm, err)
+	}
+
+	c.results[perm] = result.Status.Allowed
+	return result.Status.Allowed, nil
+}
+
+// GetMissing returns the permissions that the current identity does not have.
+func (c *Checker) GetMissing(perms []Permission) ([]Permission, error) {
+	missing := []Permission{}
+	for _, perm := range perms {
+		allowed, err := c.IsAllowed(perm)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			missing = append(missing, perm)
+		}
+	}
+	return missing, nil
+}
+
+// ToString returns a sorted, comma separated list of the permissions.
+func ToString(perms []Permission) string {
+	strs := []string{}
+	for _, perm := range perms {
+		strs = append(strs, perm.String())
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ", ")
+}
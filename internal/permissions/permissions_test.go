@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestResolve(t *testing.T) {
+	perms := []Permission{
+		{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: DebugNamespace},
+		{Verb: "get", Resource: "pods", Subresource: "log", Namespace: TargetNamespaces},
+		{Verb: "get", Resource: "nodes"},
+		{Verb: "update", Resource: ScalableCustomResources, Subresource: "scale", Namespace: TargetNamespaces},
+	}
+
+	resolved := Resolve(perms, []string{"ns1", "ns2"}, "debug-ns", []schema.GroupResource{{Group: "example.com", Resource: "memcacheds"}})
+	assert.Equal(t, []Permission{
+		{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: "debug-ns"},
+		{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "ns1"},
+		{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "ns2"},
+		{Verb: "get", Resource: "nodes"},
+		{Verb: "update", Group: "example.com", Resource: "memcacheds", Subresource: "scale", Namespace: "ns1"},
+		{Verb: "update", Group: "example.com", Resource: "memcacheds", Subresource: "scale", Namespace: "ns2"},
+	}, resolved)
+
+	// Without scalable custom resources there's nothing to review.
+	assert.Empty(t, Resolve(perms[4:], []string{"ns1"}, "debug-ns", nil))
+}
+
+func TestGetMissing(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	reviews := 0
+	client.Fake.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		// Only the nodes are forbidden.
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "nodes"
+		return true, review, nil
+	})
+
+	checker := NewChecker(client)
+	perms := []Permission{
+		{Verb: "update", Resource: "nodes"},
+		{Verb: "delete", Resource: "pods", Namespace: "ns1"},
+		{Verb: "update", Resource: "nodes"},
+	}
+
+	missing, err := checker.GetMissing(perms)
+	assert.Nil(t, err)
+	assert.Equal(t, []Permission{{Verb: "update", Resource: "nodes"}, {Verb: "update", Resource: "nodes"}}, missing)
+	// Repeated permissions are reviewed once.
+	assert.Equal(t, 2, reviews)
+}
+
+func TestToString(t *testing.T) {
+	perms := []Permission{
+		{Verb: "get", Resource: "pods", Subresource: "log", Namespace: "ns1"},
+		{Verb: "update", Group: "apps", Resource: "deployments", Namespace: "ns1"},
+		{Verb: "delete", Resource: "pods"},
+	}
+
+	assert.Equal(t, "delete pods, get pods/log in namespace ns1, update deployments.apps in namespace ns1", ToString(perms))
+	assert.Equal(t, "", ToString(nil))
+}
@@ -0,0 +1,457 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package redaction replaces the sensitive data of the claim, the log and the results artifacts, like IPs,
+// hostnames or image registries, by tokens. A value is always replaced by the same token, so the references
+// between the redacted files still work, and the tokens can be mapped back to the values with the mapping
+// file, which must be kept locally.
+package redaction
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+const (
+	// MappingFileName is the name of the tokens mapping file saved in the output folder, unless the redaction
+	// policy sets another path.
+	MappingFileName = "certsuite-redaction-mapping.json"
+
+	// Categories of the redacted values, used in their tokens.
+	CategoryIP       = "ip"
+	CategoryHost     = "host"
+	CategoryRegistry = "registry"
+	CategoryValue    = "value"
+	CategoryCustom   = "custom"
+
+	tokenPrefix      = "redacted-"
+	mappingFilePerms = 0o600
+
+	hostnameLabel = "kubernetes.io/hostname"
+)
+
+var (
+	tokenRegex = regexp.MustCompile(tokenPrefix + "(" + strings.Join([]string{CategoryIP, CategoryHost, CategoryRegistry, CategoryValue, CategoryCustom}, "|") + ")-([0-9]+)")
+	ipv4Regex  = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
+	// Candidates only, they're checked with net.ParseIP.
+	ipv6Regex = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f:]*:[0-9A-Fa-f]*`)
+	// Image references with a registry host, a repository and a tag or a digest. The first group is the registry.
+	imageRegex = regexp.MustCompile(`\b((?:[a-zA-Z0-9-]+\.)+[a-zA-Z0-9-]+(?::[0-9]+)?|localhost(?::[0-9]+)?)/[a-z0-9]+(?:[._/-][a-z0-9]+)*(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]*|@sha256:[a-f0-9]{64})`)
+	// Runs of the characters of the hostnames: a hostname is only redacted when it's a whole run, so the
+	// hostnames that are a prefix of others, like worker-1 of worker-10, are not redacted inside them.
+	hostRunRegex = regexp.MustCompile(`[A-Za-z0-9.-]+`)
+	// Types of the node addresses that hold hostnames.
+	hostnameAddressTypes = map[string]bool{"Hostname": true, "InternalDNS": true, "ExternalDNS": true}
+)
+
+// Redactor replaces the sensitive data set in a redaction policy by tokens.
+type Redactor struct {
+	policy         configuration.RedactionPolicy
+	customRegexes  []*regexp.Regexp
+	keptRegistries map[string]bool
+	annotationKeys map[string]bool
+	labelKeys      map[string]bool
+
+	// Tokens by value and values by token.
+	tokens   map[string]string
+	values   map[string]string
+	counters map[string]int
+
+	// Hostnames found in the claim, replaced in every file.
+	hosts          map[string]bool
+	hostsTokenized bool
+}
+
+// New returns a redactor for the policy. The tokens of the mapping file, if it exists, are reused so the
+// values get the same tokens as in previous runs.
+func New(policy *configuration.RedactionPolicy, mappingFile string) (*Redactor, error) {
+	r := &Redactor{
+		policy:         *policy,
+		keptRegistries: toSet(policy.KeptRegistries),
+		annotationKeys: toSet(policy.AnnotationKeys),
+		labelKeys:      toSet(policy.LabelKeys),
+		tokens:         map[string]string{},
+		values:         map[string]string{},
+		counters:       map[string]int{},
+		hosts:          map[string]bool{},
+	}
+
+	for _, pattern := range policy.CustomPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %v", pattern, err)
+		}
+		r.customRegexes = append(r.customRegexes, regex)
+	}
+
+	mapping, err := LoadMapping(mappingFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for token, value := range mapping {
+		match := tokenRegex.FindStringSubmatch(token)
+		if match == nil || match[0] != token {
+			return nil, fmt.Errorf("invalid token %q in the mapping file %s", token, mappingFile)
+		}
+		r.tokens[value] = token
+		r.values[token] = value
+		if n, _ := strconv.Atoi(match[2]); n > r.counters[match[1]] {
+			r.counters[match[1]] = n
+		}
+	}
+
+	return r, nil
+}
+
+func toSet(items []string) map[string]bool {
+	set := map[string]bool{}
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+func (r *Redactor) getToken(category, value string) string {
+	if token, found := r.tokens[value]; found {
+		return token
+	}
+
+	r.counters[category]++
+	token := fmt.Sprintf("%s%s-%d", tokenPrefix, category, r.counters[category])
+	r.tokens[value] = token
+	r.values[token] = value
+	return token
+}
+
+func (r *Redactor) addHost(host string) {
+	if host == "" || r.hosts[host] {
+		return
+	}
+	r.hosts[host] = true
+	r.hostsTokenized = false
+}
+
+// tokenizeHosts gets the tokens of the new hostnames, the longest ones first, so the tokens don't depend on the
+// order the hostnames are found in the files.
+func (r *Redactor) tokenizeHosts() {
+	if r.hostsTokenized {
+		return
+	}
+
+	hosts := []string{}
+	for host := range r.hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if len(hosts[i]) != len(hosts[j]) {
+			return len(hosts[i]) > len(hosts[j])
+		}
+		return hosts[i] < hosts[j]
+	})
+
+	for _, host := range hosts {
+		r.getToken(CategoryHost, host)
+	}
+	r.hostsTokenized = true
+}
+
+func (r *Redactor) redactHosts(text string) string {
+	r.tokenizeHosts()
+	return hostRunRegex.ReplaceAllStringFunc(text, func(match string) string {
+		if r.hosts[match] {
+			return r.tokens[match]
+		}
+		return match
+	})
+}
+
+// RedactText replaces the hostnames found in the claim, the matches of the custom patterns, the image registries
+// and the IPs of the text by their tokens.
+func (r *Redactor) RedactText(text string) string {
+	if r.policy.Hostnames && len(r.hosts) > 0 {
+		text = r.redactHosts(text)
+	}
+
+	for _, regex := range r.customRegexes {
+		text = regex.ReplaceAllStringFunc(text, func(match string) string {
+			if tokenRegex.MatchString(match) {
+				return match
+			}
+			return r.getToken(CategoryCustom, match)
+		})
+	}
+
+	if r.policy.ImageRegistries {
+		text = r.redactRegistries(text)
+	}
+
+	if r.policy.IPs {
+		text = ipv4Regex.ReplaceAllStringFunc(text, func(match string) string {
+			if net.ParseIP(match) == nil {
+				return match
+			}
+			return r.getToken(CategoryIP, match)
+		})
+		text = ipv6Regex.ReplaceAllStringFunc(text, func(match string) string {
+			if ip := net.ParseIP(match); ip == nil || ip.To4() != nil || strings.Trim(match, ":") == "" {
+				return match
+			}
+			return r.getToken(CategoryIP, match)
+		})
+	}
+
+	return text
+}
+
+func (r *Redactor) redactRegistries(text string) string {
+	var redacted strings.Builder
+	last := 0
+	for _, match := range imageRegex.FindAllStringSubmatchIndex(text, -1) {
+		registry := text[match[2]:match[3]]
+		if r.keptRegistries[registry] || tokenRegex.MatchString(registry) {
+			continue
+		}
+		redacted.WriteString(text[last:match[2]])
+		redacted.WriteString(r.getToken(CategoryRegistry, registry))
+		last = match[3]
+	}
+	redacted.WriteString(text[last:])
+	return redacted.String()
+}
+
+// RedactJSON redacts a JSON content, like the claim's, string by string. The hostnames of the nodes are
+// collected first, so they're replaced in the rest of the redacted files too, and the values of the annotations
+// and labels with the policy's keys are replaced where they're set.
+func (r *Redactor) RedactJSON(content []byte) ([]byte, error) {
+	root, err := unmarshalJSON(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.policy.Hostnames {
+		r.collectHosts(root, "")
+	}
+
+	redacted, err := json.MarshalIndent(r.redactNode(root, ""), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the redacted json: %v", err)
+	}
+	return redacted, nil
+}
+
+func unmarshalJSON(content []byte) (interface{}, error) {
+	var root interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keep the numbers as they are in the file.
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the json content: %v", err)
+	}
+	return root, nil
+}
+
+// collectHosts collects the node names, the hostname labels and the hostname addresses of the nodes.
+func (r *Redactor) collectHosts(node interface{}, key string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if key == "nodeSummary" {
+			for name := range value {
+				r.addHost(name)
+			}
+		}
+		if key == "labels" {
+			if host, ok := value[hostnameLabel].(string); ok {
+				r.addHost(host)
+			}
+		}
+		if addressType, ok := value["type"].(string); ok && key == "addresses" && hostnameAddressTypes[addressType] {
+			if host, ok := value["address"].(string); ok {
+				r.addHost(host)
+			}
+		}
+		for childKey, child := range value {
+			r.collectHosts(child, childKey)
+		}
+	case []interface{}:
+		for _, child := range value {
+			// The items of a list are collected with the key of the list.
+			r.collectHosts(child, key)
+		}
+	}
+}
+
+func (r *Redactor) redactNode(node interface{}, key string) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		redacted := map[string]interface{}{}
+		for childKey, child := range value {
+			if str, ok := child.(string); ok && (key == "annotations" && r.annotationKeys[childKey] || key == "labels" && r.labelKeys[childKey]) {
+				redacted[r.RedactText(childKey)] = r.getToken(CategoryValue, str)
+				continue
+			}
+			redacted[r.RedactText(childKey)] = r.redactNode(child, childKey)
+		}
+		return redacted
+	case []interface{}:
+		redacted := []interface{}{}
+		for _, child := range value {
+			redacted = append(redacted, r.redactNode(child, key))
+		}
+		return redacted
+	case string:
+		return r.RedactText(value)
+	default:
+		return value
+	}
+}
+
+// RedactClaimFile redacts the claim file in place.
+func (r *Redactor) RedactClaimFile(claimFile string) error {
+	content, err := os.ReadFile(claimFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the claim file %s: %v", claimFile, err)
+	}
+
+	redacted, err := r.RedactJSON(content)
+	if err != nil {
+		return fmt.Errorf("failed to redact the claim file %s: %v", claimFile, err)
+	}
+
+	return writeFile(claimFile, redacted)
+}
+
+// RedactFile writes the redacted content of a file into outputFile, which can be the same file. JSON files are
+// redacted string by string, so they're still valid, and the rest as plain text.
+func (r *Redactor) RedactFile(file, outputFile string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+
+	if strings.HasSuffix(file, ".json") && json.Valid(content) {
+		redacted, err := r.RedactJSON(content)
+		if err != nil {
+			return fmt.Errorf("failed to redact file %s: %v", file, err)
+		}
+		return writeFile(outputFile, redacted)
+	}
+
+	return writeFile(outputFile, []byte(r.RedactText(string(content))))
+}
+
+// writeFile writes the file keeping its permissions, if it exists.
+func writeFile(file string, content []byte) error {
+	perms := os.FileMode(0o644)
+	if info, err := os.Stat(file); err == nil {
+		perms = info.Mode().Perm()
+	}
+
+	if err := os.WriteFile(file, content, perms); err != nil {
+		return fmt.Errorf("failed to write file %s: %v", file, err)
+	}
+	return nil
+}
+
+// GetMapping returns the redacted values by token.
+func (r *Redactor) GetMapping() map[string]string {
+	mapping := map[string]string{}
+	for token, value := range r.values {
+		mapping[token] = value
+	}
+	return mapping
+}
+
+// SaveMapping writes the mapping of the tokens to the redacted values into the mapping file, readable only by
+// its owner.
+func (r *Redactor) SaveMapping(mappingFile string) error {
+	content, err := json.MarshalIndent(r.GetMapping(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the redaction mapping: %v", err)
+	}
+
+	if err := os.WriteFile(mappingFile, content, mappingFilePerms); err != nil {
+		return fmt.Errorf("failed to write the redaction mapping file %s: %v", mappingFile, err)
+	}
+	return nil
+}
+
+// LoadMapping reads the mapping of the tokens to the redacted values from the mapping file.
+func LoadMapping(mappingFile string) (map[string]string, error) {
+	content, err := os.ReadFile(mappingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := map[string]string{}
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the redaction mapping file %s: %v", mappingFile, err)
+	}
+	return mapping, nil
+}
+
+// Unredact replaces the tokens of the text by their values in the mapping. Unknown tokens are kept.
+func Unredact(text string, mapping map[string]string) string {
+	return tokenRegex.ReplaceAllStringFunc(text, func(token string) string {
+		if value, found := mapping[token]; found {
+			return value
+		}
+		return token
+	})
+}
+
+// UnredactJSON replaces the tokens of a JSON content string by string, so the values are escaped as needed.
+func UnredactJSON(content []byte, mapping map[string]string) ([]byte, error) {
+	root, err := unmarshalJSON(content)
+	if err != nil {
+		return nil, err
+	}
+
+	unredacted, err := json.MarshalIndent(unredactNode(root, mapping), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the unredacted json: %v", err)
+	}
+	return unredacted, nil
+}
+
+func unredactNode(node interface{}, mapping map[string]string) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		unredacted := map[string]interface{}{}
+		for key, child := range value {
+			unredacted[Unredact(key, mapping)] = unredactNode(child, mapping)
+		}
+		return unredacted
+	case []interface{}:
+		unredacted := []interface{}{}
+		for _, child := range value {
+			unredacted = append(unredacted, unredactNode(child, mapping))
+		}
+		return unredacted
+	case string:
+		return Unredact(value, mapping)
+	default:
+		return value
+	}
+}
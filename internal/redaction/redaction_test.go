@@ -0,0 +1,177 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package redaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+const testClaim = `{
+  "claim": {
+    "nodes": {
+      "nodeSummary": {
+        "worker-0.example.com": {
+          "metadata": {
+            "labels": {"kubernetes.io/hostname": "worker-0", "topology.kubernetes.io/zone": "zone-a"},
+            "annotations": {"k8s.ovn.org/node-primary-ifaddr": "{\"ipv4\":\"10.0.0.5/24\"}", "owner": "team-a"}
+          },
+          "status": {
+            "addresses": [
+              {"type": "InternalIP", "address": "10.0.0.5"},
+              {"type": "Hostname", "address": "worker-0.example.com"}
+            ]
+          }
+        }
+      }
+    },
+    "results": {
+      "test1": {"state": "passed", "duration": 3, "checkDetails": "pod image quay.io/acme/app:v1 on worker-0 fd00::1"}
+    }
+  }
+}`
+
+func TestRedactText(t *testing.T) {
+	r, err := New(&configuration.RedactionPolicy{
+		IPs:             true,
+		ImageRegistries: true,
+		KeptRegistries:  []string{"registry.redhat.io"},
+		CustomPatterns:  []string{`secret-[a-z]+`},
+	}, filepath.Join(t.TempDir(), MappingFileName))
+	assert.Nil(t, err)
+
+	text := "pod 10.0.0.5 secret-abc uses quay.io/acme/app:v1 and registry.redhat.io/ubi9/ubi:latest, node fd00::1 10.0.0.5"
+	expected := "pod redacted-ip-1 redacted-custom-1 uses redacted-registry-1/acme/app:v1 and registry.redhat.io/ubi9/ubi:latest, node redacted-ip-2 redacted-ip-1"
+	assert.Equal(t, expected, r.RedactText(text))
+	// The same values get the same tokens.
+	assert.Equal(t, expected, r.RedactText(text))
+
+	// Not IPs, image references or IPv6 addresses.
+	for _, text := range []string{"version 4.14.0", "999.1.1.1", "k8s.ovn.org/node-subnets", "time 10:45:00", "a :: b"} {
+		assert.Equal(t, text, r.RedactText(text))
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	r, err := New(&configuration.RedactionPolicy{
+		IPs:             true,
+		Hostnames:       true,
+		ImageRegistries: true,
+		AnnotationKeys:  []string{"owner"},
+		LabelKeys:       []string{"topology.kubernetes.io/zone"},
+	}, filepath.Join(t.TempDir(), MappingFileName))
+	assert.Nil(t, err)
+
+	redacted, err := r.RedactJSON([]byte(testClaim))
+	assert.Nil(t, err)
+	for _, value := range []string{"worker-0", "10.0.0.5", "fd00::1", "quay.io", "team-a", "zone-a"} {
+		assert.NotContains(t, string(redacted), value)
+	}
+	assert.Contains(t, string(redacted), "k8s.ovn.org/node-primary-ifaddr")
+	assert.Contains(t, string(redacted), `"duration": 3`)
+
+	// The hostnames of the claim are redacted in the other files.
+	// The longest ones first, so worker-0.example.com is not redacted as redacted-host-2.example.com.
+	assert.Equal(t, "log line of redacted-host-1 redacted-host-2", r.RedactText("log line of worker-0.example.com worker-0"))
+
+	// The hostnames are not redacted inside other words.
+	assert.Equal(t, "pod worker-0-abc on redacted-host-2, host worker-0.example.com.lab", r.RedactText("pod worker-0-abc on worker-0, host worker-0.example.com.lab"))
+
+	// The redacted claim is still valid JSON, and the unredacted one is the original.
+	unredacted, err := UnredactJSON(redacted, r.GetMapping())
+	assert.Nil(t, err)
+	assert.JSONEq(t, testClaim, string(unredacted))
+}
+
+func TestRedactHostPrefixes(t *testing.T) {
+	r, err := New(&configuration.RedactionPolicy{Hostnames: true}, filepath.Join(t.TempDir(), MappingFileName))
+	assert.Nil(t, err)
+	r.addHost("worker-1")
+	r.addHost("worker-10")
+	r.addHost("m1")
+
+	text := "pods worker-10-abc and worker-1-def on worker-1 and worker-10 (worker-1.example.com), m1: ok, cm1 m10"
+	redacted := r.RedactText(text)
+	assert.Equal(t, "pods worker-10-abc and worker-1-def on redacted-host-2 and redacted-host-1 (worker-1.example.com), redacted-host-3: ok, cm1 m10", redacted)
+
+	// The round trip gets the original text back.
+	assert.Equal(t, text, Unredact(redacted, r.GetMapping()))
+}
+
+func TestMapping(t *testing.T) {
+	mappingFile := filepath.Join(t.TempDir(), MappingFileName)
+	policy := &configuration.RedactionPolicy{IPs: true}
+
+	r, err := New(policy, mappingFile)
+	assert.Nil(t, err)
+	text := ""
+	for i := 1; i <= 10; i++ {
+		text += fmt.Sprintf("10.0.0.%d ", i)
+	}
+	redacted := r.RedactText(text)
+	assert.Nil(t, r.SaveMapping(mappingFile))
+
+	info, err := os.Stat(mappingFile)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(mappingFilePerms), info.Mode().Perm())
+
+	mapping, err := LoadMapping(mappingFile)
+	assert.Nil(t, err)
+	assert.Len(t, mapping, 10)
+	// redacted-ip-10 is not unredacted as redacted-ip-1 followed by a 0.
+	assert.Equal(t, text, Unredact(redacted, mapping))
+
+	// The tokens of the mapping file are reused, and the new values get the next ones.
+	r, err = New(policy, mappingFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "redacted-ip-10 redacted-ip-11", r.RedactText("10.0.0.10 10.0.0.11"))
+
+	// Invalid mapping files and patterns.
+	assert.Nil(t, os.WriteFile(mappingFile, []byte(`{"some-token": "value"}`), mappingFilePerms))
+	_, err = New(policy, mappingFile)
+	assert.NotNil(t, err)
+	_, err = New(&configuration.RedactionPolicy{CustomPatterns: []string{"("}}, filepath.Join(t.TempDir(), MappingFileName))
+	assert.NotNil(t, err)
+}
+
+func TestRedactFile(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(&configuration.RedactionPolicy{IPs: true}, filepath.Join(dir, MappingFileName))
+	assert.Nil(t, err)
+
+	jsonFile := filepath.Join(dir, "results.json")
+	assert.Nil(t, os.WriteFile(jsonFile, []byte(`{"message": "node 10.0.0.5"}`), 0o644))
+	assert.Nil(t, r.RedactFile(jsonFile, jsonFile))
+	content, err := os.ReadFile(jsonFile)
+	assert.Nil(t, err)
+	assert.True(t, json.Valid(content))
+	assert.JSONEq(t, `{"message": "node redacted-ip-1"}`, string(content))
+
+	logFile := filepath.Join(dir, "certsuite.log")
+	assert.Nil(t, os.WriteFile(logFile, []byte("connected to 10.0.0.6\n"), 0o644))
+	redactedLogFile := filepath.Join(dir, "redacted.log")
+	assert.Nil(t, r.RedactFile(logFile, redactedLogFile))
+	content, err = os.ReadFile(redactedLogFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "connected to redacted-ip-2\n", string(content))
+}
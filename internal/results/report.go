@@ -0,0 +1,342 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package results
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+const (
+	ReportFormatHTML     = "html"
+	ReportFormatMarkdown = "md"
+
+	// ReportFileName is the name of the report file saved in the output folder, without the extension.
+	ReportFileName = "certsuite-report"
+
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+)
+
+var ReportFormats = []string{ReportFormatHTML, ReportFormatMarkdown}
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplate string
+
+//go:embed templates/report.md.tmpl
+var markdownReportTemplate string
+
+type ReportField struct {
+	Key   string
+	Value string
+}
+
+type ReportObject struct {
+	Type   string
+	Reason string
+	Fields []ReportField
+}
+
+type ReportTestCase struct {
+	ID                  string
+	Suite               string
+	State               string
+	Description         string
+	Remediation         string
+	ExceptionProcess    string
+	DocLink             string
+	SkipReason          string
+	NonCompliantObjects []ReportObject
+}
+
+type ReportSummary struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+	Other   int
+}
+
+type ReportSuite struct {
+	Name string
+	ReportSummary
+	TestCases []ReportTestCase
+}
+
+type ReportNode struct {
+	Name             string
+	Roles            string
+	OSImage          string
+	KernelVersion    string
+	KubeletVersion   string
+	ContainerRuntime string
+	Architecture     string
+}
+
+// ReportData is the view of the claim file used by the report templates.
+type ReportData struct {
+	Versions         claim.Versions
+	StartTime        string
+	EndTime          string
+	TargetNamespaces []string
+	Summary          ReportSummary
+	Suites           []ReportSuite
+	FailedTestCases  []ReportTestCase
+	Nodes            []ReportNode
+}
+
+func (s *ReportSummary) add(state string) {
+	s.Total++
+	switch state {
+	case "passed":
+		s.Passed++
+	case "failed":
+		s.Failed++
+	case "skipped":
+		s.Skipped++
+	default:
+		s.Other++
+	}
+}
+
+// NewReportData builds the report view of a claim.
+func NewReportData(root *claim.Root) (*ReportData, error) {
+	if root == nil || root.Claim == nil {
+		return nil, fmt.Errorf("the claim file has no claim")
+	}
+
+	data := &ReportData{}
+	if root.Claim.Versions != nil {
+		data.Versions = *root.Claim.Versions
+	}
+	if root.Claim.Metadata != nil {
+		data.StartTime = root.Claim.Metadata.StartTime
+		data.EndTime = root.Claim.Metadata.EndTime
+	}
+	data.TargetNamespaces = getTargetNamespaces(root.Claim.Configurations)
+	data.Nodes = getReportNodes(root.Claim.Nodes)
+
+	testIDs := make([]string, 0, len(root.Claim.Results))
+	for testID := range root.Claim.Results {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Strings(testIDs)
+
+	suites := map[string]*ReportSuite{}
+	suiteNames := []string{}
+	for _, testID := range testIDs {
+		testCase := newReportTestCase(testID, root.Claim.Results[testID])
+
+		suite, found := suites[testCase.Suite]
+		if !found {
+			suite = &ReportSuite{Name: testCase.Suite}
+			suites[testCase.Suite] = suite
+			suiteNames = append(suiteNames, testCase.Suite)
+		}
+		suite.add(testCase.State)
+		suite.TestCases = append(suite.TestCases, testCase)
+		data.Summary.add(testCase.State)
+
+		if testCase.State == "failed" {
+			data.FailedTestCases = append(data.FailedTestCases, testCase)
+		}
+	}
+
+	sort.Strings(suiteNames)
+	for _, name := range suiteNames {
+		data.Suites = append(data.Suites, *suites[name])
+	}
+
+	return data, nil
+}
+
+func newReportTestCase(testID string, result claim.Result) ReportTestCase {
+	testCase := ReportTestCase{ID: testID, State: result.State}
+	// The skip reason also holds the reason of the aborted and errored test cases.
+	if result.State != "passed" && result.State != "failed" {
+		testCase.SkipReason = result.SkipReason
+	}
+	if result.TestID != nil {
+		testCase.Suite = result.TestID.Suite
+	}
+	if result.CatalogInfo != nil {
+		testCase.Description = result.CatalogInfo.Description
+		testCase.Remediation = result.CatalogInfo.Remediation
+		testCase.ExceptionProcess = result.CatalogInfo.ExceptionProcess
+		testCase.DocLink = result.CatalogInfo.BestPracticeReference
+	}
+
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(result.CheckDetails), &details); err == nil {
+		for _, object := range details.NonCompliantObjectsOut {
+			testCase.NonCompliantObjects = append(testCase.NonCompliantObjects, newReportObject(object))
+		}
+	}
+
+	return testCase
+}
+
+func newReportObject(object *testhelper.ReportObject) ReportObject {
+	reportObject := ReportObject{Type: object.ObjectType}
+	for i, key := range object.ObjectFieldsKeys {
+		if i >= len(object.ObjectFieldsValues) {
+			break
+		}
+		if key == testhelper.ReasonForNonCompliance {
+			reportObject.Reason = object.ObjectFieldsValues[i]
+			continue
+		}
+		reportObject.Fields = append(reportObject.Fields, ReportField{Key: key, Value: object.ObjectFieldsValues[i]})
+	}
+	return reportObject
+}
+
+func getTargetNamespaces(configurations map[string]interface{}) []string {
+	config, ok := configurations["Config"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	namespaces, ok := config["targetNameSpaces"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := []string{}
+	for _, ns := range namespaces {
+		if nsMap, ok := ns.(map[string]interface{}); ok {
+			names = append(names, fmt.Sprint(nsMap["name"]))
+		}
+	}
+	return names
+}
+
+// getReportNodes gets the nodes info from the nodeSummary section of the claim, which holds the node objects.
+func getReportNodes(claimNodes map[string]interface{}) []ReportNode {
+	nodeSummary, ok := claimNodes["nodeSummary"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	nodes := []ReportNode{}
+	for name, node := range nodeSummary {
+		reportNode := ReportNode{Name: name}
+
+		roles := []string{}
+		for label := range getMap(node, "metadata", "labels") {
+			if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+				roles = append(roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+			}
+		}
+		sort.Strings(roles)
+		reportNode.Roles = strings.Join(roles, ",")
+
+		nodeInfo := getMap(node, "status", "nodeInfo")
+		reportNode.OSImage = getString(nodeInfo, "osImage")
+		reportNode.KernelVersion = getString(nodeInfo, "kernelVersion")
+		reportNode.KubeletVersion = getString(nodeInfo, "kubeletVersion")
+		reportNode.ContainerRuntime = getString(nodeInfo, "containerRuntimeVersion")
+		reportNode.Architecture = getString(nodeInfo, "architecture")
+
+		nodes = append(nodes, reportNode)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+func getMap(object interface{}, keys ...string) map[string]interface{} {
+	current, _ := object.(map[string]interface{})
+	for _, key := range keys {
+		current, _ = current[key].(map[string]interface{})
+	}
+	return current
+}
+
+func getString(object map[string]interface{}, key string) string {
+	value, ok := object[key].(string)
+	if !ok {
+		return ""
+	}
+	return value
+}
+
+// markdownCell escapes a value so it can be placed in a markdown table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+}
+
+// RenderReport writes the report of a claim in the given format, html or md. Both formats produce a
+// single self-contained file.
+func RenderReport(w io.Writer, root *claim.Root, format string) error {
+	data, err := NewReportData(root)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ReportFormatHTML:
+		tmpl, err := htmltemplate.New("report").Parse(htmlReportTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to parse the html report template: %v", err)
+		}
+		return tmpl.Execute(w, data)
+	case ReportFormatMarkdown:
+		tmpl, err := texttemplate.New("report").Funcs(texttemplate.FuncMap{"cell": markdownCell}).Parse(markdownReportTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to parse the markdown report template: %v", err)
+		}
+		return tmpl.Execute(w, data)
+	default:
+		return fmt.Errorf("invalid report format %q - available formats: %v", format, ReportFormats)
+	}
+}
+
+// CreateReportFile renders the report of a claim file in the given format and saves it in outputFile.
+func CreateReportFile(claimFilePath, outputFile, format string) error {
+	claimContent, err := os.ReadFile(claimFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read claim file %s: %v", claimFilePath, err)
+	}
+
+	root := claim.Root{}
+	if err := json.Unmarshal(claimContent, &root); err != nil {
+		return fmt.Errorf("failed to unmarshal claim file %s: %v", claimFilePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderReport(&buf, &root, format); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputFile, buf.Bytes(), writeFilePerms); err != nil {
+		return fmt.Errorf("failed to write file %s: %v", outputFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package results
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestClaimRoot() *claim.Root {
+	return &claim.Root{Claim: &claim.Claim{
+		Versions: &claim.Versions{Tnf: "v5.0.0", Ocp: "4.14.1"},
+		Metadata: &claim.Metadata{StartTime: "start", EndTime: "end"},
+		Configurations: map[string]interface{}{
+			"Config": map[string]interface{}{
+				"targetNameSpaces": []interface{}{map[string]interface{}{"name": "ns1"}},
+			},
+		},
+		Nodes: map[string]interface{}{
+			"nodeSummary": map[string]interface{}{
+				"node1": map[string]interface{}{
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{
+						"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/master": "", "kubernetes.io/os": "linux"}},
+					"status": map[string]interface{}{"nodeInfo": map[string]interface{}{"osImage": "RHCOS", "kernelVersion": "5.14"}},
+				},
+			},
+		},
+		Results: map[string]claim.Result{
+			"suite1-test-passed": {TestID: &claim.Identifier{Id: "suite1-test-passed", Suite: "suite1"}, State: "passed"},
+			"suite1-test-failed": {
+				TestID:      &claim.Identifier{Id: "suite1-test-failed", Suite: "suite1"},
+				State:       "failed",
+				CatalogInfo: &claim.CatalogInfo{Description: "Test description", Remediation: "Do this | and that", BestPracticeReference: "https://docs/test"},
+				CheckDetails: `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":[{"ObjectType":"Container",` +
+					`"ObjectFieldsKeys":["Reason For Non Compliance","Namespace","Pod Name","Container Name"],"ObjectFieldsValues":["Bad container","ns1","pod1","c1"]}]}`,
+			},
+			"suite2-test-skipped": {TestID: &claim.Identifier{Id: "suite2-test-skipped", Suite: "suite2"}, State: "skipped", SkipReason: "no pods <found>"},
+		},
+	}}
+}
+
+func TestNewReportData(t *testing.T) {
+	data, err := NewReportData(getTestClaimRoot())
+	assert.Nil(t, err)
+
+	assert.Equal(t, ReportSummary{Total: 3, Passed: 1, Failed: 1, Skipped: 1}, data.Summary)
+	assert.Equal(t, []string{"ns1"}, data.TargetNamespaces)
+
+	assert.Len(t, data.Suites, 2)
+	assert.Equal(t, "suite1", data.Suites[0].Name)
+	assert.Equal(t, 2, data.Suites[0].Total)
+	assert.Equal(t, "suite2", data.Suites[1].Name)
+
+	assert.Len(t, data.FailedTestCases, 1)
+	assert.Equal(t, []ReportObject{{Type: "Container", Reason: "Bad container", Fields: []ReportField{
+		{Key: "Namespace", Value: "ns1"}, {Key: "Pod Name", Value: "pod1"}, {Key: "Container Name", Value: "c1"}}}},
+		data.FailedTestCases[0].NonCompliantObjects)
+
+	assert.Equal(t, []ReportNode{{Name: "node1", Roles: "master,worker", OSImage: "RHCOS", KernelVersion: "5.14"}}, data.Nodes)
+
+	_, err = NewReportData(&claim.Root{})
+	assert.NotNil(t, err)
+}
+
+func TestRenderReport(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, RenderReport(&buf, getTestClaimRoot(), ReportFormatMarkdown))
+	md := buf.String()
+	assert.Contains(t, md, "| 3 | 1 | 1 | 1 | 0 |")
+	assert.Contains(t, md, "### suite1-test-failed")
+	assert.Contains(t, md, "| Container | Bad container | Namespace: ns1, Pod Name: pod1, Container Name: c1 |")
+	assert.Contains(t, md, "| suite2-test-skipped | skipped | no pods <found> |")
+	assert.Contains(t, md, "| node1 | master,worker | RHCOS | 5.14 |")
+
+	buf.Reset()
+	assert.Nil(t, RenderReport(&buf, getTestClaimRoot(), ReportFormatHTML))
+	html := buf.String()
+	assert.Contains(t, html, `<h3 id="suite1-test-failed">suite1-test-failed</h3>`)
+	assert.Contains(t, html, `<a href="https://docs/test">https://docs/test</a>`)
+	assert.Contains(t, html, "no pods &lt;found&gt;")
+
+	assert.NotNil(t, RenderReport(&buf, getTestClaimRoot(), "pdf"))
+}
+
+func TestMarkdownCell(t *testing.T) {
+	assert.Equal(t, `a \| b<br>c`, markdownCell(" a | b\nc "))
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package tracing exports OpenTelemetry traces of the run, either to an OTLP collector or to a local
+// JSON file. Spans of the run's steps (discovery, checks groups and checks) are nested: the last started
+// step span is the parent of the next spans. Spans of the calls to the cluster are leaves, so they can be
+// started concurrently.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// FileName is the name of the traces file saved in the output folder when no OTLP endpoint is set.
+	FileName = "certsuite-traces.json"
+
+	tracerName        = "github.com/redhat-best-practices-for-k8s/certsuite"
+	serviceName       = "certsuite"
+	shutdownTimeout   = 30 * time.Second
+	tracesFilePerms   = 0o644
+	httpSpanKeyMethod = "http.request.method"
+	httpSpanKeyURL    = "url.path"
+	httpSpanKeyStatus = "http.response.status_code"
+)
+
+var (
+	tracer   trace.Tracer = noop.NewTracerProvider().Tracer(tracerName)
+	provider *sdktrace.TracerProvider
+	// Context of the current step span, parent of the new spans.
+	current = context.Background()
+	lock    sync.Mutex
+)
+
+// Span is a traced operation.
+type Span struct {
+	span   trace.Span
+	parent context.Context
+	isStep bool
+}
+
+// Start enables the tracing. The spans are exported with OTLP over gRPC to the endpoint, an URL like
+// http://localhost:4317, or to outputFile in JSON format when the endpoint is empty.
+func Start(endpoint, outputFile string) error {
+	var exporter sdktrace.SpanExporter
+	if endpoint != "" {
+		var err error
+		exporter, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpointURL(endpoint))
+		if err != nil {
+			return fmt.Errorf("failed to create the OTLP trace exporter for %s: %v", endpoint, err)
+		}
+	} else {
+		exporter = &fileExporter{fileName: outputFile}
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", versions.GitDisplayRelease),
+	)
+
+	lock.Lock()
+	defer lock.Unlock()
+	provider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	tracer = provider.Tracer(tracerName)
+	current = context.Background()
+	return nil
+}
+
+// Shutdown flushes the pending spans and disables the tracing.
+func Shutdown() error {
+	lock.Lock()
+	defer lock.Unlock()
+	if provider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := provider.Shutdown(ctx)
+
+	provider = nil
+	tracer = noop.NewTracerProvider().Tracer(tracerName)
+	current = context.Background()
+	return err
+}
+
+// StartSpan starts the span of a step of the run, which becomes the parent of the next spans until it ends.
+func StartSpan(name string, attrs ...attribute.KeyValue) *Span {
+	lock.Lock()
+	defer lock.Unlock()
+	ctx, span := tracer.Start(current, name, trace.WithAttributes(attrs...))
+	s := &Span{span: span, parent: current, isStep: true}
+	current = ctx
+	return s
+}
+
+// StartLeafSpan starts the span of a call to the cluster, child of the current step span.
+func StartLeafSpan(name string, attrs ...attribute.KeyValue) *Span {
+	lock.Lock()
+	defer lock.Unlock()
+	_, span := tracer.Start(current, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindClient))
+	return &Span{span: span}
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...attribute.KeyValue) {
+	s.span.SetAttributes(attrs...)
+}
+
+// SetError records the error, if any, in the span and sets its status as error.
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End ends the span. The parent of a step span becomes the current step span again.
+func (s *Span) End() {
+	s.span.End()
+	if !s.isStep {
+		return
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	current = s.parent
+}
+
+type roundTripper struct {
+	rt http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := StartLeafSpan("HTTP "+req.Method,
+		attribute.String(httpSpanKeyMethod, req.Method),
+		attribute.String(httpSpanKeyURL, req.URL.Path))
+	defer span.End()
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int(httpSpanKeyStatus, resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// WrapTransport adds a span for each API request. It's meant to be used with rest.Config's Wrap.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{rt: rt}
+}
+
+// fileExporter saves the spans in a JSON file when it's shut down.
+type fileExporter struct {
+	fileName string
+	spans    tracetest.SpanStubs
+	lock     sync.Mutex
+}
+
+func (e *fileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, tracetest.SpanStubsFromReadOnlySpans(spans)...)
+	return nil
+}
+
+func (e *fileExporter) Shutdown(_ context.Context) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	content, err := json.MarshalIndent(e.spans, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the spans: %v", err)
+	}
+
+	if err := os.WriteFile(e.fileName, content, tracesFilePerms); err != nil {
+		return fmt.Errorf("failed to write the traces file %s: %v", e.fileName, err)
+	}
+	return nil
+}
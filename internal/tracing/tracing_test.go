@@ -0,0 +1,92 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSpan struct {
+	Name        string
+	SpanContext struct {
+		SpanID string
+	}
+	Parent struct {
+		SpanID string
+	}
+	Status struct {
+		Code string
+	}
+}
+
+func TestSpansToFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), FileName)
+	assert.Nil(t, Start("", outputFile))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := &http.Client{Transport: WrapTransport(http.DefaultTransport)}
+
+	groupSpan := StartSpan("group")
+	checkSpan := StartSpan("check")
+	execSpan := StartLeafSpan("exec")
+	execSpan.SetError(errors.New("command failed"))
+	execSpan.End()
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	checkSpan.End()
+	otherCheckSpan := StartSpan("other check")
+	otherCheckSpan.End()
+	groupSpan.End()
+
+	assert.Nil(t, Shutdown())
+
+	content, err := os.ReadFile(outputFile)
+	assert.Nil(t, err)
+	spans := []testSpan{}
+	assert.Nil(t, json.Unmarshal(content, &spans))
+	assert.Len(t, spans, 5)
+
+	spansByName := map[string]testSpan{}
+	for _, span := range spans {
+		spansByName[span.Name] = span
+	}
+	groupID := spansByName["group"].SpanContext.SpanID
+	checkID := spansByName["check"].SpanContext.SpanID
+	assert.Equal(t, groupID, spansByName["check"].Parent.SpanID)
+	assert.Equal(t, groupID, spansByName["other check"].Parent.SpanID)
+	assert.Equal(t, checkID, spansByName["exec"].Parent.SpanID)
+	assert.Equal(t, checkID, spansByName["HTTP GET"].Parent.SpanID)
+	assert.Equal(t, "Error", spansByName["exec"].Status.Code)
+}
+
+func TestSpansDisabled(t *testing.T) {
+	// Spans can be used, and do nothing, when the tracing is not enabled.
+	span := StartSpan("check")
+	span.SetError(errors.New("error"))
+	span.End()
+	assert.Nil(t, Shutdown())
+}
@@ -29,6 +29,7 @@ import (
 	olmv1Alpha "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/tracing"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/compatibility"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/podhelper"
@@ -126,13 +127,26 @@ func createLabels(labelStrings []string) (labelObjects []labelObject) {
 	return labelObjects
 }
 
+// FindDebugPods returns the running pods of the debug DaemonSet.
+func FindDebugPods(config *configuration.TestConfiguration) []corev1.Pod {
+	oc := clientsholder.GetClientsHolder()
+	debugLabels := []labelObject{{LabelKey: debugHelperPodsLabelName, LabelValue: debugHelperPodsLabelValue}}
+	debugNS := []string{config.DebugDaemonSetNamespace}
+	debugPods, _ := findPodsByLabels(oc.K8sClient.CoreV1(), debugLabels, debugNS)
+	return debugPods
+}
+
 // DoAutoDiscover finds objects under test
 //
 //nolint:funlen
 func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData {
 	oc := clientsholder.GetClientsHolder()
 
+	discoverySpan := tracing.StartSpan("DoAutoDiscover")
+	defer discoverySpan.End()
+
 	var err error
+	span := tracing.StartSpan("DoAutoDiscover cluster")
 	data.StorageClasses, err = getAllStorageClasses(oc.K8sClient.StorageV1())
 	if err != nil {
 		log.Fatal("Failed to retrieve storageClasses - err: %v", err)
@@ -148,6 +162,9 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	if err != nil {
 		log.Fatal("Cannot get namespaces, err: %v", err)
 	}
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover operators")
 	data.AllSubscriptions = findSubscriptions(oc.OlmClient, []string{""})
 	data.AllCsvs, err = getAllOperators(oc.OlmClient)
 	if err != nil {
@@ -155,12 +172,19 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	}
 	data.AllInstallPlans = getAllInstallPlans(oc.OlmClient)
 	data.AllCatalogSources = getAllCatalogSources(oc.OlmClient)
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover pods")
 	data.Namespaces = namespacesListToStringList(config.TargetNameSpaces)
 	data.Pods, data.AllPods = findPodsByLabels(oc.K8sClient.CoreV1(), podsUnderTestLabelsObjects, data.Namespaces)
 	data.AbnormalEvents = findAbnormalEvents(oc.K8sClient.CoreV1(), data.Namespaces)
-	debugLabels := []labelObject{{LabelKey: debugHelperPodsLabelName, LabelValue: debugHelperPodsLabelValue}}
-	debugNS := []string{config.DebugDaemonSetNamespace}
-	data.DebugPods, _ = findPodsByLabels(oc.K8sClient.CoreV1(), debugLabels, debugNS)
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover debug pods")
+	data.DebugPods = FindDebugPods(config)
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover policies")
 	data.ResourceQuotaItems, err = getResourceQuotas(oc.K8sClient.CoreV1())
 	if err != nil {
 		log.Fatal("Cannot get resource quotas, err: %v", err)
@@ -173,8 +197,10 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	if err != nil {
 		log.Fatal("Cannot get network policies, err: %v", err)
 	}
+	span.End()
 
 	// Get cluster crds
+	span = tracing.StartSpan("DoAutoDiscover crds")
 	data.AllCrds, err = getClusterCrdNames()
 	if err != nil {
 		log.Fatal("Cannot get cluster CRD names, err: %v", err)
@@ -182,6 +208,9 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	data.Crds = FindTestCrdNames(data.AllCrds, config.CrdFilters)
 
 	data.ScaleCrUnderTest = GetScaleCrUnderTest(data.Namespaces, data.Crds)
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover operators under test")
 	data.Csvs = findOperatorsByLabels(oc.OlmClient, operatorsUnderTestLabelsObjects, config.TargetNameSpaces)
 	data.Subscriptions = findSubscriptions(oc.OlmClient, data.Namespaces)
 	data.HelmChartReleases = getHelmList(oc.RestConfig, data.Namespaces)
@@ -191,7 +220,9 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	if err != nil {
 		log.Fatal("Failed to get the operator pods, err: %v", err)
 	}
+	span.End()
 
+	span = tracing.StartSpan("DoAutoDiscover versions")
 	openshiftVersion, err := getOpenshiftVersion(oc.OcpClient)
 	if err != nil {
 		log.Fatal("Failed to get the OpenShift version, err: %v", err)
@@ -209,13 +240,19 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	data.OCPStatus = compatibility.DetermineOCPStatus(openshiftVersion, time.Now())
 
 	data.K8sVersion = k8sVersion.GitVersion
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover workloads")
 	data.Deployments = findDeploymentsByLabels(oc.K8sClient.AppsV1(), podsUnderTestLabelsObjects, data.Namespaces)
 	data.StatefulSet = findStatefulSetsByLabels(oc.K8sClient.AppsV1(), podsUnderTestLabelsObjects, data.Namespaces)
 
 	// Check if the Istio Service Mesh is present
 	data.IstioServiceMeshFound = isIstioServiceMeshInstalled(oc.K8sClient.AppsV1(), data.AllNamespaces)
+	data.Hpas = findHpaControllers(oc.K8sClient, data.Namespaces)
+	span.End()
 
 	// Find ClusterRoleBindings
+	span = tracing.StartSpan("DoAutoDiscover rbac")
 	clusterRoleBindings, err := getClusterRoleBindings(oc.K8sClient.RbacV1())
 	if err != nil {
 		log.Fatal("Cannot get cluster role bindings, err: %v", err)
@@ -233,7 +270,9 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 		log.Fatal("Cannot get roles, err: %v", err)
 	}
 	data.Roles = roles
-	data.Hpas = findHpaControllers(oc.K8sClient, data.Namespaces)
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover nodes and storage")
 	data.Nodes, err = oc.K8sClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		log.Fatal("Cannot get list of nodes, err: %v", err)
@@ -246,10 +285,14 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 	if err != nil {
 		log.Fatal("Cannot get list of persistent volume claims, err: %v", err)
 	}
+	span.End()
+
+	span = tracing.StartSpan("DoAutoDiscover services")
 	data.Services, err = getServices(oc.K8sClient.CoreV1(), data.Namespaces, data.ServicesIgnoreList)
 	if err != nil {
 		log.Fatal("Cannot get list of services, err: %v", err)
 	}
+	span.End()
 
 	data.ExecutedBy = config.ExecutedBy
 	data.PartnerName = config.PartnerName
@@ -29,6 +29,7 @@ import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // getClusterCrdNames returns a list of crd names found in the cluster.
@@ -62,3 +63,34 @@ func FindTestCrdNames(clusterCrds []*apiextv1.CustomResourceDefinition, crdFilte
 	}
 	return targetCrds
 }
+
+// GetScalableCrdResources returns the group resources of the CRDs under test whose CRs can be scaled, so the
+// permissions needed to scale them can be reviewed before the autodiscovery.
+func GetScalableCrdResources(crdFilters []configuration.CrdFilter) ([]schema.GroupResource, error) {
+	if len(crdFilters) == 0 {
+		return nil, nil
+	}
+
+	clusterCrds, err := getClusterCrdNames()
+	if err != nil {
+		return nil, err
+	}
+	return getScalableGroupResources(FindTestCrdNames(clusterCrds, crdFilters)), nil
+}
+
+// getScalableGroupResources returns the group resources of the namespaced CRDs with a scale subresource.
+func getScalableGroupResources(crds []*apiextv1.CustomResourceDefinition) []schema.GroupResource {
+	groupResources := []schema.GroupResource{}
+	for _, crd := range crds {
+		if crd.Spec.Scope != apiextv1.NamespaceScoped {
+			continue
+		}
+		for i := range crd.Spec.Versions {
+			if crd.Spec.Versions[i].Subresources != nil && crd.Spec.Versions[i].Subresources.Scale != nil {
+				groupResources = append(groupResources, schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural})
+				break
+			}
+		}
+	}
+	return groupResources
+}
@@ -25,6 +25,7 @@ import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestFindTestCrdNames(t *testing.T) {
@@ -130,3 +131,25 @@ func TestGetClusterCrdNames(t *testing.T) {
 		assert.Equal(t, tc.expectedTargetCRDs, crdNames)
 	}
 }
+
+func TestGetScalableGroupResources(t *testing.T) {
+	newCrd := func(plural string, scope apiextv1.ResourceScope, scalable bool) *apiextv1.CustomResourceDefinition {
+		crd := &apiextv1.CustomResourceDefinition{Spec: apiextv1.CustomResourceDefinitionSpec{
+			Group:    "example.com",
+			Names:    apiextv1.CustomResourceDefinitionNames{Plural: plural},
+			Scope:    scope,
+			Versions: []apiextv1.CustomResourceDefinitionVersion{{Name: "v1alpha1"}, {Name: "v1"}},
+		}}
+		if scalable {
+			crd.Spec.Versions[1].Subresources = &apiextv1.CustomResourceSubresources{Scale: &apiextv1.CustomResourceSubresourceScale{}}
+		}
+		return crd
+	}
+
+	crds := []*apiextv1.CustomResourceDefinition{
+		newCrd("memcacheds", apiextv1.NamespaceScoped, true),
+		newCrd("configs", apiextv1.NamespaceScoped, false),
+		newCrd("clusterscalers", apiextv1.ClusterScoped, true),
+	}
+	assert.Equal(t, []schema.GroupResource{{Group: "example.com", Resource: "memcacheds"}}, getScalableGroupResources(crds))
+}
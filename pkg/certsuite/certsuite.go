@@ -7,15 +7,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/metrics"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/redaction"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/results"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/tracing"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
-	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/collector"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/sinks"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/accesscontrol"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/certification"
@@ -27,6 +32,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/performance"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/platform"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/preflight"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func LoadInternalChecksDB() {
@@ -51,6 +57,7 @@ func LoadChecksDB(labelsExpr string) {
 
 const (
 	junitXMLOutputFileName = "cnf-certification-tests_junit.xml"
+	sarifOutputFileName    = "certsuite-results.sarif"
 	claimFileName          = "claim.json"
 	collectorAppURL        = "http://claims-collector.cnf-certifications.sysdeseng.com"
 	timeoutDefaultvalue    = 24 * time.Hour
@@ -135,6 +142,36 @@ func Run(labelsFilter, outputFolder string) error {
 	fmt.Println("Running discovery of CNF target resources...")
 	fmt.Print("\n")
 
+	// Trace the discovery and the checks, to find out what makes a run slow.
+	tracesOutputFile := ""
+	if testParams.EnableTracing {
+		if testParams.OTLPEndpoint == "" {
+			tracesOutputFile = filepath.Join(outputFolder, tracing.FileName)
+		}
+		if err := tracing.Start(testParams.OTLPEndpoint, tracesOutputFile); err != nil {
+			log.Error("Failed to start the tracing: %v", err)
+			tracesOutputFile = ""
+		}
+	}
+	runSpan := tracing.StartSpan("certsuite run", attribute.String("certsuite.labels", labelsFilter))
+
+	// Start a new audit trail for the commands run during this execution.
+	audit.Reset()
+
+	// Keep track of the changes made in the cluster, so they can be reverted with "certsuite cleanup"
+	// in case this execution crashes or gets interrupted.
+	journalOutputFile := filepath.Join(outputFolder, journal.FileName)
+	pendingSideEffects, journalErr := journal.Open(journalOutputFile)
+	if journalErr != nil {
+		log.Error("Failed to open the side effects journal file: %v", journalErr)
+	} else if leftovers := countLeftoverSideEffects(pendingSideEffects); leftovers > 0 {
+		log.Warn("%d side effect(s) of a previous execution were not reverted. Run \"certsuite cleanup --journal %s\" to revert them.",
+			leftovers, journalOutputFile)
+	}
+
+	// Verify the suite's identity has the permissions needed by the checks before running them.
+	verifyChecksPermissions(testParams.ConfigFile, testParams.SkipChecksMissingPermissions)
+
 	env := provider.GetTestEnvironment()
 
 	claimBuilder, err := claimhelper.NewClaimBuilder()
@@ -153,6 +190,16 @@ func Run(labelsFilter, outputFolder string) error {
 	endTime := time.Now()
 	log.Info("Finished running checks in %v", endTime.Sub(startTime))
 
+	runSpan.End()
+	if err := tracing.Shutdown(); err != nil {
+		log.Error("Failed to export the traces: %v", err)
+		tracesOutputFile = ""
+	}
+
+	if err := journal.Close(); err != nil {
+		log.Error("Failed to close the side effects journal file: %v", err)
+	}
+
 	if failedCtr > 0 {
 		log.Warn("Some checks failed. See %s for details", claimOutputFile)
 	}
@@ -160,11 +207,54 @@ func Run(labelsFilter, outputFolder string) error {
 	// Marshal the claim and output to file
 	claimBuilder.Build(claimOutputFile)
 
+	// Results files, other than the claim, to be redacted in place if required.
+	redactedOutputFiles := []string{}
+	if tracesOutputFile != "" {
+		redactedOutputFiles = append(redactedOutputFiles, tracesOutputFile)
+	}
+
+	// Save the audit trail of the commands run in the cluster's containers
+	auditOutputFile := filepath.Join(outputFolder, audit.FileName)
+	auditErr := audit.WriteTrailFile(auditOutputFile)
+	if auditErr != nil {
+		log.Error("Failed to write the commands audit trail file: %v", auditErr)
+	} else {
+		log.Info("Commands audit trail file created at %s", auditOutputFile)
+		redactedOutputFiles = append(redactedOutputFiles, auditOutputFile)
+	}
+
 	// Create JUnit file if required
 	if configuration.GetTestParameters().EnableXMLCreation {
-		junitOutputFileName := filepath.Join(outputFolder, junitXMLOutputFileName)
-		log.Info("JUnit XML file creation is enabled. Creating JUnit XML file: %s", junitOutputFileName)
-		claimBuilder.ToJUnitXML(junitOutputFileName, startTime, endTime)
+		if configuration.GetTestParameters().EnableJUnitFilePerSuite {
+			junitOutputFilePrefix := filepath.Join(outputFolder, strings.TrimSuffix(junitXMLOutputFileName, ".xml"))
+			log.Info("JUnit XML file creation is enabled. Creating a JUnit XML file per test suite: %s_<suite>.xml", junitOutputFilePrefix)
+			junitOutputFiles := claimBuilder.ToJUnitXMLPerSuite(junitOutputFilePrefix, startTime, endTime)
+			redactedOutputFiles = append(redactedOutputFiles, junitOutputFiles...)
+		} else {
+			junitOutputFileName := filepath.Join(outputFolder, junitXMLOutputFileName)
+			log.Info("JUnit XML file creation is enabled. Creating JUnit XML file: %s", junitOutputFileName)
+			claimBuilder.ToJUnitXML(junitOutputFileName, startTime, endTime)
+			redactedOutputFiles = append(redactedOutputFiles, junitOutputFileName)
+		}
+	}
+
+	// Update the metrics served in server mode and create the metrics file if required
+	metrics.Update(checksdb.GetReconciledResults(), startTime, endTime)
+	if configuration.GetTestParameters().EnableMetricsFile {
+		metricsOutputFile := filepath.Join(outputFolder, metrics.TextfileName)
+		if err := metrics.WriteTextfile(metricsOutputFile); err != nil {
+			log.Error("Failed to write the metrics file: %v", err)
+		} else {
+			log.Info("Metrics file created at %s", metricsOutputFile)
+		}
+	}
+
+	// Create SARIF file if required
+	if configuration.GetTestParameters().EnableSARIFCreation {
+		sarifOutputFile := filepath.Join(outputFolder, sarifOutputFileName)
+		log.Info("SARIF file creation is enabled. Creating SARIF file: %s", sarifOutputFile)
+		claimBuilder.ToSARIF(sarifOutputFile)
+		redactedOutputFiles = append(redactedOutputFiles, sarifOutputFile)
 	}
 
 	if configuration.GetTestParameters().SanitizeClaim {
@@ -174,15 +264,41 @@ func Run(labelsFilter, outputFolder string) error {
 		}
 	}
 
-	// Send claim file to the collector if specified by env var
-	if configuration.GetTestParameters().EnableDataCollection {
-		if env.CollectorAppEndpoint == "" {
-			env.CollectorAppEndpoint = collectorAppURL
+	// Redact the sensitive data of the claim and the results files if required by the configuration, before
+	// they're used to create the rest of the artifacts or sent to the collector.
+	var redactor *redaction.Redactor
+	redactionMappingFile := ""
+	if policy := env.Config.Redaction; policy != nil {
+		redactionMappingFile = policy.MappingFile
+		if redactionMappingFile == "" {
+			redactionMappingFile = filepath.Join(outputFolder, redaction.MappingFileName)
 		}
 
-		err = collector.SendClaimFileToCollector(env.CollectorAppEndpoint, claimOutputFile, env.ExecutedBy, env.PartnerName, env.CollectorAppPassword)
+		redactor, err = redaction.New(policy, redactionMappingFile)
 		if err != nil {
-			log.Error("Failed to send post request to the collector: %v", err)
+			log.Fatal("Failed to create the redactor: %v", err)
+		}
+
+		if err := redactor.RedactClaimFile(claimOutputFile); err != nil {
+			log.Fatal("Failed to redact the claim file: %v", err)
+		}
+		for _, file := range redactedOutputFiles {
+			if err := redactor.RedactFile(file, file); err != nil {
+				log.Fatal("Failed to redact the results file: %v", err)
+			}
+		}
+		log.Info("Claim and results files redacted")
+	}
+
+	// Create the standalone report file if required
+	reportOutputFile := ""
+	if reportFormat := configuration.GetTestParameters().ReportFormat; reportFormat != "" {
+		reportOutputFile = filepath.Join(outputFolder, results.ReportFileName+"."+reportFormat)
+		if err := results.CreateReportFile(claimOutputFile, reportOutputFile, reportFormat); err != nil {
+			log.Error("Failed to create the report file: %v", err)
+			reportOutputFile = ""
+		} else {
+			log.Info("Report file created at %s", reportOutputFile)
 		}
 	}
 
@@ -195,20 +311,105 @@ func Run(labelsFilter, outputFolder string) error {
 
 	allArtifactsFilePaths := []string{filepath.Join(outputFolder, claimFileName)}
 
+	// Add the audit trail file path.
+	if auditErr == nil {
+		allArtifactsFilePaths = append(allArtifactsFilePaths, auditOutputFile)
+	}
+
+	// Add the side effects journal file path.
+	if journalErr == nil {
+		allArtifactsFilePaths = append(allArtifactsFilePaths, journalOutputFile)
+	}
+
+	// Add the traces file path.
+	if tracesOutputFile != "" {
+		allArtifactsFilePaths = append(allArtifactsFilePaths, tracesOutputFile)
+	}
+
+	// Add the report file path.
+	if reportOutputFile != "" {
+		allArtifactsFilePaths = append(allArtifactsFilePaths, reportOutputFile)
+	}
+
 	// Add all the web artifacts file paths.
 	allArtifactsFilePaths = append(allArtifactsFilePaths, webFilePaths...)
 
 	// Add the log file path
 	allArtifactsFilePaths = append(allArtifactsFilePaths, filepath.Join(outputFolder, log.LogFileName))
 
+	// The log file is still in use and the side effects journal is needed by "certsuite cleanup", so only their
+	// copies in the artifacts file are redacted.
+	if redactor != nil {
+		redactedCopiesDir, err := os.MkdirTemp("", "certsuite-redacted-")
+		if err != nil {
+			log.Fatal("Failed to create the redacted files folder: %v", err)
+		}
+		defer os.RemoveAll(redactedCopiesDir)
+
+		for i, file := range allArtifactsFilePaths {
+			if file != journalOutputFile && file != filepath.Join(outputFolder, log.LogFileName) {
+				continue
+			}
+			redactedCopy := filepath.Join(redactedCopiesDir, filepath.Base(file))
+			if err := redactor.RedactFile(file, redactedCopy); err != nil {
+				log.Fatal("Failed to redact the results file: %v", err)
+			}
+			allArtifactsFilePaths[i] = redactedCopy
+		}
+
+		if err := redactor.SaveMapping(redactionMappingFile); err != nil {
+			log.Error("Failed to save the redaction mapping file: %v", err)
+		} else {
+			log.Warn("Redaction mapping file created at %s. Keep it locally, it holds the redacted data.", redactionMappingFile)
+		}
+	}
+
 	// tar.gz file creation with results and html artifacts, unless omitted by env var.
+	filesToSign := []string{filepath.Join(outputFolder, claimFileName)}
+	zipFilePath := ""
 	if !configuration.GetTestParameters().OmitArtifactsZipFile {
-		err = results.CompressResultsArtifacts(resultsOutputDir, allArtifactsFilePaths)
+		zipFilePath, err = results.CompressResultsArtifacts(resultsOutputDir, allArtifactsFilePaths)
 		if err != nil {
 			log.Fatal("Failed to compress results artifacts: %v", err)
 		}
+		filesToSign = append(filesToSign, zipFilePath)
 	}
 
+	// Sign the claim and the results artifacts file if required, so they can't be modified afterwards unnoticed.
+	if signKeyFile := configuration.GetTestParameters().SignKeyFile; signKeyFile != "" {
+		manifestOutputFile := filepath.Join(outputFolder, claimhelper.ManifestFileName)
+		err = claimhelper.SignFiles(signKeyFile, manifestOutputFile, filesToSign)
+		if err != nil {
+			return fmt.Errorf("failed to sign the results: %v", err)
+		}
+		log.Info("Results signed. Manifest file created at %s", manifestOutputFile)
+	}
+
+	// Send the claim and the results artifacts file to the result sinks of the configuration and to the collector
+	// if specified by env var, reporting which of them failed.
+	extraSinks := []sinks.Sink{}
+	if configuration.GetTestParameters().EnableDataCollection {
+		if env.CollectorAppEndpoint == "" {
+			env.CollectorAppEndpoint = collectorAppURL
+		}
+		extraSinks = append(extraSinks, sinks.NewCollectorSink(env.CollectorAppEndpoint, env.CollectorAppPassword))
+	}
+	sinkReports := sinks.SendAll(env.Config.ResultSinks, extraSinks, &sinks.Results{
+		ClaimFile:     claimOutputFile,
+		ArtifactsFile: zipFilePath,
+		ExecutedBy:    env.ExecutedBy,
+		PartnerName:   env.PartnerName,
+		Time:          startTime,
+	})
+	for _, report := range sinkReports {
+		if report.Err != nil {
+			log.Error("Failed to send the results to the %s sink: %v", report.Sink, report.Err)
+		} else {
+			log.Info("Results sent to the %s sink", report.Sink)
+		}
+	}
+	sinks.PrintReports(os.Stdout, sinkReports)
+
 	// Remove web artifacts if user does not want them.
 	if !configuration.GetTestParameters().IncludeWebFilesInOutputFolder {
 		for _, file := range webFilePaths {
@@ -221,3 +422,15 @@ func Run(labelsFilter, outputFolder string) error {
 
 	return nil
 }
+
+// countLeftoverSideEffects returns the number of pending side effects that a previous run should have
+// reverted, leaving out the ones kept on purpose like the debug DaemonSet.
+func countLeftoverSideEffects(pending []journal.SideEffect) int {
+	leftovers := 0
+	for i := range pending {
+		if !pending[i].IsLeftOnPurpose() {
+			leftovers++
+		}
+	}
+	return leftovers
+}
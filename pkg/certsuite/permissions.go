@@ -0,0 +1,91 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package certsuite
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/autodiscover"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+// GetChecksMissingPermissions reviews the API permissions required by the checks matching the labels filter
+// in the namespaces set in the config file. Returns the missing permissions by check ID.
+func GetChecksMissingPermissions(configFile string) (map[string][]permissions.Permission, error) {
+	config, err := configuration.LoadConfiguration(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the config file %s: %v", configFile, err)
+	}
+
+	targetNamespaces := []string{}
+	for _, ns := range config.TargetNameSpaces {
+		targetNamespaces = append(targetNamespaces, ns.Name)
+	}
+
+	scalableResources, err := autodiscover.GetScalableCrdResources(config.CrdFilters)
+	if err != nil {
+		log.Warn("Failed to get the scalable CRDs under test, their scaling permissions are not reviewed: %v", err)
+	}
+
+	checker := permissions.NewChecker(clientsholder.GetClientsHolder().K8sClient)
+	return checksdb.GetMissingPermissions(checker, targetNamespaces, config.DebugDaemonSetNamespace, scalableResources)
+}
+
+// GetSortedCheckIDs returns the IDs of the checks with missing permissions, sorted.
+func GetSortedCheckIDs(missingByCheck map[string][]permissions.Permission) []string {
+	checkIDs := make([]string, 0, len(missingByCheck))
+	for checkID := range missingByCheck {
+		checkIDs = append(checkIDs, checkID)
+	}
+	sort.Strings(checkIDs)
+	return checkIDs
+}
+
+// verifyChecksPermissions reports up front the checks that can't be run because of missing permissions.
+// Those checks will be skipped if skipChecks is true, otherwise their result will be error.
+func verifyChecksPermissions(configFile string, skipChecks bool) {
+	missingByCheck, err := GetChecksMissingPermissions(configFile)
+	if err != nil {
+		log.Error("Failed to verify the permissions required by the checks: %v", err)
+		return
+	}
+
+	if len(missingByCheck) == 0 {
+		log.Info("All the permissions required by the checks are granted.")
+		return
+	}
+
+	action := "will be set as error"
+	if skipChecks {
+		action = "will be skipped"
+	}
+
+	fmt.Printf("Missing permissions for %d check(s), they %s:\n", len(missingByCheck), action)
+	for _, checkID := range GetSortedCheckIDs(missingByCheck) {
+		missing := permissions.ToString(missingByCheck[checkID])
+		log.Warn("Check %s %s, missing permissions: %s", checkID, action, missing)
+		fmt.Printf("  - %s: %s\n", checkID, missing)
+	}
+	fmt.Print("\n")
+
+	checksdb.SetMissingPermissions(missingByCheck, skipChecks)
+}
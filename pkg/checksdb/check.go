@@ -1,14 +1,17 @@
 package checksdb
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 )
 
@@ -44,10 +47,16 @@ type Check struct {
 	SkipCheckFns []func() (skip bool, reason string)
 	SkipMode     skipMode
 
+	// API permissions the check needs, verified before running the checks.
+	RequiredPermissions      []permissions.Permission
+	missingPermissions       []permissions.Permission
+	skipOnMissingPermissions bool
+
 	Result         CheckResult
 	CapturedOutput string
 	details        string
 	skipReason     string
+	auditRecordIDs []int
 
 	logger     *log.Logger
 	logArchive *strings.Builder
@@ -152,6 +161,18 @@ func (check *Check) WithSkipCheckFn(skipCheckFn ...func() (skip bool, reason str
 	return check
 }
 
+// WithRequiredPermissions declares the API permissions the check needs. They're verified with
+// SelfSubjectAccessReviews before running the checks.
+func (check *Check) WithRequiredPermissions(perms ...permissions.Permission) *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.RequiredPermissions = append(check.RequiredPermissions, perms...)
+
+	return check
+}
+
 // This modifier is provided for the sake of completeness, but it's not necessary to use it,
 // as the SkipModeAny is the default skip mode.
 func (check *Check) WithSkipModeAny() *Check {
@@ -212,7 +233,45 @@ func (check *Check) SetResult(compliantObjects, nonCompliantObjects []*testhelpe
 		check.LogWarn("Check %s marked as skipped as both compliant and non-compliant objects lists are empty.", check.ID)
 		check.skipReason = "compliant and non-compliant objects lists are empty"
 		check.Result = CheckResultSkipped
+	} else if allNotInspected(compliantObjects) {
+		// Do not pass a check that did not inspect any object, e.g. no node had a debug pod.
+		check.LogWarn("Check %s marked as skipped as none of the objects was inspected.", check.ID)
+		check.skipReason = "none of the objects was inspected"
+		check.Result = CheckResultSkipped
+	}
+}
+
+// getDetails returns the check details with the reference to the records of the commands run by the check in the
+// audit trail file.
+func (check *Check) getDetails() string {
+	if len(check.auditRecordIDs) == 0 {
+		return check.details
+	}
+
+	details := testhelper.FailureReasonOut{}
+	if check.details != "" {
+		if err := json.Unmarshal([]byte(check.details), &details); err != nil {
+			check.LogError("Failed to parse the details of check %s: %v", check.ID, err)
+			return check.details
+		}
+	}
+	details.AuditRecords = &testhelper.AuditReference{File: audit.FileName, RecordIDs: check.auditRecordIDs}
+
+	detailsStr, err := json.Marshal(details)
+	if err != nil {
+		check.LogError("Failed to marshal the details of check %s: %v", check.ID, err)
+		return check.details
+	}
+	return string(detailsStr)
+}
+
+func allNotInspected(objects []*testhelper.ReportObject) bool {
+	for _, object := range objects {
+		if !object.IsNotInspected() {
+			return false
+		}
 	}
+	return true
 }
 
 func (check *Check) SetResultSkipped(reason string) {
@@ -265,6 +324,10 @@ func (check *Check) Run() error {
 	check.StartTime = time.Now()
 	defer func() {
 		check.EndTime = time.Now()
+		check.auditRecordIDs = audit.GetCheckRecordIDs(check.ID)
+		if len(check.auditRecordIDs) > 0 {
+			check.LogInfo("%d command(s) run by this check recorded in %s", len(check.auditRecordIDs), audit.FileName)
+		}
 	}()
 
 	check.LogInfo("Running check (labels: %v)", check.Labels)
@@ -1,10 +1,14 @@
 package checksdb
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/audit"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -146,3 +150,72 @@ func TestWithTimeout(t *testing.T) {
 
 	assert.Equal(t, time.Duration(10), check.Timeout)
 }
+
+func TestWithRequiredPermissions(t *testing.T) {
+	check := NewCheck("myID", []string{"label1", "label2"})
+
+	check.WithRequiredPermissions(permissions.Permission{Verb: "get", Resource: "nodes"})
+	assert.Len(t, check.RequiredPermissions, 1)
+
+	check.WithRequiredPermissions(permissions.Permission{Verb: "list", Resource: "pods"}, permissions.Permission{Verb: "delete", Resource: "pods"})
+	assert.Len(t, check.RequiredPermissions, 3)
+}
+
+func TestSetMissingPermissionsResult(t *testing.T) {
+	missing := []permissions.Permission{{Verb: "get", Resource: "nodes"}}
+
+	check := NewCheck("myID", []string{"label1"})
+	check.missingPermissions = missing
+	check.skipOnMissingPermissions = true
+	setMissingPermissionsResult(check)
+	assert.Equal(t, CheckResultSkipped, check.Result.String())
+	assert.Equal(t, "missing permissions: get nodes", check.skipReason)
+
+	check = NewCheck("myID", []string{"label1"})
+	check.missingPermissions = missing
+	setMissingPermissionsResult(check)
+	assert.Equal(t, CheckResultError, check.Result.String())
+}
+
+func TestSetResultNotInspectedNodes(t *testing.T) {
+	// A check whose nodes were not inspected does not pass.
+	check := NewCheck("myID", []string{"label1"})
+	check.SetResult([]*testhelper.ReportObject{
+		testhelper.NewNodeNotInspectedReportObject("node1"),
+		testhelper.NewNodeNotInspectedReportObject("node2"),
+	}, nil)
+	assert.Equal(t, CheckResultSkipped, check.Result.String())
+	assert.Equal(t, "none of the objects was inspected", check.skipReason)
+
+	// It passes when some of them were inspected.
+	check = NewCheck("myID", []string{"label1"})
+	check.SetResult([]*testhelper.ReportObject{
+		testhelper.NewNodeNotInspectedReportObject("node1"),
+		testhelper.NewNodeReportObject("node2", "compliant node", true),
+	}, nil)
+	assert.Equal(t, CheckResultPassed, check.Result.String())
+
+	check = NewCheck("myID", []string{"label1"})
+	check.SetResult([]*testhelper.ReportObject{testhelper.NewNodeNotInspectedReportObject("node1")},
+		[]*testhelper.ReportObject{testhelper.NewNodeReportObject("node2", "non compliant node", false)})
+	assert.Equal(t, CheckResultFailed, check.Result.String())
+}
+
+func TestGetDetailsWithAuditRecords(t *testing.T) {
+	check := NewCheck("myID", []string{"label1"})
+	check.SetResult([]*testhelper.ReportObject{testhelper.NewNodeReportObject("node1", "compliant node", true)}, nil)
+	assert.Equal(t, check.details, check.getDetails())
+
+	check.auditRecordIDs = []int{3, 4}
+	details := testhelper.FailureReasonOut{}
+	assert.Nil(t, json.Unmarshal([]byte(check.getDetails()), &details))
+	assert.Len(t, details.CompliantObjectsOut, 1)
+	assert.Equal(t, &testhelper.AuditReference{File: audit.FileName, RecordIDs: []int{3, 4}}, details.AuditRecords)
+
+	// The checks without result objects also reference their commands.
+	check = NewCheck("myID", []string{"label1"})
+	check.auditRecordIDs = []int{5}
+	details = testhelper.FailureReasonOut{}
+	assert.Nil(t, json.Unmarshal([]byte(check.getDetails()), &details))
+	assert.Equal(t, []int{5}, details.AuditRecords.RecordIDs)
+}
@@ -121,7 +121,7 @@ func recordCheckResult(check *Check) {
 		Duration:           int(check.EndTime.Sub(check.StartTime).Seconds()),
 		SkipReason:         check.skipReason,
 		CapturedTestOutput: check.GetLogs(),
-		CheckDetails:       check.details,
+		CheckDetails:       check.getDetails(),
 
 		CategoryClassification: &claim.CategoryClassification{
 			Extended: identifiers.Catalog[claimID].CategoryClassification[identifiers.Extended],
@@ -245,7 +245,7 @@ func FilterCheckIDs() ([]string, error) {
 	return filteredCheckIDs, nil
 }
 
-func InitLabelsExprEvaluator(labelsFilter string) error {
+func newLabelsExprEvaluator(labelsFilter string) (labels.LabelsExprEvaluator, error) {
 	// Expand the abstract "all" label into actual existing labels
 	if labelsFilter == "all" {
 		allTags := []string{identifiers.TagCommon, identifiers.TagExtended,
@@ -255,10 +255,41 @@ func InitLabelsExprEvaluator(labelsFilter string) error {
 
 	eval, err := labels.NewLabelsExprEvaluator(labelsFilter)
 	if err != nil {
-		return fmt.Errorf("could not create a label evaluator, err: %v", err)
+		return nil, fmt.Errorf("could not create a label evaluator, err: %v", err)
+	}
+
+	return eval, nil
+}
+
+func InitLabelsExprEvaluator(labelsFilter string) error {
+	eval, err := newLabelsExprEvaluator(labelsFilter)
+	if err != nil {
+		return err
 	}
 
 	labelsExprEvaluator = eval
 
 	return nil
 }
+
+// CountChecks returns the number of loaded checks matching a labels filter, without changing the filter of the
+// checks to run.
+func CountChecks(labelsFilter string) (int, error) {
+	eval, err := newLabelsExprEvaluator(labelsFilter)
+	if err != nil {
+		return 0, err
+	}
+
+	dbLock.Lock()
+	defer dbLock.Unlock()
+
+	count := 0
+	for _, group := range dbByGroup {
+		for _, check := range group.checks {
+			if eval.Eval(check.Labels) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
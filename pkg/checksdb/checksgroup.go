@@ -8,10 +8,15 @@ import (
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
 	checkIdxNone = -1
+
+	// SkipReasonNoMatchingLabels is the skip reason of the checks that don't match the labels filter.
+	SkipReasonNoMatchingLabels = "no matching labels"
 )
 
 type ChecksGroup struct {
@@ -301,7 +306,7 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 	checks := []*Check{}
 	for _, check := range group.checks {
 		if !labelsExprEvaluator.Eval(check.Labels) {
-			skipCheck(check, "no matching labels")
+			skipCheck(check, SkipReasonNoMatchingLabels)
 			continue
 		}
 		checks = append(checks, check)
@@ -311,6 +316,9 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 		return nil, 0
 	}
 
+	groupSpan := tracing.StartSpan("ChecksGroup "+group.name, attribute.String("certsuite.group", group.name))
+	defer groupSpan.End()
+
 	// Run afterAllFn always, no matter previous panics/crashes.
 	defer func() {
 		if err := runAfterAllFn(group, checks); err != nil {
@@ -339,6 +347,7 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 			remainingChecks = checks[i+1:]
 		}
 
+		checkSpan := tracing.StartSpan("Check "+check.ID, attribute.String("certsuite.check", check.ID))
 		if err := runBeforeEachFn(group, check, remainingChecks); err != nil {
 			errs = []error{err}
 		}
@@ -348,6 +357,9 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 			skip, reasons := shouldSkipCheck(check)
 			if skip {
 				skipCheck(check, strings.Join(reasons, ", "))
+			} else if len(check.missingPermissions) > 0 {
+				// Checks with missing permissions are not run, they'd fail in unexpected ways.
+				setMissingPermissionsResult(check)
 			} else {
 				check.SetAbortChan(abortChan) // Set the abort channel for the check.
 				err := runCheck(check, group, remainingChecks)
@@ -360,6 +372,8 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 		if err := runAfterEachFn(group, check, remainingChecks); err != nil {
 			errs = append(errs, err)
 		}
+		checkSpan.SetAttributes(attribute.String("certsuite.check.result", check.Result.String()))
+		checkSpan.End()
 
 		// Don't run more checks if any of beforeEach, the checkFn or afterEach functions errored/panicked.
 		if len(errs) > 0 {
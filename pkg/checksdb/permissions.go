@@ -0,0 +1,77 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package checksdb
+
+import (
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GetMissingPermissions reviews the permissions required by the checks matching the labels filter and
+// returns the missing ones by check ID. Checks with no missing permissions are not included.
+func GetMissingPermissions(checker *permissions.Checker, targetNamespaces []string, debugNamespace string,
+	scalableResources []schema.GroupResource) (map[string][]permissions.Permission, error) {
+	dbLock.Lock()
+	defer dbLock.Unlock()
+
+	missingByCheck := map[string][]permissions.Permission{}
+	for _, group := range dbByGroup {
+		for _, check := range group.checks {
+			if !labelsExprEvaluator.Eval(check.Labels) || len(check.RequiredPermissions) == 0 {
+				continue
+			}
+
+			required := permissions.Resolve(check.RequiredPermissions, targetNamespaces, debugNamespace, scalableResources)
+			missing, err := checker.GetMissing(required)
+			if err != nil {
+				return nil, err
+			}
+			if len(missing) > 0 {
+				missingByCheck[check.ID] = missing
+			}
+		}
+	}
+
+	return missingByCheck, nil
+}
+
+// SetMissingPermissions sets the missing permissions of the checks so they're not run. The checks will be
+// skipped if skipChecks is true, otherwise their result will be error.
+func SetMissingPermissions(missingByCheck map[string][]permissions.Permission, skipChecks bool) {
+	dbLock.Lock()
+	defer dbLock.Unlock()
+
+	for _, group := range dbByGroup {
+		for _, check := range group.checks {
+			check.missingPermissions = missingByCheck[check.ID]
+			check.skipOnMissingPermissions = skipChecks
+		}
+	}
+}
+
+// setMissingPermissionsResult sets the result of a check that can't be run because of missing permissions.
+func setMissingPermissionsResult(check *Check) {
+	reason := "missing permissions: " + permissions.ToString(check.missingPermissions)
+	if check.skipOnMissingPermissions {
+		skipCheck(check, reason)
+		return
+	}
+
+	check.LogError("Check %s can't be run, %s", check.ID, reason)
+	check.SetResultError(reason)
+	printCheckResult(check)
+}
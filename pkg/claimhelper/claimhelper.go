@@ -35,6 +35,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/diagnostics"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/labels"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
 )
 
@@ -48,6 +49,12 @@ const (
 	// States for test cases
 	TestStateFailed  = "failed"
 	TestStateSkipped = "skipped"
+
+	JUnitTestSuiteName = "CNF Certification Test Suite"
+
+	// Severities of the test cases in the JUnit output: high for the test cases that are mandatory in any scenario.
+	JUnitSeverityHigh = "high"
+	JUnitSeverityLow  = "low"
 )
 
 type SkippedMessage struct {
@@ -61,37 +68,43 @@ type FailureMessage struct {
 	Type    string `xml:"type,attr,omitempty"`
 }
 
+type Property struct {
+	Text  string `xml:",chardata"`
+	Name  string `xml:"name,attr,omitempty"`
+	Value string `xml:"value,attr,omitempty"`
+}
+
+type Properties struct {
+	Text     string     `xml:",chardata"`
+	Property []Property `xml:"property"`
+}
+
 type TestCase struct {
-	Text      string          `xml:",chardata"`
-	Name      string          `xml:"name,attr,omitempty"`
-	Classname string          `xml:"classname,attr,omitempty"`
-	Status    string          `xml:"status,attr,omitempty"`
-	Time      string          `xml:"time,attr,omitempty"`
-	SystemErr string          `xml:"system-err,omitempty"`
-	Skipped   *SkippedMessage `xml:"skipped"`
-	Failure   *FailureMessage `xml:"failure"`
+	Text       string          `xml:",chardata"`
+	Name       string          `xml:"name,attr,omitempty"`
+	Classname  string          `xml:"classname,attr,omitempty"`
+	Status     string          `xml:"status,attr,omitempty"`
+	Time       string          `xml:"time,attr,omitempty"`
+	Properties *Properties     `xml:"properties"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+	SystemErr  string          `xml:"system-err,omitempty"`
+	Skipped    *SkippedMessage `xml:"skipped"`
+	Failure    *FailureMessage `xml:"failure"`
 }
 
 type Testsuite struct {
-	Text       string `xml:",chardata"`
-	Name       string `xml:"name,attr,omitempty"`
-	Package    string `xml:"package,attr,omitempty"`
-	Tests      string `xml:"tests,attr,omitempty"`
-	Disabled   string `xml:"disabled,attr,omitempty"`
-	Skipped    string `xml:"skipped,attr,omitempty"`
-	Errors     string `xml:"errors,attr,omitempty"`
-	Failures   string `xml:"failures,attr,omitempty"`
-	Time       string `xml:"time,attr,omitempty"`
-	Timestamp  string `xml:"timestamp,attr,omitempty"`
-	Properties struct {
-		Text     string `xml:",chardata"`
-		Property []struct {
-			Text  string `xml:",chardata"`
-			Name  string `xml:"name,attr,omitempty"`
-			Value string `xml:"value,attr,omitempty"`
-		} `xml:"property"`
-	} `xml:"properties"`
-	Testcase []TestCase `xml:"testcase"`
+	Text       string     `xml:",chardata"`
+	Name       string     `xml:"name,attr,omitempty"`
+	Package    string     `xml:"package,attr,omitempty"`
+	Tests      string     `xml:"tests,attr,omitempty"`
+	Disabled   string     `xml:"disabled,attr,omitempty"`
+	Skipped    string     `xml:"skipped,attr,omitempty"`
+	Errors     string     `xml:"errors,attr,omitempty"`
+	Failures   string     `xml:"failures,attr,omitempty"`
+	Time       string     `xml:"time,attr,omitempty"`
+	Timestamp  string     `xml:"timestamp,attr,omitempty"`
+	Properties Properties `xml:"properties"`
+	Testcase   []TestCase `xml:"testcase"`
 }
 
 type TestSuitesXML struct {
@@ -156,16 +169,16 @@ func (c *ClaimBuilder) Build(outputFile string) {
 	log.Info("Claim file created at %s", outputFile)
 }
 
-//nolint:funlen
 func populateXMLFromClaim(c claim.Claim, startTime, endTime time.Time) TestSuitesXML {
-	const (
-		TestSuiteName = "CNF Certification Test Suite"
-	)
+	return populateXMLFromResults(c.Results, JUnitTestSuiteName, startTime, endTime)
+}
 
+// populateXMLFromResults creates the JUnit test suite with the given name containing the results.
+func populateXMLFromResults(results map[string]claim.Result, suiteName string, startTime, endTime time.Time) TestSuitesXML {
 	// Collector all of the Test IDs
 	allTestIDs := []string{}
-	for testID := range c.Results {
-		allTestIDs = append(allTestIDs, c.Results[testID].TestID.Id)
+	for testID := range results {
+		allTestIDs = append(allTestIDs, results[testID].TestID.Id)
 	}
 
 	// Sort the test IDs
@@ -173,20 +186,20 @@ func populateXMLFromClaim(c claim.Claim, startTime, endTime time.Time) TestSuite
 
 	xmlOutput := TestSuitesXML{}
 	// <testsuites>
-	xmlOutput.Tests = strconv.Itoa(len(c.Results))
+	xmlOutput.Tests = strconv.Itoa(len(results))
 
 	// Count all of the failed tests in the suite
 	failedTests := 0
-	for testID := range c.Results {
-		if c.Results[testID].State == TestStateFailed {
+	for testID := range results {
+		if results[testID].State == TestStateFailed {
 			failedTests++
 		}
 	}
 
 	// Count all of the skipped tests in the suite
 	skippedTests := 0
-	for testID := range c.Results {
-		if c.Results[testID].State == TestStateSkipped {
+	for testID := range results {
+		if results[testID].State == TestStateSkipped {
 			skippedTests++
 		}
 	}
@@ -197,8 +210,8 @@ func populateXMLFromClaim(c claim.Claim, startTime, endTime time.Time) TestSuite
 	xmlOutput.Time = strconv.FormatFloat(endTime.Sub(startTime).Seconds(), 'f', 5, 64)
 
 	// <testsuite>
-	xmlOutput.Testsuite.Name = TestSuiteName
-	xmlOutput.Testsuite.Tests = strconv.Itoa(len(c.Results))
+	xmlOutput.Testsuite.Name = suiteName
+	xmlOutput.Testsuite.Tests = strconv.Itoa(len(results))
 	// Counters for failed and skipped tests
 	xmlOutput.Testsuite.Failures = strconv.Itoa(failedTests)
 	xmlOutput.Testsuite.Skipped = strconv.Itoa(skippedTests)
@@ -208,56 +221,137 @@ func populateXMLFromClaim(c claim.Claim, startTime, endTime time.Time) TestSuite
 	xmlOutput.Testsuite.Timestamp = time.Now().UTC().Format(DateTimeFormatDirective)
 
 	// <properties>
+	xmlOutput.Testsuite.Properties.Property = []Property{
+		{Name: "certsuite-version", Value: versions.GitDisplayRelease},
+		{Name: "certsuite-git-commit", Value: versions.GitCommit},
+	}
 
 	// <testcase>
 	// Loop through all of the sorted test IDs
 	for _, testID := range allTestIDs {
-		testCase := TestCase{}
-		testCase.Name = testID
-		testCase.Classname = TestSuiteName
-		testCase.Status = c.Results[testID].State
+		xmlOutput.Testsuite.Testcase = append(xmlOutput.Testsuite.Testcase, getJUnitTestCase(testID, results[testID], suiteName))
+	}
 
-		// Clean the time strings to remove the " m=" suffix
-		start, err := time.Parse(DateTimeFormatDirective, strings.Split(c.Results[testID].StartTime, " m=")[0])
-		if err != nil {
-			log.Error("Failed to parse start time: %v", err)
+	return xmlOutput
+}
+
+func getJUnitTestCase(testID string, result claim.Result, classname string) TestCase {
+	testCase := TestCase{}
+	testCase.Name = testID
+	testCase.Classname = classname
+	testCase.Status = result.State
+	testCase.Properties = &Properties{Property: getJUnitTestCaseProperties(&result)}
+	testCase.SystemOut = result.CapturedTestOutput
+
+	// Clean the time strings to remove the " m=" suffix
+	start, err := time.Parse(DateTimeFormatDirective, strings.Split(result.StartTime, " m=")[0])
+	if err != nil {
+		log.Error("Failed to parse start time: %v", err)
+	}
+	end, err := time.Parse(DateTimeFormatDirective, strings.Split(result.EndTime, " m=")[0])
+	if err != nil {
+		log.Error("Failed to parse end time: %v", err)
+	}
+
+	// Calculate the duration of the test case
+	difference := end.Sub(start)
+	testCase.Time = strconv.FormatFloat(difference.Seconds(), 'f', 10, 64)
+
+	// Populate the skipped message if the test case was skipped
+	if testCase.Status == TestStateSkipped {
+		testCase.Skipped = &SkippedMessage{}
+		testCase.Skipped.Text = result.SkipReason
+	}
+
+	// Populate the failure message if the test case failed
+	if testCase.Status == TestStateFailed {
+		testCase.Failure = getJUnitFailureMessage(result.CheckDetails)
+	}
+
+	return testCase
+}
+
+// getJUnitTestCaseProperties returns the test case's labels, suite, classification per scenario, severity
+// and documentation link.
+func getJUnitTestCaseProperties(result *claim.Result) []Property {
+	properties := []Property{}
+	if result.TestID != nil {
+		properties = append(properties,
+			Property{Name: "suite", Value: result.TestID.Suite},
+			Property{Name: "labels", Value: result.TestID.Tags})
+	}
+
+	severity := JUnitSeverityLow
+	if result.CategoryClassification != nil {
+		classification := []struct{ scenario, value string }{
+			{identifiers.FarEdge, result.CategoryClassification.FarEdge},
+			{identifiers.Telco, result.CategoryClassification.Telco},
+			{identifiers.NonTelco, result.CategoryClassification.NonTelco},
+			{identifiers.Extended, result.CategoryClassification.Extended},
 		}
-		end, err := time.Parse(DateTimeFormatDirective, strings.Split(c.Results[testID].EndTime, " m=")[0])
-		if err != nil {
-			log.Error("Failed to parse end time: %v", err)
+		for _, c := range classification {
+			properties = append(properties, Property{Name: "classification." + c.scenario, Value: c.value})
+			if c.value == identifiers.Mandatory {
+				severity = JUnitSeverityHigh
+			}
 		}
+	}
+	properties = append(properties, Property{Name: "severity", Value: severity})
 
-		// Calculate the duration of the test case
-		difference := end.Sub(start)
-		testCase.Time = strconv.FormatFloat(difference.Seconds(), 'f', 10, 64)
+	if result.CatalogInfo != nil {
+		properties = append(properties, Property{Name: "doc-link", Value: result.CatalogInfo.BestPracticeReference})
+	}
 
-		// Populate the skipped message if the test case was skipped
-		if testCase.Status == TestStateSkipped {
-			testCase.Skipped = &SkippedMessage{}
-			testCase.Skipped.Text = c.Results[testID].SkipReason
-		} else {
-			testCase.Skipped = nil
-		}
+	return properties
+}
 
-		// Populate the failure message if the test case failed
-		if testCase.Status == TestStateFailed {
-			testCase.Failure = &FailureMessage{}
-			testCase.Failure.Text = c.Results[testID].CheckDetails
-		} else {
-			testCase.Failure = nil
-		}
+// getJUnitFailureMessage returns the failure message of a failed test case, listing its non-compliant objects
+// one per line. The check details are used as they are when they can't be parsed.
+func getJUnitFailureMessage(checkDetails string) *FailureMessage {
+	details := testhelper.FailureReasonOut{}
+	if err := j.Unmarshal([]byte(checkDetails), &details); err != nil || len(details.NonCompliantObjectsOut) == 0 {
+		return &FailureMessage{Text: checkDetails}
+	}
 
-		// Append the test case to the test suite
-		xmlOutput.Testsuite.Testcase = append(xmlOutput.Testsuite.Testcase, testCase)
+	lines := []string{}
+	for _, object := range details.NonCompliantObjectsOut {
+		lines = append(lines, getReadableReportObject(object))
 	}
 
-	return xmlOutput
+	return &FailureMessage{
+		Message: fmt.Sprintf("%d non-compliant object(s)", len(details.NonCompliantObjectsOut)),
+		Type:    "NonCompliant",
+		Text:    strings.Join(lines, "\n"),
+	}
 }
 
-func (c *ClaimBuilder) ToJUnitXML(outputFile string, startTime, endTime time.Time) {
-	// Create the JUnit XML file from the claim output.
-	xmlOutput := populateXMLFromClaim(*c.claimRoot.Claim, startTime, endTime)
+// getReadableReportObject returns a one-line description of a report object, e.g.
+// "Container tnf/test-0/test: Reason: not running as non-root (Namespace: tnf, Pod Name: test-0, ...)".
+func getReadableReportObject(object *testhelper.ReportObject) string {
+	fields := map[string]string{}
+	otherFields := []string{}
+	for i, key := range object.ObjectFieldsKeys {
+		if i >= len(object.ObjectFieldsValues) {
+			break
+		}
+		fields[key] = object.ObjectFieldsValues[i]
+		if key != testhelper.ReasonForNonCompliance {
+			otherFields = append(otherFields, key+": "+object.ObjectFieldsValues[i])
+		}
+	}
 
+	location := getSarifLogicalLocation(object.ObjectType, fields)
+	line := object.ObjectType + " " + location.FullyQualifiedName
+	if reason := fields[testhelper.ReasonForNonCompliance]; reason != "" {
+		line += ": " + reason
+	}
+	if len(otherFields) > 0 {
+		line += " (" + strings.Join(otherFields, ", ") + ")"
+	}
+	return line
+}
+
+func writeJUnitXMLFile(outputFile string, xmlOutput *TestSuitesXML) {
 	// Write the JUnit XML file.
 	payload, err := xml.MarshalIndent(xmlOutput, "", "  ")
 	if err != nil {
@@ -271,6 +365,44 @@ func (c *ClaimBuilder) ToJUnitXML(outputFile string, startTime, endTime time.Tim
 	}
 }
 
+func (c *ClaimBuilder) ToJUnitXML(outputFile string, startTime, endTime time.Time) {
+	// Create the JUnit XML file from the claim output.
+	xmlOutput := populateXMLFromClaim(*c.claimRoot.Claim, startTime, endTime)
+	writeJUnitXMLFile(outputFile, &xmlOutput)
+}
+
+// ToJUnitXMLPerSuite creates a JUnit XML file for each test suite, named <outputFilePrefix>_<suite>.xml, and
+// returns their paths.
+func (c *ClaimBuilder) ToJUnitXMLPerSuite(outputFilePrefix string, startTime, endTime time.Time) []string {
+	suitesResults := map[string]map[string]claim.Result{}
+	for testID, result := range c.claimRoot.Claim.Results {
+		suite := ""
+		if result.TestID != nil {
+			suite = result.TestID.Suite
+		}
+		if suitesResults[suite] == nil {
+			suitesResults[suite] = map[string]claim.Result{}
+		}
+		suitesResults[suite][testID] = result
+	}
+
+	suites := []string{}
+	for suite := range suitesResults {
+		suites = append(suites, suite)
+	}
+	sort.Strings(suites)
+
+	outputFiles := []string{}
+	for _, suite := range suites {
+		xmlOutput := populateXMLFromResults(suitesResults[suite], suite, startTime, endTime)
+		outputFile := outputFilePrefix + "_" + suite + ".xml"
+		writeJUnitXMLFile(outputFile, &xmlOutput)
+		outputFiles = append(outputFiles, outputFile)
+	}
+
+	return outputFiles
+}
+
 func (c *ClaimBuilder) Reset() {
 	c.claimRoot.Claim.Metadata.StartTime = time.Now().UTC().Format(DateTimeFormatDirective)
 }
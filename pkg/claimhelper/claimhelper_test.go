@@ -18,6 +18,7 @@ package claimhelper
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -308,3 +309,71 @@ func TestReadClaimFile(t *testing.T) {
 	// Check if the output is a valid JSON
 	assert.Contains(t, string(output), "test-case1")
 }
+
+func TestGetJUnitTestCase(t *testing.T) {
+	result := claim.Result{
+		TestID: &claim.Identifier{
+			Id:    "access-control-non-root-user",
+			Suite: "access-control",
+			Tags:  "common,telco",
+		},
+		State:              "failed",
+		StartTime:          "2023-12-20 14:51:33 -0600 MST",
+		EndTime:            "2023-12-20 14:51:34 -0600 MST",
+		CheckDetails:       `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":[{"ObjectType":"Container","ObjectFieldsKeys":["Reason For Non Compliance","Namespace","Pod Name","Container Name"],"ObjectFieldsValues":["Container is running as root","tnf","test-0","test"]}]}`,
+		CapturedTestOutput: "check logs",
+		CategoryClassification: &claim.CategoryClassification{
+			Extended: "Mandatory",
+			FarEdge:  "Optional",
+			NonTelco: "Optional",
+			Telco:    "Optional",
+		},
+		CatalogInfo: &claim.CatalogInfo{BestPracticeReference: "https://example.com/doc"},
+	}
+
+	testCase := getJUnitTestCase("access-control-non-root-user", result, "access-control")
+	assert.Equal(t, "access-control", testCase.Classname)
+	assert.Equal(t, "check logs", testCase.SystemOut)
+	assert.Contains(t, testCase.Properties.Property, Property{Name: "labels", Value: "common,telco"})
+	assert.Contains(t, testCase.Properties.Property, Property{Name: "classification.Extended", Value: "Mandatory"})
+	assert.Contains(t, testCase.Properties.Property, Property{Name: "severity", Value: JUnitSeverityHigh})
+	assert.Contains(t, testCase.Properties.Property, Property{Name: "doc-link", Value: "https://example.com/doc"})
+	assert.Equal(t, "1 non-compliant object(s)", testCase.Failure.Message)
+	assert.Equal(t, "Container tnf/test-0/test: Container is running as root (Namespace: tnf, Pod Name: test-0, Container Name: test)",
+		testCase.Failure.Text)
+
+	// Check details that can't be parsed are used as they are.
+	result.CheckDetails = "some failure"
+	testCase = getJUnitTestCase("access-control-non-root-user", result, "access-control")
+	assert.Equal(t, "some failure", testCase.Failure.Text)
+}
+
+func TestToJUnitXMLPerSuite(t *testing.T) {
+	t.Setenv("UNIT_TEST", "true")
+
+	testClaimBuilder, err := NewClaimBuilder()
+	assert.Nil(t, err)
+
+	newResult := func(testID, suite, state string) claim.Result {
+		return claim.Result{
+			TestID:    &claim.Identifier{Id: testID, Suite: suite},
+			State:     state,
+			StartTime: "2023-12-20 14:51:33 -0600 MST",
+			EndTime:   "2023-12-20 14:51:34 -0600 MST",
+		}
+	}
+	testClaimBuilder.claimRoot.Claim.Results = map[string]claim.Result{
+		"test-case1": newResult("test-case1", "suite1", "passed"),
+		"test-case2": newResult("test-case2", "suite1", "failed"),
+		"test-case3": newResult("test-case3", "suite2", "skipped"),
+	}
+
+	outputFilePrefix := filepath.Join(t.TempDir(), "junit")
+	outputFiles := testClaimBuilder.ToJUnitXMLPerSuite(outputFilePrefix, time.Now(), time.Now())
+	assert.Equal(t, []string{outputFilePrefix + "_suite1.xml", outputFilePrefix + "_suite2.xml"}, outputFiles)
+
+	content, err := os.ReadFile(outputFilePrefix + "_suite1.xml")
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `<testsuite name="suite1" tests="2" skipped="0" errors="0" failures="1"`)
+	assert.NotContains(t, string(content), "test-case3")
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimhelper
+
+import (
+	j "encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
+)
+
+const (
+	SarifVersion   = "2.1.0"
+	SarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifToolName           = "certsuite"
+	sarifToolInformationURI = "https://github.com/redhat-best-practices-for-k8s/certsuite"
+
+	// States for test cases, in addition to TestStateFailed and TestStateSkipped
+	TestStateError   = "error"
+	TestStateAborted = "aborted"
+)
+
+// Subset of the SARIF 2.1.0 object model needed to report the test results.
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name,omitempty"`
+	ShortDescription *SarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription  *SarifMessage          `json:"fullDescription,omitempty"`
+	Help             *SarifMessage          `json:"help,omitempty"`
+	HelpURI          string                 `json:"helpUri,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifLogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+type SarifLocation struct {
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type SarifResult struct {
+	RuleID       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Kind         string                 `json:"kind,omitempty"`
+	Level        string                 `json:"level,omitempty"`
+	Message      SarifMessage           `json:"message"`
+	Locations    []SarifLocation        `json:"locations,omitempty"`
+	Suppressions []SarifSuppression     `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// populateSARIFFromClaim creates a SARIF log with a rule for each failed, errored or skipped test case.
+// Each non-compliant object of a failed test case is a result, and skipped test cases are reported as
+// suppressed results.
+func populateSARIFFromClaim(c claim.Claim) SarifLog {
+	testIDs := []string{}
+	for testID := range c.Results {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Strings(testIDs)
+
+	run := SarifRun{
+		Tool: SarifTool{Driver: SarifDriver{
+			Name:           sarifToolName,
+			Version:        versions.GitDisplayRelease,
+			InformationURI: sarifToolInformationURI,
+			Rules:          []SarifRule{},
+		}},
+		Results: []SarifResult{},
+	}
+
+	for _, testID := range testIDs {
+		result := c.Results[testID]
+		var results []SarifResult
+		switch result.State {
+		case TestStateFailed:
+			results = getFailedTestCaseSarifResults(&result)
+		case TestStateError, TestStateAborted:
+			results = []SarifResult{{Kind: "fail", Level: "error", Message: SarifMessage{Text: "Test case " + result.State + ": " + result.SkipReason}}}
+		case TestStateSkipped:
+			results = []SarifResult{{
+				Kind:         "notApplicable",
+				Level:        "none",
+				Message:      SarifMessage{Text: "Test case skipped: " + result.SkipReason},
+				Suppressions: []SarifSuppression{{Kind: "external", Justification: result.SkipReason}},
+			}}
+		default:
+			continue
+		}
+
+		ruleIndex := len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, getSarifRule(testID, &result))
+		for i := range results {
+			results[i].RuleID = testID
+			results[i].RuleIndex = ruleIndex
+		}
+		run.Results = append(run.Results, results...)
+	}
+
+	return SarifLog{
+		Version: SarifVersion,
+		Schema:  SarifSchemaURI,
+		Runs:    []SarifRun{run},
+	}
+}
+
+func getSarifRule(testID string, result *claim.Result) SarifRule {
+	rule := SarifRule{ID: testID, Name: testID, Properties: map[string]interface{}{}}
+	if result.CatalogInfo != nil {
+		rule.ShortDescription = &SarifMessage{Text: strings.SplitN(result.CatalogInfo.Description, "\n", 2)[0]}
+		rule.FullDescription = &SarifMessage{Text: result.CatalogInfo.Description}
+		rule.Help = &SarifMessage{Text: result.CatalogInfo.Remediation}
+		rule.HelpURI = result.CatalogInfo.BestPracticeReference
+	}
+	if result.TestID != nil {
+		tags := []string{result.TestID.Suite}
+		if result.TestID.Tags != "" {
+			tags = append(tags, strings.Split(result.TestID.Tags, ",")...)
+		}
+		rule.Properties["tags"] = tags
+	}
+	return rule
+}
+
+// getFailedTestCaseSarifResults returns a result for each non-compliant object of a failed test case. If the
+// check details can't be parsed or have no non-compliant objects, a single result is returned.
+func getFailedTestCaseSarifResults(result *claim.Result) []SarifResult {
+	details := testhelper.FailureReasonOut{}
+	if err := j.Unmarshal([]byte(result.CheckDetails), &details); err != nil || len(details.NonCompliantObjectsOut) == 0 {
+		return []SarifResult{{Kind: "fail", Level: "error", Message: SarifMessage{Text: "Test case failed: " + result.CheckDetails}}}
+	}
+
+	results := []SarifResult{}
+	for _, object := range details.NonCompliantObjectsOut {
+		fields := map[string]string{}
+		for i := range object.ObjectFieldsKeys {
+			if i < len(object.ObjectFieldsValues) {
+				fields[object.ObjectFieldsKeys[i]] = object.ObjectFieldsValues[i]
+			}
+		}
+
+		results = append(results, SarifResult{
+			Kind:       "fail",
+			Level:      "error",
+			Message:    SarifMessage{Text: fields[testhelper.ReasonForNonCompliance]},
+			Locations:  []SarifLocation{{LogicalLocations: []SarifLogicalLocation{getSarifLogicalLocation(object.ObjectType, fields)}}},
+			Properties: map[string]interface{}{"objectType": object.ObjectType, "objectFields": fields},
+		})
+	}
+
+	return results
+}
+
+// getSarifLogicalLocation returns the location of a report object: namespace/pod/container for the
+// containers and pods, namespace/name for the operators and the namespaced objects, or just the name.
+func getSarifLogicalLocation(objectType string, fields map[string]string) SarifLogicalLocation {
+	location := SarifLogicalLocation{Kind: objectType}
+	switch {
+	case fields[testhelper.ContainerName] != "":
+		location.Name = fields[testhelper.ContainerName]
+		location.FullyQualifiedName = fields[testhelper.Namespace] + "/" + fields[testhelper.PodName] + "/" + fields[testhelper.ContainerName]
+	case fields[testhelper.PodName] != "":
+		location.Name = fields[testhelper.PodName]
+		location.FullyQualifiedName = fields[testhelper.Namespace] + "/" + fields[testhelper.PodName]
+	case fields[testhelper.Name] != "" && fields[testhelper.Namespace] != "":
+		location.Name = fields[testhelper.Name]
+		location.FullyQualifiedName = fields[testhelper.Namespace] + "/" + fields[testhelper.Name]
+	case fields[testhelper.Name] != "":
+		location.Name = fields[testhelper.Name]
+		location.FullyQualifiedName = fields[testhelper.Name]
+	default:
+		location.Name = objectType
+		location.FullyQualifiedName = objectType
+	}
+	return location
+}
+
+// ToSARIF writes the test results in SARIF 2.1.0 format, so they can be aggregated with other scanners'.
+func (c *ClaimBuilder) ToSARIF(outputFile string) {
+	sarifOutput := populateSARIFFromClaim(*c.claimRoot.Claim)
+
+	payload, err := j.MarshalIndent(sarifOutput, "", "  ")
+	if err != nil {
+		log.Fatal("Failed to generate the SARIF output: %v", err)
+	}
+
+	log.Info("Writing SARIF file: %s", outputFile)
+	err = os.WriteFile(outputFile, payload, claimFilePermissions)
+	if err != nil {
+		log.Fatal("Failed to write the SARIF file: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimhelper
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateSARIFFromClaim(t *testing.T) {
+	catalogInfo := &claim.CatalogInfo{
+		Description:           "Checks something.\nMore details.",
+		Remediation:           "Fix it.",
+		BestPracticeReference: "https://docs/test",
+	}
+
+	c := claim.Claim{Results: map[string]claim.Result{
+		"test-passed": {TestID: &claim.Identifier{Id: "test-passed", Suite: "suite1"}, State: "passed", CatalogInfo: catalogInfo},
+		"test-failed": {
+			TestID:      &claim.Identifier{Id: "test-failed", Suite: "suite1", Tags: "common,telco"},
+			State:       "failed",
+			CatalogInfo: catalogInfo,
+			CheckDetails: `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":[` +
+				`{"ObjectType":"Container","ObjectFieldsKeys":["Reason For Non Compliance","Namespace","Pod Name","Container Name"],"ObjectFieldsValues":["Bad container","ns1","pod1","c1"]},` +
+				`{"ObjectType":"Operator","ObjectFieldsKeys":["Reason For Non Compliance","Namespace","Name"],"ObjectFieldsValues":["Bad operator","ns2","op1"]}]}`,
+		},
+		"test-skipped": {TestID: &claim.Identifier{Id: "test-skipped", Suite: "suite2"}, State: "skipped", SkipReason: "no pods", CatalogInfo: catalogInfo},
+	}}
+
+	sarif := populateSARIFFromClaim(c)
+	assert.Equal(t, SarifVersion, sarif.Version)
+	assert.Len(t, sarif.Runs, 1)
+
+	rules := sarif.Runs[0].Tool.Driver.Rules
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "test-failed", rules[0].ID)
+	assert.Equal(t, "Checks something.", rules[0].ShortDescription.Text)
+	assert.Equal(t, "Fix it.", rules[0].Help.Text)
+	assert.Equal(t, "https://docs/test", rules[0].HelpURI)
+	assert.Equal(t, []string{"suite1", "common", "telco"}, rules[0].Properties["tags"])
+	assert.Equal(t, "test-skipped", rules[1].ID)
+
+	results := sarif.Runs[0].Results
+	assert.Len(t, results, 3)
+
+	assert.Equal(t, "test-failed", results[0].RuleID)
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, "Bad container", results[0].Message.Text)
+	assert.Equal(t, "ns1/pod1/c1", results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "ns2/op1", results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "Operator", results[1].Locations[0].LogicalLocations[0].Kind)
+
+	assert.Equal(t, "test-skipped", results[2].RuleID)
+	assert.Equal(t, 1, results[2].RuleIndex)
+	assert.Equal(t, []SarifSuppression{{Kind: "external", Justification: "no pods"}}, results[2].Suppressions)
+}
+
+func TestPopulateSARIFFromClaimNoObjects(t *testing.T) {
+	c := claim.Claim{Results: map[string]claim.Result{
+		"test-failed": {TestID: &claim.Identifier{Id: "test-failed"}, State: "failed", CheckDetails: "not json"},
+	}}
+
+	results := populateSARIFFromClaim(c).Runs[0].Results
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Test case failed: not json", results[0].Message.Text)
+	assert.Empty(t, results[0].Locations)
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimhelper
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	j "encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// ManifestFileName is the name of the digests manifest saved in the output folder when the claim is signed.
+	ManifestFileName = "certsuite-manifest.json"
+	// SignatureFileSuffix is appended to the manifest file name to get its detached signature file name.
+	SignatureFileSuffix = ".sig"
+
+	manifestDigestAlgorithm = "sha256"
+
+	// Verification status of the files in the manifest.
+	FileStatusOK       = "OK"
+	FileStatusTampered = "TAMPERED"
+	FileStatusMissing  = "MISSING"
+)
+
+// Manifest holds the digests of the signed files, by file name relative to the manifest's folder.
+type Manifest struct {
+	Algorithm string            `json:"algorithm"`
+	Files     map[string]string `json:"files"`
+}
+
+// FileVerification is the verification result of a file in the manifest.
+type FileVerification struct {
+	FileName string
+	Status   string
+}
+
+func getFileDigest(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func readPEMBlock(keyFile string) (*pem.Block, error) {
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %v", keyFile, err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in key file %s", keyFile)
+	}
+	return block, nil
+}
+
+// LoadPrivateKey loads an ed25519 or ECDSA private key from a PEM file, in PKCS #8 or SEC 1 (ECDSA only) form.
+func LoadPrivateKey(keyFile string) (crypto.Signer, error) {
+	block, err := readPEMBlock(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "EC PRIVATE KEY" {
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key in %s: %v", keyFile, err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T in %s, only ed25519 and ECDSA keys are supported", key, keyFile)
+	}
+}
+
+// LoadPublicKey loads an ed25519 or ECDSA public key from a PEM file in PKIX form.
+func LoadPublicKey(keyFile string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key in %s: %v", keyFile, err)
+	}
+
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T in %s, only ed25519 and ECDSA keys are supported", key, keyFile)
+	}
+}
+
+func sign(key crypto.Signer, message []byte) ([]byte, error) {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, message), nil
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.SignASN1(rand.Reader, k, digest[:])
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+func verify(key crypto.PublicKey, message, signature []byte) bool {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, message, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(k, digest[:], signature)
+	default:
+		return false
+	}
+}
+
+// SignFiles writes a manifest with the digests of the files and its detached signature, made with the private
+// key in privateKeyFile. The files must be in the same folder as the manifest.
+func SignFiles(privateKeyFile, manifestFile string, filePaths []string) error {
+	key, err := LoadPrivateKey(privateKeyFile)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{Algorithm: manifestDigestAlgorithm, Files: map[string]string{}}
+	for _, filePath := range filePaths {
+		digest, err := getFileDigest(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get the digest of %s: %v", filePath, err)
+		}
+		manifest.Files[filepath.Base(filePath)] = digest
+	}
+
+	payload, err := j.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the manifest: %v", err)
+	}
+
+	signature, err := sign(key, payload)
+	if err != nil {
+		return fmt.Errorf("failed to sign the manifest: %v", err)
+	}
+
+	if err := os.WriteFile(manifestFile, payload, claimFilePermissions); err != nil {
+		return fmt.Errorf("failed to write the manifest file %s: %v", manifestFile, err)
+	}
+
+	signatureFile := manifestFile + SignatureFileSuffix
+	if err := os.WriteFile(signatureFile, []byte(base64.StdEncoding.EncodeToString(signature)+"\n"), claimFilePermissions); err != nil {
+		return fmt.Errorf("failed to write the signature file %s: %v", signatureFile, err)
+	}
+
+	return nil
+}
+
+// VerifyFiles checks the signature of the manifest with the public key in publicKeyFile and returns the
+// verification status of each file in the manifest, sorted by name. An error is returned if the manifest has
+// been tampered with.
+func VerifyFiles(publicKeyFile, manifestFile string) ([]FileVerification, error) {
+	key, err := LoadPublicKey(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest file %s: %v", manifestFile, err)
+	}
+
+	signatureFile := manifestFile + SignatureFileSuffix
+	encodedSignature, err := os.ReadFile(signatureFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signature file %s: %v", signatureFile, err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the signature in %s: %v", signatureFile, err)
+	}
+
+	if !verify(key, payload, signature) {
+		return nil, errors.New("the manifest signature is not valid: the manifest was modified or signed with another key")
+	}
+
+	manifest := Manifest{}
+	if err := j.Unmarshal(payload, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the manifest file %s: %v", manifestFile, err)
+	}
+	if manifest.Algorithm != manifestDigestAlgorithm {
+		return nil, fmt.Errorf("unsupported digest algorithm %q in the manifest", manifest.Algorithm)
+	}
+
+	fileNames := []string{}
+	for fileName := range manifest.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	verifications := []FileVerification{}
+	manifestDir := filepath.Dir(manifestFile)
+	for _, fileName := range fileNames {
+		status := FileStatusOK
+		digest, err := getFileDigest(filepath.Join(manifestDir, fileName))
+		switch {
+		case err != nil:
+			status = FileStatusMissing
+		case digest != manifest.Files[fileName]:
+			status = FileStatusTampered
+		}
+		verifications = append(verifications, FileVerification{FileName: fileName, Status: status})
+	}
+
+	return verifications, nil
+}
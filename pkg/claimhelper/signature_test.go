@@ -0,0 +1,119 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package claimhelper
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeKeyFiles(t *testing.T, dir string, privateKey crypto.Signer) (privateKeyFile, publicKeyFile string) {
+	privateBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.Nil(t, err)
+	publicBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	assert.Nil(t, err)
+
+	privateKeyFile = filepath.Join(dir, "key.pem")
+	publicKeyFile = filepath.Join(dir, "pub.pem")
+	assert.Nil(t, os.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateBytes}), 0o600))
+	assert.Nil(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes}), 0o600))
+	return privateKeyFile, publicKeyFile
+}
+
+func TestSignAndVerifyFiles(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	for _, key := range []crypto.Signer{ed25519Key, ecdsaKey} {
+		dir := t.TempDir()
+		keysDir := t.TempDir()
+		privateKeyFile, publicKeyFile := writeKeyFiles(t, keysDir, key)
+
+		claimFile := filepath.Join(dir, "claim.json")
+		zipFile := filepath.Join(dir, "results.tar.gz")
+		assert.Nil(t, os.WriteFile(claimFile, []byte(`{"claim":{}}`), 0o600))
+		assert.Nil(t, os.WriteFile(zipFile, []byte("zip content"), 0o600))
+
+		manifestFile := filepath.Join(dir, ManifestFileName)
+		assert.Nil(t, SignFiles(privateKeyFile, manifestFile, []string{claimFile, zipFile}))
+
+		verifications, err := VerifyFiles(publicKeyFile, manifestFile)
+		assert.Nil(t, err)
+		assert.Equal(t, []FileVerification{
+			{FileName: "claim.json", Status: FileStatusOK},
+			{FileName: "results.tar.gz", Status: FileStatusOK},
+		}, verifications)
+
+		// Modified and removed files are reported.
+		assert.Nil(t, os.WriteFile(claimFile, []byte(`{"claim":{"results":{}}}`), 0o600))
+		assert.Nil(t, os.Remove(zipFile))
+		verifications, err = VerifyFiles(publicKeyFile, manifestFile)
+		assert.Nil(t, err)
+		assert.Equal(t, []FileVerification{
+			{FileName: "claim.json", Status: FileStatusTampered},
+			{FileName: "results.tar.gz", Status: FileStatusMissing},
+		}, verifications)
+
+		// A modified manifest fails the signature verification.
+		assert.Nil(t, os.WriteFile(manifestFile, []byte(`{"algorithm":"sha256","files":{}}`), 0o600))
+		_, err = VerifyFiles(publicKeyFile, manifestFile)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestVerifyFilesWithAnotherKey(t *testing.T) {
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	privateKeyFile, _ := writeKeyFiles(t, t.TempDir(), signingKey)
+	_, otherPublicKeyFile := writeKeyFiles(t, t.TempDir(), otherKey)
+
+	claimFile := filepath.Join(dir, "claim.json")
+	assert.Nil(t, os.WriteFile(claimFile, []byte(`{"claim":{}}`), 0o600))
+	manifestFile := filepath.Join(dir, ManifestFileName)
+	assert.Nil(t, SignFiles(privateKeyFile, manifestFile, []string{claimFile}))
+
+	_, err = VerifyFiles(otherPublicKeyFile, manifestFile)
+	assert.NotNil(t, err)
+}
+
+func TestLoadPrivateKeyErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadPrivateKey(filepath.Join(dir, "missing.pem"))
+	assert.NotNil(t, err)
+
+	notPEM := filepath.Join(dir, "not.pem")
+	assert.Nil(t, os.WriteFile(notPEM, []byte("not a key"), 0o600))
+	_, err = LoadPrivateKey(notPEM)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// User is a partner allowed to upload claims and to access its own claims. Admins can access the claims of all
+// the partners. The password can be a bcrypt hash.
+type User struct {
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+	Admin    bool   `yaml:"admin,omitempty"`
+}
+
+type Credentials struct {
+	Users []User `yaml:"users"`
+}
+
+// LoadCredentials reads the users of the collector from a YAML file.
+func LoadCredentials(credentialsFile string) (*Credentials, error) {
+	content, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s: %v", credentialsFile, err)
+	}
+
+	credentials := Credentials{}
+	if err := yaml.Unmarshal(content, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %v", credentialsFile, err)
+	}
+
+	names := map[string]bool{}
+	for _, user := range credentials.Users {
+		if user.Name == "" || user.Password == "" {
+			return nil, fmt.Errorf("credentials file %s has a user without name or password", credentialsFile)
+		}
+		if names[user.Name] {
+			return nil, fmt.Errorf("credentials file %s has user %s twice", credentialsFile, user.Name)
+		}
+		names[user.Name] = true
+	}
+
+	return &credentials, nil
+}
+
+func isBcryptHash(password string) bool {
+	return strings.HasPrefix(password, "$2a$") || strings.HasPrefix(password, "$2b$") || strings.HasPrefix(password, "$2y$")
+}
+
+// Authenticate returns the user with that name and password, or nil.
+func (c *Credentials) Authenticate(name, password string) *User {
+	for i := range c.Users {
+		user := &c.Users[i]
+		if user.Name != name {
+			continue
+		}
+
+		if isBcryptHash(user.Password) {
+			if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil {
+				return user
+			}
+		} else if subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) == 1 {
+			return user
+		}
+		return nil
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -93,5 +94,9 @@ func SendClaimFileToCollector(endPoint, claimFilePath, executedBy, partnerName,
 		return err
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("collector returned status %s", resp.Status)
+	}
 	return nil
 }
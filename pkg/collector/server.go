@@ -0,0 +1,232 @@
+package collector
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+const (
+	// Maximum size of the upload requests.
+	maxUploadSize = 100 << 20
+	// Maximum number of claims listed when the request doesn't set a limit.
+	defaultListLimit = 100
+)
+
+//go:embed collector.html
+var collectorHTML []byte
+
+// ClaimSummary is a claim record with the test cases that failed.
+type ClaimSummary struct {
+	ClaimRecord
+	FailedTestCases []string `json:"failedTestCases"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server is the receiving endpoint of the claims collector. Without credentials, the claims can be uploaded and
+// read by anyone.
+type Server struct {
+	store       *Store
+	credentials *Credentials
+}
+
+func NewServer(store *Store, credentials *Credentials) *Server {
+	return &Server{store: store, credentials: credentials}
+}
+
+// Handler returns the handler of the upload endpoint, the REST API and the web page.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /{$}", s.uploadHandler)
+	mux.HandleFunc("GET /{$}", s.authenticated(s.pageHandler))
+	mux.HandleFunc("GET /api/v1/claims", s.authenticated(s.listHandler))
+	mux.HandleFunc("GET /api/v1/claims/{id}", s.authenticated(s.summaryHandler))
+	mux.HandleFunc("GET /api/v1/claims/{id}/download", s.authenticated(s.downloadHandler))
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Failed to write the collector response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+type userHandlerFunc func(w http.ResponseWriter, r *http.Request, user *User)
+
+// authenticated requires HTTP basic authentication with the credentials of a user.
+func (s *Server) authenticated(handler userHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.credentials == nil {
+			handler(w, r, &User{Admin: true})
+			return
+		}
+
+		name, password, ok := r.BasicAuth()
+		user := s.credentials.Authenticate(name, password)
+		if !ok || user == nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="certsuite collector"`)
+			writeError(w, http.StatusUnauthorized, errors.New("invalid credentials"))
+			return
+		}
+		handler(w, r, user)
+	}
+}
+
+// uploadHandler stores a claim file sent by "certsuite run" with the --enable-data-collection flag. The multipart
+// form has the claimFile, executed_by, partner_name and decoded_password fields, and the partner name and password
+// are the credentials of a user.
+func (s *Server) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid upload form: %v", err))
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	partnerName := r.FormValue("partner_name")
+	if s.credentials != nil && s.credentials.Authenticate(partnerName, r.FormValue("decoded_password")) == nil {
+		log.Warn("Rejected claim upload of partner %q: invalid credentials", partnerName)
+		writeError(w, http.StatusUnauthorized, errors.New("invalid credentials"))
+		return
+	}
+
+	claimFile, _, err := r.FormFile("claimFile")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("claim file not found: %v", err))
+		return
+	}
+	defer claimFile.Close()
+
+	content, err := io.ReadAll(claimFile)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read the claim file: %v", err))
+		return
+	}
+
+	record, err := s.store.AddClaim(partnerName, r.FormValue("executed_by"), content)
+	if err != nil {
+		log.Error("Failed to store the claim of partner %q: %v", partnerName, err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	log.Info("Claim %d of partner %q stored", record.ID, partnerName)
+	writeJSON(w, http.StatusCreated, record)
+}
+
+func (s *Server) pageHandler(w http.ResponseWriter, _ *http.Request, _ *User) {
+	w.Header().Set("Content-Type", "text/html")
+	if _, err := w.Write(collectorHTML); err != nil {
+		log.Error("Failed to write the collector page: %v", err)
+	}
+}
+
+// listHandler lists the claims, filtered by the partner, executedBy, search and limit query parameters. Partners
+// only get their own claims.
+func (s *Server) listHandler(w http.ResponseWriter, r *http.Request, user *User) {
+	query := r.URL.Query()
+	filter := ClaimFilter{
+		PartnerName: query.Get("partner"),
+		ExecutedBy:  query.Get("executedBy"),
+		Search:      query.Get("search"),
+		Limit:       defaultListLimit,
+	}
+	if !user.Admin {
+		filter.PartnerName = user.Name
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", limit))
+			return
+		}
+	}
+
+	records, err := s.store.ListClaims(&filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// getClaimRecord returns the record of the claim of the request path, or writes the error response if it doesn't
+// exist or belongs to another partner.
+func (s *Server) getClaimRecord(w http.ResponseWriter, r *http.Request, user *User) *ClaimRecord {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid claim id %q", r.PathValue("id")))
+		return nil
+	}
+
+	record, err := s.store.GetClaim(id)
+	if errors.Is(err, ErrClaimNotFound) || (err == nil && !user.Admin && record.PartnerName != user.Name) {
+		writeError(w, http.StatusNotFound, ErrClaimNotFound)
+		return nil
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil
+	}
+	return record
+}
+
+func (s *Server) summaryHandler(w http.ResponseWriter, r *http.Request, user *User) {
+	record := s.getClaimRecord(w, r, user)
+	if record == nil {
+		return
+	}
+
+	content, err := s.store.GetClaimContent(record.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	parsed, err := parseClaimContent(content)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summary := ClaimSummary{ClaimRecord: *record, FailedTestCases: parsed.getTestCasesByState()["failed"]}
+	if summary.FailedTestCases == nil {
+		summary.FailedTestCases = []string{}
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (s *Server) downloadHandler(w http.ResponseWriter, r *http.Request, user *User) {
+	record := s.getClaimRecord(w, r, user)
+	if record == nil {
+		return
+	}
+
+	content, err := s.store.GetClaimContent(record.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"claim-%d.json\"", record.ID))
+	if _, err := w.Write(content); err != nil {
+		log.Error("Failed to write claim %d: %v", record.ID, err)
+	}
+}
@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testClaim = `{"claim": {"versions": {"tnf": "v5.2.0", "ocp": "4.15.0", "k8s": "v1.28.6"}, "results": {
+	"access-control-namespace": {"state": "passed"},
+	"lifecycle-pod-owner-type": {"state": "failed"},
+	"lifecycle-cpu-isolation": {"state": "skipped"},
+	"networking-icmpv4-connectivity": {"state": "failed"}
+}}}`
+
+func newTestServer(t *testing.T, credentials *Credentials) *httptest.Server {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "collector.db"))
+	assert.Nil(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	server := httptest.NewServer(NewServer(store, credentials).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeTestClaim(t *testing.T, content string) string {
+	claimFile := filepath.Join(t.TempDir(), "claim.json")
+	assert.Nil(t, os.WriteFile(claimFile, []byte(content), 0o600))
+	return claimFile
+}
+
+func getJSON(t *testing.T, url, user, password string, v any) int {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	assert.Nil(t, err)
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	if v != nil && resp.StatusCode == http.StatusOK {
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestLoadCredentials(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "users.yaml")
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(credentialsFile, []byte("users:\n"+
+		"  - name: acme\n    password: '"+string(hash)+"'\n"+
+		"  - name: redhat\n    password: admin-password\n    admin: true\n"), 0o600))
+
+	credentials, err := LoadCredentials(credentialsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "acme", credentials.Authenticate("acme", "secret").Name)
+	assert.Nil(t, credentials.Authenticate("acme", "wrong"))
+	assert.True(t, credentials.Authenticate("redhat", "admin-password").Admin)
+	assert.Nil(t, credentials.Authenticate("redhat", "admin"))
+	assert.Nil(t, credentials.Authenticate("other", "secret"))
+
+	assert.Nil(t, os.WriteFile(credentialsFile, []byte("users:\n  - name: acme\n"), 0o600))
+	_, err = LoadCredentials(credentialsFile)
+	assert.NotNil(t, err)
+}
+
+func TestServer(t *testing.T) {
+	credentials := &Credentials{Users: []User{
+		{Name: "acme", Password: "acme-password"},
+		{Name: "other", Password: "other-password"},
+		{Name: "redhat", Password: "admin-password", Admin: true},
+	}}
+	server := newTestServer(t, credentials)
+	claimFile := writeTestClaim(t, testClaim)
+
+	// Uploads with the same multipart contract of the client.
+	assert.Nil(t, SendClaimFileToCollector(server.URL, claimFile, "ci", "acme", "acme-password"))
+	assert.Nil(t, SendClaimFileToCollector(server.URL, claimFile, "nightly", "other", "other-password"))
+	assert.NotNil(t, SendClaimFileToCollector(server.URL, claimFile, "ci", "acme", "other-password"))
+	assert.NotNil(t, SendClaimFileToCollector(server.URL, writeTestClaim(t, "not a claim"), "ci", "acme", "acme-password"))
+
+	// Partners only get their own claims.
+	records := []ClaimRecord{}
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/claims", "acme", "acme-password", &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, "acme", records[0].PartnerName)
+	assert.Equal(t, "ci", records[0].ExecutedBy)
+	assert.Equal(t, "v5.2.0", records[0].CertSuiteVersion)
+	assert.Equal(t, "4.15.0", records[0].OCPVersion)
+	assert.Equal(t, 1, records[0].Passed)
+	assert.Equal(t, 2, records[0].Failed)
+	assert.Equal(t, 1, records[0].Skipped)
+	acmeClaimURL := fmt.Sprintf("%s/api/v1/claims/%d", server.URL, records[0].ID)
+
+	assert.Equal(t, http.StatusNotFound, getJSON(t, acmeClaimURL, "other", "other-password", nil))
+	assert.Equal(t, http.StatusUnauthorized, getJSON(t, acmeClaimURL, "acme", "wrong", nil))
+	assert.Equal(t, http.StatusUnauthorized, getJSON(t, server.URL+"/", "", "", nil))
+
+	// Admins get all of them, newest first.
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/claims", "redhat", "admin-password", &records))
+	assert.Len(t, records, 2)
+	assert.Equal(t, "other", records[0].PartnerName)
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/claims?search=nigh", "redhat", "admin-password", &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/claims?partner=acme&limit=1", "redhat", "admin-password", &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, server.URL+"/api/v1/claims?limit=none", "redhat", "admin-password", nil))
+
+	summary := ClaimSummary{}
+	assert.Equal(t, http.StatusOK, getJSON(t, acmeClaimURL, "acme", "acme-password", &summary))
+	assert.Equal(t, []string{"lifecycle-pod-owner-type", "networking-icmpv4-connectivity"}, summary.FailedTestCases)
+	assert.Equal(t, "acme", summary.PartnerName)
+	assert.Equal(t, http.StatusNotFound, getJSON(t, server.URL+"/api/v1/claims/3", "redhat", "admin-password", nil))
+
+	req, err := http.NewRequest(http.MethodGet, acmeClaimURL+"/download", http.NoBody)
+	assert.Nil(t, err)
+	req.SetBasicAuth("acme", "acme-password")
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, testClaim, string(content))
+	assert.Equal(t, `attachment; filename="claim-1.json"`, resp.Header.Get("Content-Disposition"))
+}
+
+func TestServerWithoutCredentials(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	assert.Nil(t, SendClaimFileToCollector(server.URL, writeTestClaim(t, testClaim), "ci", "acme", ""))
+	records := []ClaimRecord{}
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/api/v1/claims", "", "", &records))
+	assert.Len(t, records, 1)
+	assert.Equal(t, http.StatusOK, getJSON(t, server.URL+"/", "", "", nil))
+}
@@ -0,0 +1,243 @@
+package collector
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// Pure Go SQLite driver, registered as "sqlite".
+	_ "github.com/glebarez/go-sqlite"
+)
+
+const createClaimsTable = `CREATE TABLE IF NOT EXISTS claims (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	partner_name TEXT NOT NULL,
+	executed_by TEXT NOT NULL,
+	uploaded_at TEXT NOT NULL,
+	certsuite_version TEXT NOT NULL,
+	ocp_version TEXT NOT NULL,
+	k8s_version TEXT NOT NULL,
+	passed INTEGER NOT NULL,
+	failed INTEGER NOT NULL,
+	skipped INTEGER NOT NULL,
+	content BLOB NOT NULL
+)`
+
+const claimColumns = "id, partner_name, executed_by, uploaded_at, certsuite_version, ocp_version, k8s_version, passed, failed, skipped"
+
+// ErrClaimNotFound is returned when there's no claim with the requested id.
+var ErrClaimNotFound = errors.New("claim not found")
+
+// ClaimRecord is a claim file stored by the collector, with the summary of its results.
+type ClaimRecord struct {
+	ID               int64     `json:"id"`
+	PartnerName      string    `json:"partnerName"`
+	ExecutedBy       string    `json:"executedBy"`
+	UploadedAt       time.Time `json:"uploadedAt"`
+	CertSuiteVersion string    `json:"certSuiteVersion"`
+	OCPVersion       string    `json:"ocpVersion"`
+	K8sVersion       string    `json:"k8sVersion"`
+	Passed           int       `json:"passed"`
+	Failed           int       `json:"failed"`
+	Skipped          int       `json:"skipped"`
+}
+
+// ClaimFilter selects the claims listed. Empty fields don't filter.
+type ClaimFilter struct {
+	PartnerName string
+	ExecutedBy  string
+	// Text searched in the partner name, the executor and the versions.
+	Search string
+	Limit  int
+}
+
+// claimContent has the fields of the claim file needed by the summary.
+type claimContent struct {
+	Claim struct {
+		Versions struct {
+			CertSuite string `json:"tnf"`
+			OCP       string `json:"ocp"`
+			K8s       string `json:"k8s"`
+		} `json:"versions"`
+		Results map[string]struct {
+			State string `json:"state"`
+		} `json:"results"`
+	} `json:"claim"`
+}
+
+func parseClaimContent(content []byte) (*claimContent, error) {
+	parsed := claimContent{}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid claim file: %v", err)
+	}
+	if parsed.Claim.Results == nil {
+		return nil, errors.New("invalid claim file: no results found")
+	}
+	return &parsed, nil
+}
+
+// getTestCasesByState returns the sorted ids of the test cases of the claim, by state.
+func (c *claimContent) getTestCasesByState() map[string][]string {
+	testCases := map[string][]string{}
+	for id, result := range c.Claim.Results {
+		testCases[result.State] = append(testCases[result.State], id)
+	}
+	for state := range testCases {
+		sort.Strings(testCases[state])
+	}
+	return testCases
+}
+
+// Store saves the claims in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens the SQLite database file, creating it if it doesn't exist.
+func OpenStore(dbFile string) (*Store, error) {
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %v", dbFile, err)
+	}
+
+	if _, err := db.Exec(createClaimsTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create the claims table in database %s: %v", dbFile, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// AddClaim stores a claim file with the summary of its results, returning its record.
+func (s *Store) AddClaim(partnerName, executedBy string, content []byte) (*ClaimRecord, error) {
+	parsed, err := parseClaimContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases := parsed.getTestCasesByState()
+	record := ClaimRecord{
+		PartnerName:      partnerName,
+		ExecutedBy:       executedBy,
+		UploadedAt:       time.Now().UTC().Truncate(time.Second),
+		CertSuiteVersion: parsed.Claim.Versions.CertSuite,
+		OCPVersion:       parsed.Claim.Versions.OCP,
+		K8sVersion:       parsed.Claim.Versions.K8s,
+		Passed:           len(testCases["passed"]),
+		Failed:           len(testCases["failed"]),
+		Skipped:          len(testCases["skipped"]),
+	}
+
+	result, err := s.db.Exec("INSERT INTO claims (partner_name, executed_by, uploaded_at, certsuite_version, ocp_version, "+
+		"k8s_version, passed, failed, skipped, content) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		record.PartnerName, record.ExecutedBy, record.UploadedAt.Format(time.RFC3339), record.CertSuiteVersion,
+		record.OCPVersion, record.K8sVersion, record.Passed, record.Failed, record.Skipped, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store the claim: %v", err)
+	}
+
+	record.ID, err = result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the id of the stored claim: %v", err)
+	}
+	return &record, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanClaimRecord(row rowScanner) (*ClaimRecord, error) {
+	record := ClaimRecord{}
+	uploadedAt := ""
+	err := row.Scan(&record.ID, &record.PartnerName, &record.ExecutedBy, &uploadedAt, &record.CertSuiteVersion,
+		&record.OCPVersion, &record.K8sVersion, &record.Passed, &record.Failed, &record.Skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	record.UploadedAt, err = time.Parse(time.RFC3339, uploadedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload time %q of claim %d: %v", uploadedAt, record.ID, err)
+	}
+	return &record, nil
+}
+
+// ListClaims returns the records of the claims selected by the filter, the most recent first.
+func (s *Store) ListClaims(filter *ClaimFilter) ([]ClaimRecord, error) {
+	conditions := []string{}
+	args := []any{}
+	if filter.PartnerName != "" {
+		conditions = append(conditions, "partner_name = ?")
+		args = append(args, filter.PartnerName)
+	}
+	if filter.ExecutedBy != "" {
+		conditions = append(conditions, "executed_by = ?")
+		args = append(args, filter.ExecutedBy)
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "(partner_name LIKE ? OR executed_by LIKE ? OR certsuite_version LIKE ? OR "+
+			"ocp_version LIKE ? OR k8s_version LIKE ?)")
+		pattern := "%" + filter.Search + "%"
+		args = append(args, pattern, pattern, pattern, pattern, pattern)
+	}
+
+	query := "SELECT " + claimColumns + " FROM claims"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the claims: %v", err)
+	}
+	defer rows.Close()
+
+	records := []ClaimRecord{}
+	for rows.Next() {
+		record, err := scanClaimRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the claims: %v", err)
+		}
+		records = append(records, *record)
+	}
+	return records, rows.Err()
+}
+
+// GetClaim returns the record of a claim, or ErrClaimNotFound.
+func (s *Store) GetClaim(id int64) (*ClaimRecord, error) {
+	record, err := scanClaimRecord(s.db.QueryRow("SELECT "+claimColumns+" FROM claims WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrClaimNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim %d: %v", id, err)
+	}
+	return record, nil
+}
+
+// GetClaimContent returns the claim file of a claim, or ErrClaimNotFound.
+func (s *Store) GetClaimContent(id int64) ([]byte, error) {
+	content := []byte{}
+	err := s.db.QueryRow("SELECT content FROM claims WHERE id = ?", id).Scan(&content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrClaimNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim %d: %v", id, err)
+	}
+	return content, nil
+}
@@ -60,6 +60,47 @@ type CrdFilter struct {
 	NameSuffix string `yaml:"nameSuffix" json:"nameSuffix"`
 	Scalable   bool   `yaml:"scalable" json:"scalable"`
 }
+
+// RedactionPolicy defines the sensitive data to be replaced by tokens in the claim, the log and the results artifacts.
+type RedactionPolicy struct {
+	// Redact the IPv4 and IPv6 addresses
+	IPs bool `yaml:"ips,omitempty" json:"ips,omitempty"`
+	// Redact the node names and hostnames
+	Hostnames bool `yaml:"hostnames,omitempty" json:"hostnames,omitempty"`
+	// Redact the registries of the container images, except the kept ones
+	ImageRegistries bool     `yaml:"imageRegistries,omitempty" json:"imageRegistries,omitempty"`
+	KeptRegistries  []string `yaml:"keptRegistries,omitempty" json:"keptRegistries,omitempty"`
+	// Keys of the annotations and labels whose values are redacted
+	AnnotationKeys []string `yaml:"annotationKeys,omitempty" json:"annotationKeys,omitempty"`
+	LabelKeys      []string `yaml:"labelKeys,omitempty" json:"labelKeys,omitempty"`
+	// Regular expressions of other data to be redacted
+	CustomPatterns []string `yaml:"customPatterns,omitempty" json:"customPatterns,omitempty"`
+	// Path of the file that maps the tokens to the redacted values, so the redaction can be reversed
+	MappingFile string `yaml:"mappingFile,omitempty" json:"mappingFile,omitempty"`
+}
+
+// ResultSink defines a destination the claim and the results artifacts are sent to at the end of the run. The
+// credentials are not saved in the claim file and can reference environment variables, e.g. ${WEBHOOK_TOKEN}.
+type ResultSink struct {
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Type of the sink: webhook, s3 or directory
+	Type string `yaml:"type" json:"type"`
+	// webhook: URL the claim file is posted to, bearer token, additional headers and number of retries
+	URL     string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Token   string            `yaml:"token,omitempty" json:"-"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"-"`
+	Retries int               `yaml:"retries,omitempty" json:"retries,omitempty"`
+	// s3: endpoint of the S3-compatible object storage, region, bucket, prefix of the object keys and credentials
+	Endpoint        string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	Region          string `yaml:"region,omitempty" json:"region,omitempty"`
+	Bucket          string `yaml:"bucket,omitempty" json:"bucket,omitempty"`
+	Prefix          string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	AccessKeyID     string `yaml:"accessKeyID,omitempty" json:"-"`
+	SecretAccessKey string `yaml:"secretAccessKey,omitempty" json:"-"`
+	// directory: local folder the results are archived in
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
 type ManagedDeploymentsStatefulsets struct {
 	Name string `yaml:"name" json:"name"`
 }
@@ -87,6 +128,14 @@ type TestConfiguration struct {
 	ValidProtocolNames          []string                          `yaml:"validProtocolNames,omitempty" json:"validProtocolNames,omitempty"`
 	ServicesIgnoreList          []string                          `yaml:"servicesignorelist,omitempty" json:"servicesignorelist,omitempty"`
 	DebugDaemonSetNamespace     string                            `yaml:"debugDaemonSetNamespace,omitempty" json:"debugDaemonSetNamespace,omitempty"`
+	// Restricts the debug DaemonSet to the nodes hosting pods under test
+	DebugDaemonSetWorkloadNodesOnly bool `yaml:"debugDaemonSetWorkloadNodesOnly,omitempty" json:"debugDaemonSetWorkloadNodesOnly,omitempty"`
+	// Additional node selector for the debug DaemonSet
+	DebugDaemonSetNodeSelector map[string]string `yaml:"debugDaemonSetNodeSelector,omitempty" json:"debugDaemonSetNodeSelector,omitempty"`
+	// Sensitive data redaction
+	Redaction *RedactionPolicy `yaml:"redaction,omitempty" json:"redaction,omitempty"`
+	// Destinations of the claim and the results artifacts, besides the collector
+	ResultSinks []ResultSink `yaml:"resultSinks,omitempty" json:"resultSinks,omitempty"`
 	// Collector's parameters
 	ExecutedBy           string `yaml:"executedBy,omitempty" json:"executedBy,omitempty"`
 	PartnerName          string `yaml:"partnerName,omitempty" json:"partnerName,omitempty"`
@@ -107,6 +156,7 @@ type TestParameters struct {
 	DaemonsetMemReq               string
 	DaemonsetMemLim               string
 	SanitizeClaim                 bool
+	SkipChecksMissingPermissions  bool
 	TnfImageRepo                  string
 	TnfDebugImage                 string
 	NonIntrusiveOnly              bool
@@ -115,6 +165,21 @@ type TestParameters struct {
 	OmitArtifactsZipFile          bool
 	EnableDataCollection          bool
 	EnableXMLCreation             bool
+	EnableJUnitFilePerSuite       bool
+	EnableSARIFCreation           bool
+	EnableMetricsFile             bool
+	EnableTracing                 bool
+	OTLPEndpoint                  string
+	SignKeyFile                   string
+	ReportFormat                  string
 	ServerMode                    bool
+	MaxConcurrentRuns             int
+	ServerAddress                 string
+	ServerTLSCertFile             string
+	ServerTLSKeyFile              string
+	ServerSelfSignedCert          bool
+	ServerTokensFile              string
+	ServerHtpasswdFile            string
+	ServerAllowedOrigins          []string
 	Timeout                       time.Duration
 }
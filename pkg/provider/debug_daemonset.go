@@ -0,0 +1,213 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	k8sPrivilegedDs "github.com/redhat-best-practices-for-k8s/privileged-daemonset"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	k8sFakeClient "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	debugPodsPollInterval = 5 * time.Second
+	nodeNameField         = "metadata.name"
+)
+
+// isDebugDaemonSetScoped returns true when the debug DaemonSet must not be scheduled on every node of the cluster.
+func isDebugDaemonSetScoped(config *configuration.TestConfiguration) bool {
+	return config.DebugDaemonSetWorkloadNodesOnly || len(config.DebugDaemonSetNodeSelector) > 0
+}
+
+// getDebugDaemonSetNodeNames returns the sorted names of the nodes where the debug pods should be deployed. The
+// nodes hosting pods under test are always included, even if they don't match the node selector.
+func getDebugDaemonSetNodeNames(nodes map[string]Node, podsUnderTest []*Pod, config *configuration.TestConfiguration) []string {
+	selector := labels.SelectorFromSet(config.DebugDaemonSetNodeSelector)
+	nodeNames := []string{}
+	for nodeName := range nodes {
+		node := nodes[nodeName]
+		hasWorkload := node.HasWorkloadDeployed(podsUnderTest)
+		if config.DebugDaemonSetWorkloadNodesOnly && !hasWorkload {
+			continue
+		}
+		if !selector.Matches(labels.Set(node.Data.Labels)) {
+			if !hasWorkload {
+				continue
+			}
+			log.Warn("Node %q does not match the debug DaemonSet node selector but hosts pods under test, deploying a debug pod on it", nodeName)
+		}
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// GetNodesWithoutDebugPod returns the sorted names of the nodes that have no debug pod running on them, so
+// node level checks cannot inspect them.
+func (env *TestEnvironment) GetNodesWithoutDebugPod() []string {
+	nodeNames := []string{}
+	for nodeName := range env.Nodes {
+		if _, exist := env.DebugPods[nodeName]; !exist {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// IsNodeNotInspected returns true when the debug DaemonSet is restricted to a subset of the nodes and the given
+// node has no debug pod, so the node level checks must report it as not inspected.
+func (env *TestEnvironment) IsNodeNotInspected(nodeName string) bool {
+	_, exist := env.DebugPods[nodeName]
+	return !exist && env.DebugPodsNodeScoped
+}
+
+// isScopedDebugDaemonSet returns true if the given debug DaemonSet was restricted to a subset of the nodes.
+func isScopedDebugDaemonSet(ds *appsv1.DaemonSet) bool {
+	return ds.Spec.Template.Spec.Affinity != nil || len(ds.Spec.Template.Spec.NodeSelector) > 0
+}
+
+// isScopedDebugDaemonSetDeployed returns true if a node scoped debug DaemonSet already exists in the namespace.
+func isScopedDebugDaemonSetDeployed(client kubernetes.Interface, namespace string) bool {
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), DaemonSetName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return isScopedDebugDaemonSet(ds)
+}
+
+// getDebugDaemonSetLabels returns the labels of the debug DaemonSet pods.
+func getDebugDaemonSetLabels() map[string]string {
+	return map[string]string{
+		"name":                                  DaemonSetName,
+		"redhat-best-practices-for-k8s.com/app": DaemonSetName,
+	}
+}
+
+// newScopedDebugDaemonSet returns the debug DaemonSet of the privileged-daemonset library restricted to the given
+// node names. The library doesn't export its DaemonSet template, so it's deployed with a fake client first, where
+// it's scheduled on no node and gets ready right away, and then the node affinity is added to it.
+func newScopedDebugDaemonSet(namespace, image string, nodeNames []string, params *configuration.TestParameters) (*appsv1.DaemonSet, error) {
+	fakeClient := k8sFakeClient.NewSimpleClientset()
+	k8sPrivilegedDs.SetDaemonSetClient(fakeClient)
+	_, err := k8sPrivilegedDs.CreateDaemonSet(DaemonSetName, namespace, containerName, image, getDebugDaemonSetLabels(), debugPodsTimeout,
+		params.DaemonsetCPUReq, params.DaemonsetCPULim, params.DaemonsetMemReq, params.DaemonsetMemLim)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate the tnf daemonset, err=%v", err)
+	}
+
+	ds, err := fakeClient.AppsV1().DaemonSets(namespace).Get(context.TODO(), DaemonSetName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not generate the tnf daemonset, err=%v", err)
+	}
+
+	ds.ObjectMeta = metav1.ObjectMeta{
+		Name:        ds.Name,
+		Namespace:   ds.Namespace,
+		Labels:      ds.Labels,
+		Annotations: ds.Annotations,
+	}
+	ds.Spec.Template.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchFields: []corev1.NodeSelectorRequirement{{
+						Key:      nodeNameField,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   nodeNames,
+					}},
+				}},
+			},
+		},
+	}
+	return ds, nil
+}
+
+// deployScopedDaemonSet deploys the debug DaemonSet only on the given nodes and waits for its pods to be ready.
+func deployScopedDaemonSet(namespace string, nodeNames []string) error {
+	if len(nodeNames) == 0 {
+		return errors.New("no nodes selected to deploy the tnf daemonset")
+	}
+
+	params := configuration.GetTestParameters()
+	dsImage := params.TnfImageRepo + "/" + params.TnfDebugImage
+	ds, err := newScopedDebugDaemonSet(namespace, dsImage, nodeNames, params)
+	if err != nil {
+		return err
+	}
+
+	client := clientsholder.GetClientsHolder().K8sClient
+	k8sPrivilegedDs.SetDaemonSetClient(client)
+
+	log.Info("Deploying the tnf daemonset on %d node(s): %v", len(nodeNames), nodeNames)
+	journal.RecordDebugDaemonSetDeployed(namespace, DaemonSetName)
+	if err := k8sPrivilegedDs.DeleteNamespaceIfPresent(namespace); err != nil {
+		return fmt.Errorf("could not delete namespace %q, err=%v", namespace, err)
+	}
+	_, err = client.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("could not create namespace %q, err=%v", namespace, err)
+	}
+	if err := k8sPrivilegedDs.ConfigurePrivilegedServiceAccount(namespace); err != nil {
+		return fmt.Errorf("could not configure privileged rights, err=%v", err)
+	}
+
+	if _, err := client.AppsV1().DaemonSets(namespace).Create(context.TODO(), ds, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("could not deploy tnf daemonset, err=%v", err)
+	}
+
+	if err := waitScopedDaemonSetReady(client, namespace, len(nodeNames), debugPodsTimeout); err != nil {
+		return fmt.Errorf("timed out waiting for tnf daemonset, err=%v", err)
+	}
+
+	return nil
+}
+
+// waitScopedDaemonSetReady waits until the debug DaemonSet has a ready pod on each of the expected nodes.
+func waitScopedDaemonSetReady(client kubernetes.Interface, namespace string, expectedPods int, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(context.TODO(), debugPodsPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, DaemonSetName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get daemonset %q (ns %q), err: %v", DaemonSetName, namespace, err)
+		}
+
+		status := ds.Status
+		if status.DesiredNumberScheduled != int32(expectedPods) {
+			log.Debug("Daemonset %q scheduled on %d node(s), expected %d", DaemonSetName, status.DesiredNumberScheduled, expectedPods)
+			return false, nil
+		}
+
+		return status.NumberReady == status.DesiredNumberScheduled &&
+			status.NumberAvailable == status.DesiredNumberScheduled &&
+			status.NumberMisscheduled == 0, nil
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDebugDaemonSetNodeNames(t *testing.T) {
+	generateNode := func(nodeName string, labels map[string]string) Node {
+		return Node{
+			Data: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: labels,
+				},
+			},
+		}
+	}
+
+	nodes := map[string]Node{
+		"node1": generateNode("node1", map[string]string{"zone": "a"}),
+		"node2": generateNode("node2", map[string]string{"zone": "b"}),
+		"node3": generateNode("node3", map[string]string{"zone": "a"}),
+	}
+	pods := []*Pod{
+		{Pod: &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node2"}}},
+		{Pod: &corev1.Pod{Spec: corev1.PodSpec{NodeName: "node3"}}},
+	}
+
+	testCases := []struct {
+		config   configuration.TestConfiguration
+		expected []string
+	}{
+		{
+			config:   configuration.TestConfiguration{},
+			expected: []string{"node1", "node2", "node3"},
+		},
+		{
+			config:   configuration.TestConfiguration{DebugDaemonSetWorkloadNodesOnly: true},
+			expected: []string{"node2", "node3"},
+		},
+		{
+			// node2 does not match the selector but hosts a pod under test.
+			config:   configuration.TestConfiguration{DebugDaemonSetNodeSelector: map[string]string{"zone": "a"}},
+			expected: []string{"node1", "node2", "node3"},
+		},
+		{
+			config:   configuration.TestConfiguration{DebugDaemonSetNodeSelector: map[string]string{"zone": "b"}},
+			expected: []string{"node2", "node3"},
+		},
+		{
+			config: configuration.TestConfiguration{
+				DebugDaemonSetWorkloadNodesOnly: true,
+				DebugDaemonSetNodeSelector:      map[string]string{"zone": "c"},
+			},
+			expected: []string{"node2", "node3"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, getDebugDaemonSetNodeNames(nodes, pods, &testCase.config))
+	}
+}
+
+func TestGetNodesWithoutDebugPod(t *testing.T) {
+	env := TestEnvironment{
+		Nodes: map[string]Node{
+			"node1": {Data: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			"node2": {Data: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}}},
+			"node3": {Data: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node3"}}},
+		},
+		DebugPods: map[string]*corev1.Pod{
+			"node2": {},
+		},
+	}
+
+	assert.Equal(t, []string{"node1", "node3"}, env.GetNodesWithoutDebugPod())
+
+	// The nodes without a debug pod are only reported as not inspected when the DaemonSet is node scoped.
+	assert.False(t, env.IsNodeNotInspected("node1"))
+	env.DebugPodsNodeScoped = true
+	assert.True(t, env.IsNodeNotInspected("node1"))
+	assert.False(t, env.IsNodeNotInspected("node2"))
+}
+
+func TestNewScopedDebugDaemonSet(t *testing.T) {
+	params := configuration.TestParameters{
+		DaemonsetCPUReq: "100m",
+		DaemonsetCPULim: "100m",
+		DaemonsetMemReq: "512M",
+		DaemonsetMemLim: "512M",
+	}
+
+	ds, err := newScopedDebugDaemonSet("cnf-suite", "quay.io/debug:latest", []string{"node1", "node3"}, &params)
+	assert.Nil(t, err)
+	assert.Equal(t, DaemonSetName, ds.Name)
+	assert.Equal(t, "cnf-suite", ds.Namespace)
+	assert.Empty(t, ds.ResourceVersion)
+	assert.True(t, isScopedDebugDaemonSet(ds))
+
+	// The rest of the spec comes from the privileged-daemonset library.
+	podSpec := ds.Spec.Template.Spec
+	assert.Equal(t, getDebugDaemonSetLabels()["name"], ds.Spec.Template.Labels["name"])
+	assert.Equal(t, "quay.io/debug:latest", podSpec.Containers[0].Image)
+	assert.Equal(t, containerName, podSpec.Containers[0].Name)
+	assert.True(t, *podSpec.Containers[0].SecurityContext.Privileged)
+	assert.Equal(t, "100m", podSpec.Containers[0].Resources.Requests.Cpu().String())
+	assert.True(t, podSpec.HostPID)
+	assert.NotEmpty(t, podSpec.Tolerations)
+	assert.NotEmpty(t, podSpec.Volumes)
+
+	terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Len(t, terms, 1)
+	assert.Equal(t, []string{"node1", "node3"}, terms[0].MatchFields[0].Values)
+}
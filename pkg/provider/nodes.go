@@ -123,10 +123,12 @@ const (
 	isHyperThreadCommand = "chroot /host lscpu"
 )
 
-func (node *Node) IsHyperThreadNode(env *TestEnvironment) (bool, error) {
+// IsHyperThreadNode returns whether the node has hyperthreading enabled. The command is recorded in the audit trail as
+// run by the given check.
+func (node *Node) IsHyperThreadNode(env *TestEnvironment, checkID string) (bool, error) {
 	o := clientsholder.GetClientsHolder()
 	nodeName := node.Data.Name
-	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name)
+	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name).WithCheckID(checkID)
 	cmdValue, errStr, err := o.ExecCommandContainer(ctx, isHyperThreadCommand)
 	if err != nil || errStr != "" {
 		return false, fmt.Errorf("cannot execute %s on debug pod %s, err=%s, stderr=%s", isHyperThreadCommand, env.DebugPods[nodeName], err, errStr)
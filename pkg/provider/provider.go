@@ -29,6 +29,7 @@ import (
 	mcv1 "github.com/openshift/api/machineconfiguration/v1"
 	olmv1Alpha "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/autodiscover"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
@@ -113,6 +114,7 @@ type TestEnvironment struct { // rename this with testTarget
 	IstioServiceMeshFound  bool
 	ValidProtocolNames     []string
 	DaemonsetFailedToSpawn bool
+	DebugPodsNodeScoped    bool
 	ScaleCrUnderTest       []ScaleObject
 	StorageClassList       []storagev1.StorageClass
 	ExecutedBy             string
@@ -179,14 +181,14 @@ func deployDaemonSet(namespace string) error {
 	k8sPrivilegedDs.SetDaemonSetClient(clientsholder.GetClientsHolder().K8sClient)
 
 	dsImage := env.params.TnfImageRepo + "/" + env.params.TnfDebugImage
-	if k8sPrivilegedDs.IsDaemonSetReady(DaemonSetName, namespace, dsImage) {
+	// A debug DaemonSet left by a node scoped run is not reused, as it does not cover all the nodes.
+	if k8sPrivilegedDs.IsDaemonSetReady(DaemonSetName, namespace, dsImage) &&
+		!isScopedDebugDaemonSetDeployed(clientsholder.GetClientsHolder().K8sClient, namespace) {
 		return nil
 	}
 
-	matchLabels := make(map[string]string)
-	matchLabels["name"] = DaemonSetName
-	matchLabels["redhat-best-practices-for-k8s.com/app"] = DaemonSetName
-	_, err := k8sPrivilegedDs.CreateDaemonSet(DaemonSetName, namespace, containerName, dsImage, matchLabels, debugPodsTimeout,
+	journal.RecordDebugDaemonSetDeployed(namespace, DaemonSetName)
+	_, err := k8sPrivilegedDs.CreateDaemonSet(DaemonSetName, namespace, containerName, dsImage, getDebugDaemonSetLabels(), debugPodsTimeout,
 		configuration.GetTestParameters().DaemonsetCPUReq,
 		configuration.GetTestParameters().DaemonsetCPULim,
 		configuration.GetTestParameters().DaemonsetMemReq,
@@ -214,12 +216,17 @@ func buildTestEnvironment() { //nolint:funlen
 	}
 	log.Debug("CERTSUITE configuration: %+v", config)
 
-	// Wait for the debug pods to be ready before the autodiscovery starts.
-	if err := deployDaemonSet(config.DebugDaemonSetNamespace); err != nil {
-		log.Error("The TNF daemonset could not be deployed, err: %v", err)
-		// Because of this failure, we are only able to run a certain amount of tests that do not rely
-		// on the existence of the daemonset debug pods.
-		env.DaemonsetFailedToSpawn = true
+	// When the debug DaemonSet is restricted to some nodes, it can only be deployed once the pods
+	// under test have been found.
+	env.DebugPodsNodeScoped = isDebugDaemonSetScoped(&config)
+	if !env.DebugPodsNodeScoped {
+		// Wait for the debug pods to be ready before the autodiscovery starts.
+		if err := deployDaemonSet(config.DebugDaemonSetNamespace); err != nil {
+			log.Error("The TNF daemonset could not be deployed, err: %v", err)
+			// Because of this failure, we are only able to run a certain amount of tests that do not rely
+			// on the existence of the daemonset debug pods.
+			env.DaemonsetFailedToSpawn = true
+		}
 	}
 
 	data := autodiscover.DoAutoDiscover(&config)
@@ -252,11 +259,23 @@ func buildTestEnvironment() { //nolint:funlen
 		aNewPod := NewPod(&pods[i])
 		env.AllPods = append(env.AllPods, &aNewPod)
 	}
+	if env.DebugPodsNodeScoped {
+		nodeNames := getDebugDaemonSetNodeNames(env.Nodes, env.Pods, &config)
+		if err := deployScopedDaemonSet(config.DebugDaemonSetNamespace, nodeNames); err != nil {
+			log.Error("The TNF daemonset could not be deployed, err: %v", err)
+			env.DaemonsetFailedToSpawn = true
+		}
+		data.DebugPods = autodiscover.FindDebugPods(&config)
+	}
 	env.DebugPods = make(map[string]*corev1.Pod)
 	for i := 0; i < len(data.DebugPods); i++ {
 		nodeName := data.DebugPods[i].Spec.NodeName
 		env.DebugPods[nodeName] = &data.DebugPods[i]
 	}
+	if env.DebugPodsNodeScoped {
+		log.Warn("The TNF daemonset is restricted to %d node(s). Nodes that will not be inspected by node level checks: %v",
+			len(env.DebugPods), env.GetNodesWithoutDebugPod())
+	}
 
 	env.CSVToPodListMap = make(map[string][]*Pod)
 	for k, podList := range data.CSVToPodListMap {
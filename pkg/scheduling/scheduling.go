@@ -83,11 +83,11 @@ var schedulingRequirements = map[string]string{SharedCPUScheduling: "SHARED_CPU_
 	ExclusiveCPUScheduling: "EXCLUSIVE_CPU_SCHEDULING: scheduling priority < 10 and scheduling policy == SCHED_RR or SCHED_FIFO",
 	IsolatedCPUScheduling:  "ISOLATED_CPU_SCHEDULING: scheduling policy == SCHED_RR or SCHED_FIFO"}
 
-func ProcessPidsCPUScheduling(processes []*crclient.Process, testContainer *provider.Container, check string, logger *log.Logger) (compliantContainerPids, nonCompliantContainerPids []*testhelper.ReportObject) {
+func ProcessPidsCPUScheduling(processes []*crclient.Process, testContainer *provider.Container, check, checkID string, logger *log.Logger) (compliantContainerPids, nonCompliantContainerPids []*testhelper.ReportObject) {
 	hasCPUSchedulingConditionSuccess := false
 	for _, process := range processes {
 		logger.Debug("Testing process %q", process)
-		schedulePolicy, schedulePriority, err := GetProcessCPUSchedulingFn(process.Pid, testContainer)
+		schedulePolicy, schedulePriority, err := GetProcessCPUSchedulingFn(process.Pid, testContainer, checkID)
 		if err != nil {
 			logger.Error("Unable to get the scheduling policy and priority : %v", err)
 			return compliantContainerPids, nonCompliantContainerPids
@@ -117,7 +117,9 @@ func ProcessPidsCPUScheduling(processes []*crclient.Process, testContainer *prov
 	return compliantContainerPids, nonCompliantContainerPids
 }
 
-func GetProcessCPUScheduling(pid int, testContainer *provider.Container) (schedulePolicy string, schedulePriority int, err error) {
+// GetProcessCPUScheduling gets the scheduling policy and priority of a container process. The command is recorded in the
+// audit trail as run by the given check.
+func GetProcessCPUScheduling(pid int, testContainer *provider.Container, checkID string) (schedulePolicy string, schedulePriority int, err error) {
 	log.Info("Checking the scheduling policy/priority in %v for pid=%d", testContainer, pid)
 
 	command := fmt.Sprintf("chrt -p %d", pid)
@@ -126,6 +128,7 @@ func GetProcessCPUScheduling(pid int, testContainer *provider.Container) (schedu
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to get debug pod's context for container %s: %v", testContainer, err)
 	}
+	ctx = ctx.WithCheckID(checkID)
 
 	ch := clientsholder.GetClientsHolder()
 
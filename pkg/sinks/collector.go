@@ -0,0 +1,39 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sinks
+
+import (
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/collector"
+)
+
+// collectorSink sends the claim file to the claims collector, enabled with the --enable-data-collection flag.
+type collectorSink struct {
+	endpoint string
+	password string
+}
+
+func NewCollectorSink(endpoint, password string) Sink {
+	return &collectorSink{endpoint: endpoint, password: password}
+}
+
+func (sink *collectorSink) Name() string {
+	return TypeCollector
+}
+
+func (sink *collectorSink) Send(results *Results) error {
+	return collector.SendClaimFileToCollector(sink.endpoint, results.ClaimFile, results.ExecutedBy, results.PartnerName, sink.password)
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sinks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	s3Timeout       = 5 * time.Minute
+
+	// AWS signature version 4 parameters.
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	amzDayFormat     = "20060102"
+)
+
+// s3Sink uploads the results to a bucket of an S3-compatible object storage, like AWS S3 or MinIO. The objects are
+// addressed with path-style URLs, which all of them support.
+type s3Sink struct {
+	name            string
+	endpoint        string
+	region          string
+	bucket          string
+	prefix          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+func newS3Sink(name string, config *configuration.ResultSink) (Sink, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("the bucket of the %s sink is not set", name)
+	}
+
+	sink := &s3Sink{
+		name:            name,
+		endpoint:        strings.TrimSuffix(config.Endpoint, "/"),
+		region:          config.Region,
+		bucket:          config.Bucket,
+		prefix:          strings.Trim(config.Prefix, "/"),
+		accessKeyID:     os.ExpandEnv(config.AccessKeyID),
+		secretAccessKey: os.ExpandEnv(config.SecretAccessKey),
+		client:          &http.Client{Timeout: s3Timeout},
+	}
+	if sink.accessKeyID == "" || sink.secretAccessKey == "" {
+		return nil, fmt.Errorf("the credentials of the %s sink are not set", name)
+	}
+	if sink.region == "" {
+		sink.region = defaultS3Region
+	}
+	if sink.endpoint == "" {
+		sink.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", sink.region)
+	}
+
+	return sink, nil
+}
+
+func (sink *s3Sink) Name() string {
+	return sink.name
+}
+
+// Send uploads the results files as objects named <prefix>/<run time>/<file name>.
+func (sink *s3Sink) Send(results *Results) error {
+	for _, file := range results.getFiles() {
+		key := path.Join(sink.prefix, results.getRunID(), filepath.Base(file))
+		if err := sink.putObject(key, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sink *s3Sink) putObject(key, file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, sink.endpoint+"/"+uriEncode(sink.bucket+"/"+key), bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	signRequest(req, content, sink.region, sink.accessKeyID, sink.secretAccessKey, time.Now())
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s: %v", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload object %s: status %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// uriEncode encodes every byte of the path but the unreserved characters and the slashes, as required by the
+// canonical request of the signature.
+func uriEncode(uriPath string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(uriPath) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-_.~/", b) >= 0 {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// signRequest signs a request without query parameters with the AWS signature version 4, adding the
+// x-amz-date, x-amz-content-sha256 and Authorization headers. All the headers of the request are signed.
+func signRequest(req *http.Request, payload []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for header, values := range req.Header {
+		headers[strings.ToLower(header)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := []string{}
+	for header := range headers {
+		headerNames = append(headerNames, header)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, header := range headerNames {
+		canonicalHeaders.WriteString(header + ":" + headers[header] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.UTC().Format(amzDayFormat), region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), now.UTC().Format(amzDayFormat))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package sinks sends the claim and the results artifacts of a run to the destinations set in the configuration:
+// HTTP webhooks, S3-compatible object storages, local directories and the collector.
+package sinks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+const (
+	// Types of the result sinks.
+	TypeWebhook   = "webhook"
+	TypeS3        = "s3"
+	TypeDirectory = "directory"
+	TypeCollector = "collector"
+
+	runIDTimeFormat = "20060102-150405"
+)
+
+// Results are the files of a run sent to the sinks.
+type Results struct {
+	ClaimFile string
+	// Optional, empty when the artifacts file is not created.
+	ArtifactsFile string
+	ExecutedBy    string
+	PartnerName   string
+	// Time of the run, used to store the results of different runs separately.
+	Time time.Time
+}
+
+// Sink is a destination of the results of a run.
+type Sink interface {
+	Name() string
+	Send(results *Results) error
+}
+
+// Report is the outcome of sending the results to a sink.
+type Report struct {
+	Sink string
+	Err  error
+}
+
+// New creates the sink set in the configuration. The credentials of the configuration can reference environment
+// variables.
+func New(config *configuration.ResultSink) (Sink, error) {
+	name := config.Type
+	if config.Name != "" {
+		name = fmt.Sprintf("%s %q", config.Type, config.Name)
+	}
+
+	switch config.Type {
+	case TypeWebhook:
+		return newWebhookSink(name, config)
+	case TypeS3:
+		return newS3Sink(name, config)
+	case TypeDirectory:
+		return newDirectorySink(name, config)
+	default:
+		return nil, fmt.Errorf("unknown result sink type %q", config.Type)
+	}
+}
+
+// SendAll sends the results to the sinks of the configuration, and to the extra sinks, returning the outcome of
+// each of them. A sink that can't be created is reported as failed.
+func SendAll(configs []configuration.ResultSink, extraSinks []Sink, results *Results) []Report {
+	sinks := []Sink{}
+	reports := []Report{}
+	for i := range configs {
+		sink, err := New(&configs[i])
+		if err != nil {
+			reports = append(reports, Report{Sink: fmt.Sprintf("result sink %d", i+1), Err: err})
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+	sinks = append(sinks, extraSinks...)
+
+	for _, sink := range sinks {
+		reports = append(reports, Report{Sink: sink.Name(), Err: sink.Send(results)})
+	}
+	return reports
+}
+
+// PrintReports prints whether the results were sent to each sink.
+func PrintReports(w io.Writer, reports []Report) {
+	if len(reports) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nResult sinks:\n")
+	for _, report := range reports {
+		if report.Err != nil {
+			fmt.Fprintf(w, "  %s: FAILED: %v\n", report.Sink, report.Err)
+		} else {
+			fmt.Fprintf(w, "  %s: sent\n", report.Sink)
+		}
+	}
+}
+
+// getFiles returns the files of the results to be stored.
+func (results *Results) getFiles() []string {
+	files := []string{results.ClaimFile}
+	if results.ArtifactsFile != "" {
+		files = append(files, results.ArtifactsFile)
+	}
+	return files
+}
+
+// getRunID returns the name of the folder the results of the run are stored in.
+func (results *Results) getRunID() string {
+	return results.Time.UTC().Format(runIDTimeFormat)
+}
+
+type directorySink struct {
+	name string
+	path string
+}
+
+func newDirectorySink(name string, config *configuration.ResultSink) (Sink, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("the path of the %s sink is not set", name)
+	}
+	return &directorySink{name: name, path: config.Path}, nil
+}
+
+func (sink *directorySink) Name() string {
+	return sink.name
+}
+
+// Send copies the results files to a folder of the directory named after the time of the run.
+func (sink *directorySink) Send(results *Results) error {
+	runDir := filepath.Join(sink.path, results.getRunID())
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create folder %s: %v", runDir, err)
+	}
+
+	for _, file := range results.getFiles() {
+		if err := copyFile(file, filepath.Join(runDir, filepath.Base(file))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %v", srcPath, err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %v", dstPath, err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return fmt.Errorf("failed to copy file %s to %s: %v", srcPath, dstPath, err)
+	}
+	return dst.Close()
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package sinks
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+const (
+	webhookTimeout = 30 * time.Second
+
+	// Headers with the collector's parameters.
+	executedByHeader  = "X-Certsuite-Executed-By"
+	partnerNameHeader = "X-Certsuite-Partner-Name"
+)
+
+// Time waited before the first retry, doubled after every retry.
+var retryBackoff = 2 * time.Second
+
+type webhookSink struct {
+	name    string
+	url     string
+	token   string
+	headers map[string]string
+	retries int
+	client  *http.Client
+}
+
+func newWebhookSink(name string, config *configuration.ResultSink) (Sink, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("the URL of the %s sink is not set", name)
+	}
+	if config.Retries < 0 {
+		return nil, fmt.Errorf("the retries of the %s sink can't be negative", name)
+	}
+
+	headers := map[string]string{}
+	for header, value := range config.Headers {
+		headers[header] = os.ExpandEnv(value)
+	}
+
+	return &webhookSink{
+		name:    name,
+		url:     config.URL,
+		token:   os.ExpandEnv(config.Token),
+		headers: headers,
+		retries: config.Retries,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}, nil
+}
+
+func (sink *webhookSink) Name() string {
+	return sink.name
+}
+
+// Send posts the claim file to the webhook. The request is retried with an exponential backoff when it can't be
+// sent or the webhook returns a server error or asks to slow down.
+func (sink *webhookSink) Send(results *Results) error {
+	claim, err := os.ReadFile(results.ClaimFile)
+	if err != nil {
+		return fmt.Errorf("failed to read claim file %s: %v", results.ClaimFile, err)
+	}
+
+	backoff := retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := sink.post(claim, results)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == sink.retries {
+			return err
+		}
+
+		log.Warn("Failed to send the results to the %s sink, retrying in %v: %v", sink.name, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends the claim file once, returning whether the request can be retried if it failed.
+func (sink *webhookSink) post(claim []byte, results *Results) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, sink.url, bytes.NewReader(claim))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if results.ExecutedBy != "" {
+		req.Header.Set(executedByHeader, results.ExecutedBy)
+	}
+	if results.PartnerName != "" {
+		req.Header.Set(partnerNameHeader, results.PartnerName)
+	}
+	if sink.token != "" {
+		req.Header.Set("Authorization", "Bearer "+sink.token)
+	}
+	for header, value := range sink.headers {
+		req.Header.Set(header, value)
+	}
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		retry = resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return false, nil
+}
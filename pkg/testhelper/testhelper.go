@@ -39,6 +39,14 @@ type ReportObject struct {
 type FailureReasonOut struct {
 	CompliantObjectsOut    []*ReportObject
 	NonCompliantObjectsOut []*ReportObject
+	// Commands run by the check, only set in the claim file.
+	AuditRecords *AuditReference `json:",omitempty"`
+}
+
+// AuditReference points to the records of the commands run by a check in the audit trail file.
+type AuditReference struct {
+	File      string `json:"file"`
+	RecordIDs []int  `json:"recordIDs"`
 }
 
 func Equal(p, other []*ReportObject) bool {
@@ -148,10 +156,15 @@ const (
 	TaintMask                       = "Taint Mask"
 	ModuleName                      = "Module Name"
 	Taints                          = "Taints"
+	DriftProperty                   = "Drift Property"
+	DriftObject                     = "Drift Object"
+	ValueBefore                     = "Value Before"
+	ValueAfter                      = "Value After"
 	SysctlKey                       = "Sysctl Key"
 	SysctlValue                     = "Sysctl Value"
 	OSImage                         = "OS Image"
 	DebugPodName                    = "Debug Pod Name"
+	NotInspected                    = "Not Inspected"
 
 	// ICMP tests
 	NetworkName              = "Network Name"
@@ -212,6 +225,7 @@ const (
 	Error                        = "Error"
 	OperatorPermission           = "Operator Cluster Permission"
 	TaintType                    = "Taint"
+	ClusterStateDriftType        = "Cluster State Drift"
 	ImageDigest                  = "Image Digest"
 	ImageRepo                    = "Image Repo"
 	ImageTag                     = "Image Tag"
@@ -262,6 +276,35 @@ func NewNodeReportObject(aNodeName, aReason string, isCompliant bool) (out *Repo
 	return out
 }
 
+// NewNodeNotInspectedReportObject creates a ReportObject for a node that was not inspected because the debug
+// DaemonSet was not scheduled on it. Its NotInspected field prevents a check from passing with only these objects.
+func NewNodeNotInspectedReportObject(aNodeName string) (out *ReportObject) {
+	out = NewNodeReportObject(aNodeName, "Node not inspected: debug DaemonSet not scheduled on this node", true)
+	out.AddField(NotInspected, "true")
+	return out
+}
+
+// IsNotInspected returns whether the ReportObject is for an object that was not inspected.
+func (obj *ReportObject) IsNotInspected() bool {
+	for i, key := range obj.ObjectFieldsKeys {
+		if key == NotInspected {
+			return obj.ObjectFieldsValues[i] == "true"
+		}
+	}
+	return false
+}
+
+// NewClusterStateDriftReportObject creates a new ReportObject for a property of an object whose value was compared
+// before and after the intrusive test cases.
+func NewClusterStateDriftReportObject(aProperty, anObject, aValueBefore, aValueAfter, aReason string, isCompliant bool) (out *ReportObject) {
+	out = NewReportObject(aReason, ClusterStateDriftType, isCompliant)
+	out.AddField(DriftProperty, aProperty)
+	out.AddField(DriftObject, anObject)
+	out.AddField(ValueBefore, aValueBefore)
+	out.AddField(ValueAfter, aValueAfter)
+	return out
+}
+
 // NewClusterVersionReportObject creates a new ReportObject for a cluster version.
 // It takes the version, aReason, and isCompliant as input parameters and returns the created ReportObject.
 func NewClusterVersionReportObject(version, aReason string, isCompliant bool) (out *ReportObject) {
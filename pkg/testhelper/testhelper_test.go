@@ -133,6 +133,16 @@ func TestNewNodeReportObject(t *testing.T) {
 	}
 }
 
+func TestNewNodeNotInspectedReportObject(t *testing.T) {
+	reportObj := NewNodeNotInspectedReportObject("testNodeName")
+
+	assert.Equal(t, NodeType, reportObj.ObjectType)
+	assert.Equal(t, []string{ReasonForCompliance, Name, NotInspected}, reportObj.ObjectFieldsKeys)
+	assert.Equal(t, []string{"Node not inspected: debug DaemonSet not scheduled on this node", "testNodeName", "true"}, reportObj.ObjectFieldsValues)
+	assert.True(t, reportObj.IsNotInspected())
+	assert.False(t, NewNodeReportObject("testNodeName", "reason", true).IsNotInspected())
+}
+
 func TestNewClusterVersionReportObject(t *testing.T) {
 	testCases := []struct {
 		testVersion     string
@@ -74,6 +74,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSecContextIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainerSCC(c, &env)
@@ -81,6 +82,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSysAdminIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSysAdminCapability(c, &env)
@@ -88,6 +90,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNetAdminIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetAdminCapability(c, &env)
@@ -95,6 +98,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNetRawIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetRawCapability(c, &env)
@@ -102,6 +106,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestIpcLockIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testIpcLockCapability(c, &env)
@@ -109,6 +114,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestBpfIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testBpfCapability(c, &env)
@@ -116,6 +122,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSecConNonRootUserIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSecConRootUser(c, &env)
@@ -123,6 +130,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSecConPrivilegeEscalation)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSecConPrivilegeEscalation(c, &env)
@@ -130,6 +138,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainerHostPort)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainerHostPort(c, &env)
@@ -137,6 +146,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHostNetwork)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodHostNetwork(c, &env)
@@ -144,6 +154,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHostPath)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodHostPath(c, &env)
@@ -151,6 +162,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHostIPC)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodHostIPC(c, &env)
@@ -158,6 +170,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHostPID)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodHostPID(c, &env)
@@ -165,6 +178,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNamespaceBestPracticesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListCrds).
 		WithSkipCheckFn(testhelper.GetNoNamespacesSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNamespace(c, &env)
@@ -172,6 +186,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodServiceAccountBestPracticesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodServiceAccount(c, &env)
@@ -179,6 +194,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodRoleBindingsBestPracticesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListAllRoleBindings).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodRoleBindings(c, &env)
@@ -186,6 +202,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodClusterRoleBindingsBestPracticesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListClusterRoleBindings, common.PermissionListAllCsvs).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodClusterRoleBindings(c, &env)
@@ -193,6 +210,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodAutomountServiceAccountIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionGetServiceAccounts).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testAutomountServiceToken(c, &env)
@@ -200,6 +218,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOneProcessPerContainerIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOneProcessPerContainer(c, &env)
@@ -207,6 +226,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSYSNiceRealtimeCapabilityIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListNodes).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSYSNiceRealtimeCapability(c, &env)
@@ -214,6 +234,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSysPtraceCapabilityIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetSharedProcessNamespacePodsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSysPtraceCapability(c, &env)
@@ -221,6 +242,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNamespaceResourceQuotaIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListAllResourceQuotas).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNamespaceResourceQuota(c, &env)
@@ -228,6 +250,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNoSSHDaemonsAllowedIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNoSSHDaemonsAllowed(c, &env)
@@ -235,6 +258,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodRequestsAndLimitsIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodRequestsAndLimits(c, &env)
@@ -242,6 +266,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.Test1337UIDIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			test1337UIDs(c, &env)
@@ -249,6 +274,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestServicesDoNotUseNodeportsIdentifier)).
+		WithRequiredPermissions(common.PermissionListServices).
 		WithSkipCheckFn(testhelper.GetNoServicesUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNodePort(c, &env)
@@ -256,6 +282,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestCrdRoleIdentifier)).
+		WithRequiredPermissions(common.PermissionListCrds, common.PermissionListAllRoles).
 		WithSkipCheckFn(testhelper.GetNoCrdsUnderTestSkipFn(&env), testhelper.GetNoNamespacesSkipFn(&env), testhelper.GetNoRolesSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testCrdRoles(c, &env)
@@ -737,11 +764,16 @@ func testOneProcessPerContainer(check *checksdb.Check, env *provider.TestEnviron
 			continue
 		}
 		debugPod := env.DebugPods[cut.NodeName]
+		if debugPod == nil && env.DebugPodsNodeScoped {
+			check.LogWarn("Container %q not inspected: the debug DaemonSet is not scheduled on node %q", cut, cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 		if debugPod == nil {
 			check.LogError("Debug pod not found for node %q", cut.NodeName)
 			return
 		}
-		ocpContext := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name)
+		ocpContext := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name).WithCheckID(check.ID)
 		pid, err := crclient.GetPidFromContainer(cut, ocpContext)
 		if err != nil {
 			check.LogError("Could not get PID for Container %q, error: %v", cut, err)
@@ -872,9 +904,14 @@ func testNoSSHDaemonsAllowed(check *checksdb.Check, env *provider.TestEnvironmen
 	for _, put := range env.Pods {
 		check.LogInfo("Testing Pod %q", put)
 		cut := put.Containers[0]
+		if env.IsNodeNotInspected(cut.NodeName) {
+			check.LogWarn("Pod %q not inspected: the debug DaemonSet is not scheduled on node %q", put, cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 
 		// 1. Find SSH port
-		port, err := netutil.GetSSHDaemonPort(cut)
+		port, err := netutil.GetSSHDaemonPort(cut, check.ID)
 		if err != nil {
 			check.LogError("Could not get ssh daemon port on %q, err: %v", cut, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewPodReportObject(put.Namespace, put.Name, "Failed to get the ssh port for pod", false))
@@ -896,7 +933,7 @@ func testNoSSHDaemonsAllowed(check *checksdb.Check, env *provider.TestEnvironmen
 
 		// 2. Check if SSH port is listening
 		sshPortInfo := netutil.PortInfo{PortNumber: int32(sshServicePortNumber), Protocol: sshServicePortProtocol}
-		listeningPorts, err := netutil.GetListeningPorts(cut)
+		listeningPorts, err := netutil.GetListeningPorts(cut, check.ID)
 		if err != nil {
 			check.LogError("Failed to get the listening ports for Pod %q, err: %v", put, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewPodReportObject(put.Namespace, put.Name, "Failed to get the listening ports for pod", false))
@@ -80,6 +80,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestHelmVersionIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllPods, common.PermissionListHelmReleases).
 		WithSkipCheckFn(skipIfNoHelmChartReleasesFn).
 		WithCheckFn(func(check *checksdb.Check) error {
 			testHelmVersion(check)
@@ -87,6 +88,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorIsCertifiedIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionGetClusterOperators).
 		WithSkipCheckFn(skipIfNoOperatorsFn).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testAllOperatorCertified(c, &env, validator)
@@ -94,6 +96,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestHelmIsCertifiedIdentifier)).
+		WithRequiredPermissions(common.PermissionListHelmReleases).
 		WithSkipCheckFn(skipIfNoHelmChartReleasesFn).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testHelmCertified(c, &env, validator)
@@ -101,6 +104,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainerIsCertifiedDigestIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainerCertificationStatusByDigest(c, &env, validator)
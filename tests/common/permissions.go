@@ -0,0 +1,74 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package common
+
+import "github.com/redhat-best-practices-for-k8s/certsuite/internal/permissions"
+
+// API permissions shared by the checks of multiple test suite packages. The objects discovered before
+// running the checks are declared too, as the checks can't inspect them without these permissions.
+var (
+	// Running commands in the debug pods, needed to inspect the nodes.
+	PermissionExecInDebugPods = permissions.Permission{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: permissions.DebugNamespace}
+	// Running commands in the containers under test.
+	PermissionExecInTargetPods   = permissions.Permission{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: permissions.TargetNamespaces}
+	PermissionGetPodLogs         = permissions.Permission{Verb: "get", Resource: "pods", Subresource: "log", Namespace: permissions.TargetNamespaces}
+	PermissionListPods           = permissions.Permission{Verb: "list", Resource: "pods", Namespace: permissions.TargetNamespaces}
+	PermissionListAllPods        = permissions.Permission{Verb: "list", Resource: "pods"}
+	PermissionListNodes          = permissions.Permission{Verb: "list", Resource: "nodes"}
+	PermissionGetNodes           = permissions.Permission{Verb: "get", Resource: "nodes"}
+	PermissionUpdateNodes        = permissions.Permission{Verb: "update", Resource: "nodes"}
+	PermissionDeletePods         = permissions.Permission{Verb: "delete", Resource: "pods", Namespace: permissions.TargetNamespaces}
+	PermissionListServices       = permissions.Permission{Verb: "list", Resource: "services", Namespace: permissions.TargetNamespaces}
+	PermissionGetServiceAccounts = permissions.Permission{Verb: "get", Resource: "serviceaccounts", Namespace: permissions.TargetNamespaces}
+	// Operator pods can run out of the target namespaces.
+	PermissionGetAllServiceAccounts = permissions.Permission{Verb: "get", Resource: "serviceaccounts"}
+	// Helm stores the releases as secrets in their namespace.
+	PermissionListHelmReleases                = permissions.Permission{Verb: "list", Resource: "secrets", Namespace: permissions.TargetNamespaces}
+	PermissionListAllResourceQuotas           = permissions.Permission{Verb: "list", Resource: "resourcequotas"}
+	PermissionListPersistentVolumes           = permissions.Permission{Verb: "list", Resource: "persistentvolumes"}
+	PermissionListAllPersistentVolumeClaims   = permissions.Permission{Verb: "list", Resource: "persistentvolumeclaims"}
+	PermissionListStorageClasses              = permissions.Permission{Verb: "list", Group: "storage.k8s.io", Resource: "storageclasses"}
+	PermissionListAllNetworkPolicies          = permissions.Permission{Verb: "list", Group: "networking.k8s.io", Resource: "networkpolicies"}
+	PermissionGetNetworkAttachmentDefinitions = permissions.Permission{Verb: "get", Group: "k8s.cni.cncf.io", Resource: "network-attachment-definitions",
+		Namespace: permissions.TargetNamespaces}
+	PermissionListPodDisruptionBudgets = permissions.Permission{Verb: "list", Group: "policy", Resource: "poddisruptionbudgets", Namespace: permissions.TargetNamespaces}
+	PermissionListAllRoles             = permissions.Permission{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "roles"}
+	PermissionListAllRoleBindings      = permissions.Permission{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}
+	PermissionListClusterRoleBindings  = permissions.Permission{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"}
+	PermissionListCrds                 = permissions.Permission{Verb: "list", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}
+	PermissionListDeployments          = permissions.Permission{Verb: "list", Group: "apps", Resource: "deployments", Namespace: permissions.TargetNamespaces}
+	PermissionListStatefulSets         = permissions.Permission{Verb: "list", Group: "apps", Resource: "statefulsets", Namespace: permissions.TargetNamespaces}
+	PermissionGetDeployments           = permissions.Permission{Verb: "get", Group: "apps", Resource: "deployments", Namespace: permissions.TargetNamespaces}
+	PermissionGetStatefulSets          = permissions.Permission{Verb: "get", Group: "apps", Resource: "statefulsets", Namespace: permissions.TargetNamespaces}
+	PermissionUpdateDeployments        = permissions.Permission{Verb: "update", Group: "apps", Resource: "deployments", Namespace: permissions.TargetNamespaces}
+	PermissionUpdateStatefulSets       = permissions.Permission{Verb: "update", Group: "apps", Resource: "statefulsets", Namespace: permissions.TargetNamespaces}
+	PermissionGetHpas                  = permissions.Permission{Verb: "get", Group: "autoscaling", Resource: "horizontalpodautoscalers", Namespace: permissions.TargetNamespaces}
+	PermissionUpdateHpas               = permissions.Permission{Verb: "update", Group: "autoscaling", Resource: "horizontalpodautoscalers", Namespace: permissions.TargetNamespaces}
+	// Scaling the custom resources under test through their scale subresource.
+	PermissionGetCrScale    = permissions.Permission{Verb: "get", Resource: permissions.ScalableCustomResources, Subresource: "scale", Namespace: permissions.TargetNamespaces}
+	PermissionUpdateCrScale = permissions.Permission{Verb: "update", Resource: permissions.ScalableCustomResources, Subresource: "scale", Namespace: permissions.TargetNamespaces}
+	// Operators discovered in the cluster, with their subscriptions and install plans.
+	PermissionListAllCsvs          = permissions.Permission{Verb: "list", Group: "operators.coreos.com", Resource: "clusterserviceversions"}
+	PermissionGetAllCsvs           = permissions.Permission{Verb: "get", Group: "operators.coreos.com", Resource: "clusterserviceversions"}
+	PermissionListAllSubscriptions = permissions.Permission{Verb: "list", Group: "operators.coreos.com", Resource: "subscriptions"}
+	PermissionListAllInstallPlans  = permissions.Permission{Verb: "list", Group: "operators.coreos.com", Resource: "installplans"}
+	// The OpenShift version is read from the openshift-apiserver cluster operator.
+	PermissionGetClusterOperators = permissions.Permission{Verb: "get", Group: "config.openshift.io", Resource: "clusteroperators"}
+	PermissionGetMachineConfigs   = permissions.Permission{Verb: "get", Group: "machineconfiguration.openshift.io", Resource: "machineconfigs"}
+	// The Istio service mesh is detected by its control plane deployment.
+	PermissionGetIstioDeployment = permissions.Permission{Verb: "get", Group: "apps", Resource: "deployments", Namespace: "istio-system"}
+)
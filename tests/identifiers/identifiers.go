@@ -135,6 +135,7 @@ var (
 	TestPodDeploymentBestPracticesIdentifier          claim.Identifier
 	TestDeploymentScalingIdentifier                   claim.Identifier
 	TestStateFulSetScalingIdentifier                  claim.Identifier
+	TestClusterStateDriftIdentifier                   claim.Identifier
 	TestImagePullPolicyIdentifier                     claim.Identifier
 	TestPodRecreationIdentifier                       claim.Identifier
 	TestPodRoleBindingsBestPracticesIdentifier        claim.Identifier
@@ -1142,6 +1143,22 @@ that Node's kernel may not have the same hacks.'`,
 		},
 		TagCommon)
 
+	TestClusterStateDriftIdentifier = AddCatalogEntry(
+		"cluster-state-drift",
+		common.LifecycleTestKey,
+		`Tests that the workload and the cluster come back to the same state after the intrusive lifecycle test cases (pod recreation and deployment/statefulset/CRD scaling). A snapshot is taken before the first intrusive test case and compared with a new one taken after the last: replicas of the deployments, statefulsets and scalable CRs, number of ready replicas and pods of the deployments and statefulsets, image digests of the containers, min/max replicas of the HPAs and schedulability of the nodes. Any difference is reported as a drift.`, //nolint:lll
+		ClusterStateDriftRemediation,
+		NoDocumentedProcess+NotApplicableSNO,
+		TestClusterStateDriftIdentifierDocLink,
+		true,
+		map[string]string{
+			FarEdge:  Optional,
+			Telco:    Optional,
+			NonTelco: Optional,
+			Extended: Optional,
+		},
+		TagCommon)
+
 	TestImagePullPolicyIdentifier = AddCatalogEntry(
 		"image-pull-policy",
 		common.LifecycleTestKey,
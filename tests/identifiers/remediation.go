@@ -37,6 +37,8 @@ const (
 
 	StatefulSetScalingRemediation = `Ensure the workload's statefulsets/replica sets can scale in/out successfully.`
 
+	ClusterStateDriftRemediation = `Ensure the workload recovers its original state after its pods are deleted or rescheduled and after being scaled in/out: same number of replicas and ready pods, and same images. Check for nodes left cordoned and HPAs or replicas left with different values (certsuite cleanup can revert them).` //nolint:lll
+
 	SecConCapabilitiesRemediation = `Remove the following capabilities from the container/pod definitions: NET_ADMIN SCC, SYS_ADMIN SCC, NET_RAW SCC, IPC_LOCK SCC`
 
 	BpfCapabilityRemediation = `Remove the following capability from the container/pod definitions: BPF`
@@ -0,0 +1,227 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+// Package drift takes snapshots of the state of the workload under test and the cluster nodes, so
+// the state before the intrusive lifecycle test cases can be compared with the state after them.
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/scale"
+)
+
+const (
+	PropertyReplicas        = "replicas"
+	PropertyReadyReplicas   = "readyReplicas"
+	PropertyPods            = "pods"
+	PropertyImageDigests    = "imageDigests"
+	PropertyHpaMinMax       = "hpaMinMax"
+	PropertyNodeSchedulable = "nodeSchedulable"
+
+	// Value of the properties that can't be found in one of the snapshots.
+	ValueMissing = "<missing>"
+)
+
+// Snapshot holds the value of each property for each object, e.g. snapshot["replicas"]["Deployment ns1/dp1"] = "3".
+type Snapshot map[string]map[string]string
+
+// Drift is a property of an object whose value differs between two snapshots.
+type Drift struct {
+	Property string
+	Object   string
+	Before   string
+	After    string
+}
+
+func (s Snapshot) set(property, object, value string) {
+	if s[property] == nil {
+		s[property] = map[string]string{}
+	}
+	s[property][object] = value
+}
+
+// TakeSnapshot gets the current state of the pod sets, scalable CRs and HPAs under test and the schedulability
+// of the cluster nodes.
+func TakeSnapshot(client kubernetes.Interface, scalingClient scale.ScalesGetter, env *provider.TestEnvironment) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	for _, dp := range env.Deployments {
+		deployment, err := client.AppsV1().Deployments(dp.Namespace).Get(context.TODO(), dp.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s/%s: %v", dp.Namespace, dp.Name, err)
+		}
+		object := fmt.Sprintf("Deployment %s/%s", dp.Namespace, dp.Name)
+		snapshot.set(PropertyReplicas, object, int32PtrToString(deployment.Spec.Replicas))
+		snapshot.set(PropertyReadyReplicas, object, fmt.Sprint(deployment.Status.ReadyReplicas))
+		if err := addPodsState(client, snapshot, object, deployment.Namespace, deployment.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sts := range env.StatefulSets {
+		statefulSet, err := client.AppsV1().StatefulSets(sts.Namespace).Get(context.TODO(), sts.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset %s/%s: %v", sts.Namespace, sts.Name, err)
+		}
+		object := fmt.Sprintf("StatefulSet %s/%s", sts.Namespace, sts.Name)
+		snapshot.set(PropertyReplicas, object, int32PtrToString(statefulSet.Spec.Replicas))
+		snapshot.set(PropertyReadyReplicas, object, fmt.Sprint(statefulSet.Status.ReadyReplicas))
+		if err := addPodsState(client, snapshot, object, statefulSet.Namespace, statefulSet.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range env.ScaleCrUnderTest {
+		cr := &env.ScaleCrUnderTest[i]
+		crScale, err := scalingClient.Scales(cr.Scale.Namespace).Get(context.TODO(), cr.GroupResourceSchema, cr.Scale.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the scale of %s %s/%s: %v", cr.GroupResourceSchema.String(), cr.Scale.Namespace, cr.Scale.Name, err)
+		}
+		object := fmt.Sprintf("%s %s/%s", cr.GroupResourceSchema.String(), cr.Scale.Namespace, cr.Scale.Name)
+		snapshot.set(PropertyReplicas, object, fmt.Sprint(crScale.Spec.Replicas))
+	}
+
+	for _, h := range env.HorizontalScaler {
+		hpa, err := client.AutoscalingV1().HorizontalPodAutoscalers(h.Namespace).Get(context.TODO(), h.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hpa %s/%s: %v", h.Namespace, h.Name, err)
+		}
+		snapshot.set(PropertyHpaMinMax, fmt.Sprintf("HorizontalPodAutoscaler %s/%s", h.Namespace, h.Name),
+			fmt.Sprintf("min=%s max=%d", int32PtrToString(hpa.Spec.MinReplicas), hpa.Spec.MaxReplicas))
+	}
+
+	for nodeName := range env.Nodes {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %v", nodeName, err)
+		}
+		snapshot.set(PropertyNodeSchedulable, "Node "+nodeName, fmt.Sprint(!node.Spec.Unschedulable))
+	}
+
+	return snapshot, nil
+}
+
+// addPodsState adds the number of pods and the image digests of the pods of a pod set to the snapshot. The
+// nodes of the pods are not part of the state, as the intrusive test cases reschedule them.
+func addPodsState(client kubernetes.Interface, snapshot Snapshot, podSet, namespace string, selector *metav1.LabelSelector) error {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector for %s: %v", podSet, err)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list the pods of %s: %v", podSet, err)
+	}
+
+	podsCount := 0
+	containerDigests := map[string]map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// Pods being deleted are leftovers of the intrusive tests, not part of the pod set's state.
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podsCount++
+		for _, status := range pod.Status.ContainerStatuses {
+			if containerDigests[status.Name] == nil {
+				containerDigests[status.Name] = map[string]bool{}
+			}
+			containerDigests[status.Name][status.ImageID] = true
+		}
+	}
+
+	snapshot.set(PropertyPods, podSet, fmt.Sprint(podsCount))
+
+	for containerName, digests := range containerDigests {
+		snapshot.set(PropertyImageDigests, fmt.Sprintf("%s container %s", podSet, containerName), joinSorted(digests))
+	}
+
+	return nil
+}
+
+// Compare returns the drifts between two snapshots, sorted by property and object, and the number of
+// property values that are the same in both.
+func Compare(before, after Snapshot) (drifts []Drift, unchanged int) {
+	drifts = []Drift{}
+	for _, property := range getSortedKeys(before, after) {
+		beforeValues, afterValues := before[property], after[property]
+		objects := map[string]bool{}
+		for object := range beforeValues {
+			objects[object] = true
+		}
+		for object := range afterValues {
+			objects[object] = true
+		}
+
+		for _, object := range sortedKeys(objects) {
+			beforeValue, found := beforeValues[object]
+			if !found {
+				beforeValue = ValueMissing
+			}
+			afterValue, found := afterValues[object]
+			if !found {
+				afterValue = ValueMissing
+			}
+
+			if beforeValue == afterValue {
+				unchanged++
+				continue
+			}
+			drifts = append(drifts, Drift{Property: property, Object: object, Before: beforeValue, After: afterValue})
+		}
+	}
+
+	return drifts, unchanged
+}
+
+func getSortedKeys(snapshots ...Snapshot) []string {
+	keys := map[string]bool{}
+	for _, snapshot := range snapshots {
+		for key := range snapshot {
+			keys[key] = true
+		}
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinSorted(set map[string]bool) string {
+	return strings.Join(sortedKeys(set), ",")
+}
+
+func int32PtrToString(value *int32) string {
+	if value == nil {
+		return "<unset>"
+	}
+	return fmt.Sprint(*value)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024 Red Hat, Inc.
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation; either version 2 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License along
+// with this program; if not, write to the Free Software Foundation, Inc.,
+// 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+
+package drift
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTakeSnapshot(t *testing.T) {
+	replicas := int32(2)
+	minReplicas := int32(1)
+	labels := map[string]string{"app": "dp1"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dp1", Namespace: "ns1"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+	generatePod := func(name, nodeName, imageID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1", Labels: labels},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "c1", ImageID: imageID}},
+			},
+		}
+	}
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "hpa1", Namespace: "ns1"},
+		Spec:       autoscalingv1.HorizontalPodAutoscalerSpec{MinReplicas: &minReplicas, MaxReplicas: 3},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Spec: corev1.NodeSpec{Unschedulable: true}}
+
+	client := k8sfake.NewSimpleClientset(deployment, hpa, node,
+		generatePod("pod1", "node1", "quay.io/img@sha256:1"),
+		generatePod("pod2", "node1", "quay.io/img@sha256:2"))
+	env := provider.TestEnvironment{
+		Deployments:      []*provider.Deployment{{Deployment: deployment}},
+		HorizontalScaler: []*autoscalingv1.HorizontalPodAutoscaler{hpa},
+		Nodes:            map[string]provider.Node{"node1": {Data: node}},
+	}
+
+	snapshot, err := TakeSnapshot(client, nil, &env)
+	assert.Nil(t, err)
+	assert.Equal(t, Snapshot{
+		PropertyReplicas:        {"Deployment ns1/dp1": "2"},
+		PropertyReadyReplicas:   {"Deployment ns1/dp1": "2"},
+		PropertyPods:            {"Deployment ns1/dp1": "2"},
+		PropertyImageDigests:    {"Deployment ns1/dp1 container c1": "quay.io/img@sha256:1,quay.io/img@sha256:2"},
+		PropertyHpaMinMax:       {"HorizontalPodAutoscaler ns1/hpa1": "min=1 max=3"},
+		PropertyNodeSchedulable: {"Node node1": "false"},
+	}, snapshot)
+}
+
+func TestCompare(t *testing.T) {
+	before := Snapshot{
+		PropertyReplicas:        {"Deployment ns1/dp1": "2"},
+		PropertyReadyReplicas:   {"Deployment ns1/dp1": "2"},
+		PropertyPods:            {"Deployment ns1/dp1": "2"},
+		PropertyNodeSchedulable: {"Node node1": "true", "Node node2": "true"},
+	}
+	after := Snapshot{
+		PropertyReplicas:        {"Deployment ns1/dp1": "2"},
+		PropertyReadyReplicas:   {"Deployment ns1/dp1": "1"},
+		PropertyPods:            {"Deployment ns1/dp1": "2"},
+		PropertyNodeSchedulable: {"Node node1": "false", "Node node2": "true"},
+		PropertyHpaMinMax:       {"HorizontalPodAutoscaler ns1/hpa1": "min=1 max=3"},
+	}
+
+	drifts, unchanged := Compare(before, after)
+	assert.Equal(t, 3, unchanged)
+	assert.Equal(t, []Drift{
+		{Property: PropertyHpaMinMax, Object: "HorizontalPodAutoscaler ns1/hpa1", Before: ValueMissing, After: "min=1 max=3"},
+		{Property: PropertyNodeSchedulable, Object: "Node node1", Before: "true", After: "false"},
+		{Property: PropertyReadyReplicas, Object: "Deployment ns1/dp1", Before: "2", After: "1"},
+	}, drifts)
+
+	drifts, unchanged = Compare(before, before)
+	assert.Empty(t, drifts)
+	assert.Equal(t, 5, unchanged)
+}
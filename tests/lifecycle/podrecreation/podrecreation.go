@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
@@ -45,10 +46,16 @@ const (
 	NoDelete                    = "noDelete"
 )
 
+// Journal IDs of the nodes cordoned by CordonHelper that have not been uncordoned yet.
+var cordonedNodes = map[string]int{}
+
 func CordonHelper(name, operation string) error {
 	clients := clientsholder.GetClientsHolder()
 
 	log.Info("Performing %s operation on node %s", operation, name)
+	if _, alreadyCordoned := cordonedNodes[name]; operation == Cordon && !alreadyCordoned {
+		cordonedNodes[name] = journal.RecordNodeCordoned(name)
+	}
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Fetch node object
 		node, err := clients.K8sClient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
@@ -69,8 +76,14 @@ func CordonHelper(name, operation string) error {
 	})
 	if retryErr != nil {
 		log.Error("can not %s node: %s, err=%v", operation, name, retryErr)
+		return retryErr
 	}
-	return retryErr
+
+	if id, cordoned := cordonedNodes[name]; operation == Uncordon && cordoned {
+		journal.MarkReverted(id)
+		delete(cordonedNodes, name)
+	}
+	return nil
 }
 
 func CountPodsWithDelete(pods []*provider.Pod, nodeName, mode string) (count int, err error) {
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/podsets"
@@ -46,6 +47,7 @@ func TestScaleCrd(crScale *provider.CrScale, groupResourceSchema schema.GroupRes
 	name := crScale.GetName()
 	namespace := crScale.GetNamespace()
 
+	sideEffectID := journal.RecordReplicasChanged(groupResourceSchema.String(), groupResourceSchema.Group, groupResourceSchema.Resource, namespace, name, replicas)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -73,6 +75,7 @@ func TestScaleCrd(crScale *provider.CrScale, groupResourceSchema schema.GroupRes
 		}
 	}
 
+	journal.MarkReverted(sideEffectID)
 	return true
 }
 
@@ -126,6 +129,7 @@ func TestScaleHPACrd(cr *provider.CrScale, hpa *scalingv1.HorizontalPodAutoscale
 	replicas := cr.Spec.Replicas
 	name := cr.GetName()
 
+	sideEffectID := journal.RecordHpaChanged(namespace, hpa.Name, hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -159,7 +163,11 @@ func TestScaleHPACrd(cr *provider.CrScale, hpa *scalingv1.HorizontalPodAutoscale
 	}
 	// back the min and the max value of the hpa
 	logger.Debug("Back HPA %s:%s to min=%d max=%d", namespace, hpa.Name, min, hpa.Spec.MaxReplicas)
-	return scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, min, hpa.Spec.MaxReplicas, timeout, groupResourceSchema, logger)
+	if !scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, min, hpa.Spec.MaxReplicas, timeout, groupResourceSchema, logger) {
+		return false
+	}
+	journal.MarkReverted(sideEffectID)
+	return true
 }
 
 func scaleHpaCRDHelper(hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, crName, namespace string, min, max int32, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
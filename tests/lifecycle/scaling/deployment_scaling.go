@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/podsets"
@@ -47,6 +48,7 @@ func TestScaleDeployment(deployment *appsv1.Deployment, timeout time.Duration, l
 		replicas = 1
 	}
 
+	sideEffectID := journal.RecordReplicasChanged("Deployment", "", "", deployment.Namespace, deployment.Name, replicas)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -73,6 +75,7 @@ func TestScaleDeployment(deployment *appsv1.Deployment, timeout time.Duration, l
 			return false
 		}
 	}
+	journal.MarkReverted(sideEffectID)
 	return true
 }
 
@@ -124,6 +127,7 @@ func TestScaleHpaDeployment(deployment *provider.Deployment, hpa *v1autoscaling.
 		replicas = *deployment.Spec.Replicas
 	}
 	max := hpa.Spec.MaxReplicas
+	sideEffectID := journal.RecordHpaChanged(hpa.Namespace, hpa.Name, hpa.Spec.MinReplicas, max)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -157,7 +161,11 @@ func TestScaleHpaDeployment(deployment *provider.Deployment, hpa *v1autoscaling.
 	}
 	// back the min and the max value of the hpa
 	logger.Debug("Back HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, min, max)
-	return scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, min, max, timeout, logger)
+	if !scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, min, max, timeout, logger) {
+		return false
+	}
+	journal.MarkReverted(sideEffectID)
+	return true
 }
 
 func scaleHpaDeploymentHelper(hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, deploymentName, namespace string, min, max int32, timeout time.Duration, logger *log.Logger) bool {
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/journal"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/podsets"
 
@@ -46,6 +47,7 @@ func TestScaleStatefulSet(statefulset *appsv1.StatefulSet, timeout time.Duration
 		replicas = *statefulset.Spec.Replicas
 	}
 
+	sideEffectID := journal.RecordReplicasChanged("StatefulSet", "", "", namespace, name, replicas)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -76,6 +78,7 @@ func TestScaleStatefulSet(statefulset *appsv1.StatefulSet, timeout time.Duration
 			return false
 		}
 	}
+	journal.MarkReverted(sideEffectID)
 	return true
 }
 
@@ -124,6 +127,7 @@ func TestScaleHpaStatefulSet(statefulset *appsv1.StatefulSet, hpa *v1autoscaling
 		replicas = *statefulset.Spec.Replicas
 	}
 	max := hpa.Spec.MaxReplicas
+	sideEffectID := journal.RecordHpaChanged(namespace, hpaName, hpa.Spec.MinReplicas, max)
 	if replicas <= 1 {
 		// scale up
 		replicas++
@@ -158,6 +162,9 @@ func TestScaleHpaStatefulSet(statefulset *appsv1.StatefulSet, hpa *v1autoscaling
 	// back the min and the max value of the hpa
 	logger.Debug("Back HPA %s:%s to min=%d max=%d", namespace, hpaName, min, max)
 	pass := scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, min, max, timeout, logger)
+	if pass {
+		journal.MarkReverted(sideEffectID)
+	}
 	return pass
 }
 
@@ -17,8 +17,10 @@
 package lifecycle
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
@@ -27,6 +29,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/common"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/drift"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/ownerreference"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/podrecreation"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/lifecycle/podsets"
@@ -49,6 +52,26 @@ const (
 var (
 	env provider.TestEnvironment
 
+	// State of the workload and the nodes before the intrusive test cases, nil if it was not taken.
+	stateBeforeIntrusiveTests drift.Snapshot
+
+	beforeAllFn = func(checks []*checksdb.Check) error {
+		env = provider.GetTestEnvironment()
+		stateBeforeIntrusiveTests = nil
+		if !env.IsIntrusive() || !hasIntrusiveChecks(checks) {
+			return nil
+		}
+
+		clients := clientsholder.GetClientsHolder()
+		snapshot, err := drift.TakeSnapshot(clients.K8sClient, clients.ScalingClient, &env)
+		if err != nil {
+			log.Error("Failed to take the cluster state snapshot before the intrusive test cases: %v", err)
+			return nil
+		}
+		stateBeforeIntrusiveTests = snapshot
+		return nil
+	}
+
 	beforeEachFn = func(check *checksdb.Check) error {
 		env = provider.GetTestEnvironment()
 		return nil
@@ -61,17 +84,42 @@ var (
 		}
 		return false, ""
 	}
+
+	skipIfNoStateSnapshot = func() (bool, string) {
+		if stateBeforeIntrusiveTests == nil {
+			return true, "no cluster state snapshot was taken before the intrusive test cases"
+		}
+		return false, ""
+	}
 )
 
+// hasIntrusiveChecks returns true if any of the checks to run changes the state of the workload or the nodes.
+func hasIntrusiveChecks(checks []*checksdb.Check) bool {
+	intrusiveChecks := map[string]bool{
+		identifiers.TestCrdScalingIdentifier.Id:         true,
+		identifiers.TestPodRecreationIdentifier.Id:      true,
+		identifiers.TestDeploymentScalingIdentifier.Id:  true,
+		identifiers.TestStateFulSetScalingIdentifier.Id: true,
+	}
+	for _, check := range checks {
+		if intrusiveChecks[check.ID] {
+			return true
+		}
+	}
+	return false
+}
+
 //nolint:funlen
 func LoadChecks() {
 	log.Debug("Loading %s suite checks", common.LifecycleTestKey)
 
 	checksGroup := checksdb.NewChecksGroup(common.LifecycleTestKey).
+		WithBeforeAllFn(beforeAllFn).
 		WithBeforeEachFn(beforeEachFn)
 
 	// Prestop test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainerPrestopIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersPreStop(c, &env)
@@ -80,6 +128,7 @@ func LoadChecks() {
 
 	// Scale CRD test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestCrdScalingIdentifier)).
+		WithRequiredPermissions(common.PermissionGetCrScale, common.PermissionUpdateCrScale, common.PermissionGetHpas, common.PermissionUpdateHpas).
 		WithSkipCheckFn(
 			testhelper.GetNoCrdsUnderTestSkipFn(&env),
 			testhelper.GetNotIntrusiveSkipFn(&env)).
@@ -92,6 +141,7 @@ func LoadChecks() {
 
 	// Poststart test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainerPostStartIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersPostStart(c, &env)
@@ -100,6 +150,7 @@ func LoadChecks() {
 
 	// Image pull policy test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestImagePullPolicyIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersImagePolicy(c, &env)
@@ -108,6 +159,7 @@ func LoadChecks() {
 
 	// Readiness probe test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestReadinessProbeIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersReadinessProbe(c, &env)
@@ -116,6 +168,7 @@ func LoadChecks() {
 
 	// Liveness probe test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestLivenessProbeIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersLivenessProbe(c, &env)
@@ -124,6 +177,7 @@ func LoadChecks() {
 
 	// Startup probe test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestStartupProbeIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersStartupProbe(c, &env)
@@ -132,6 +186,7 @@ func LoadChecks() {
 
 	// Pod owner reference test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodDeploymentBestPracticesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodsOwnerReference(c, &env)
@@ -140,6 +195,7 @@ func LoadChecks() {
 
 	// High availability test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHighAvailabilityBestPractices)).
+		WithRequiredPermissions(common.PermissionListDeployments, common.PermissionListStatefulSets).
 		WithSkipCheckFn(testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle)).
 		WithSkipCheckFn(skipIfNoPodSetsetsUnderTest).
 		WithCheckFn(func(c *checksdb.Check) error {
@@ -149,6 +205,7 @@ func LoadChecks() {
 
 	// Selector and affinity best practices test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodNodeSelectorAndAffinityBestPractices)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle),
 			testhelper.GetPodsWithoutAffinityRequiredLabelSkipFn(&env)).
@@ -159,6 +216,8 @@ func LoadChecks() {
 
 	// Pod recreation test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodRecreationIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllPods, common.PermissionDeletePods, common.PermissionGetNodes, common.PermissionUpdateNodes,
+			common.PermissionGetDeployments, common.PermissionGetStatefulSets).
 		WithSkipCheckFn(
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle),
 			testhelper.GetNotIntrusiveSkipFn(&env)).
@@ -170,6 +229,8 @@ func LoadChecks() {
 
 	// Deployment scaling test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestDeploymentScalingIdentifier)).
+		WithRequiredPermissions(common.PermissionGetDeployments, common.PermissionUpdateDeployments, common.PermissionGetHpas,
+			common.PermissionUpdateHpas).
 		WithSkipCheckFn(
 			testhelper.GetNotIntrusiveSkipFn(&env),
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle)).
@@ -181,6 +242,8 @@ func LoadChecks() {
 
 	// Statefulset scaling test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestStateFulSetScalingIdentifier)).
+		WithRequiredPermissions(common.PermissionGetStatefulSets, common.PermissionUpdateStatefulSets, common.PermissionGetHpas,
+			common.PermissionUpdateHpas).
 		WithSkipCheckFn(
 			testhelper.GetNotIntrusiveSkipFn(&env),
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle)).
@@ -190,8 +253,21 @@ func LoadChecks() {
 			return nil
 		}))
 
+	// Cluster state drift test. It must run after all the intrusive test cases.
+	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestClusterStateDriftIdentifier)).
+		WithRequiredPermissions(common.PermissionGetNodes, common.PermissionListAllPods, common.PermissionGetDeployments,
+			common.PermissionGetStatefulSets, common.PermissionGetHpas, common.PermissionGetCrScale).
+		WithSkipCheckFn(
+			testhelper.GetNotIntrusiveSkipFn(&env),
+			skipIfNoStateSnapshot).
+		WithCheckFn(func(c *checksdb.Check) error {
+			testClusterStateDrift(c, &env)
+			return nil
+		}))
+
 	// Persistent volume reclaim policy test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPersistentVolumeReclaimPolicyIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListPersistentVolumes, common.PermissionListAllPersistentVolumeClaims).
 		WithSkipCheckFn(
 			testhelper.GetNoPersistentVolumesSkipFn(&env),
 			testhelper.GetNoPodsUnderTestSkipFn(&env)).
@@ -202,6 +278,7 @@ func LoadChecks() {
 
 	// CPU Isolation test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestCPUIsolationIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoGuaranteedPodsWithExclusiveCPUsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testCPUIsolation(c, &env)
@@ -210,6 +287,7 @@ func LoadChecks() {
 
 	// Affinity required pods test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestAffinityRequiredPods)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoAffinityRequiredPodsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testAffinityRequiredPods(c, &env)
@@ -218,6 +296,7 @@ func LoadChecks() {
 
 	// Pod toleration bypass test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodTolerationBypassIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodTolerationBypass(c, &env)
@@ -226,6 +305,7 @@ func LoadChecks() {
 
 	// Storage provisioner test
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestStorageProvisioner)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListStorageClasses, common.PermissionListAllPersistentVolumeClaims).
 		WithSkipCheckFn(
 			testhelper.GetNoPodsUnderTestSkipFn(&env),
 			testhelper.GetNoStorageClassesSkipFn(&env),
@@ -883,3 +963,33 @@ func testStorageProvisioner(check *checksdb.Check, env *provider.TestEnvironment
 	}
 	check.SetResult(compliantObjects, nonCompliantObjects)
 }
+
+func testClusterStateDrift(check *checksdb.Check, env *provider.TestEnvironment) {
+	var compliantObjects []*testhelper.ReportObject
+	var nonCompliantObjects []*testhelper.ReportObject
+
+	clients := clientsholder.GetClientsHolder()
+	stateAfterIntrusiveTests, err := drift.TakeSnapshot(clients.K8sClient, clients.ScalingClient, env)
+	if err != nil {
+		check.LogError("Failed to take the cluster state snapshot after the intrusive test cases: %v", err)
+		nonCompliantObjects = append(nonCompliantObjects, testhelper.NewReportObject(
+			fmt.Sprintf("Failed to take the cluster state snapshot after the intrusive test cases: %v", err), testhelper.ClusterStateDriftType, false))
+		check.SetResult(compliantObjects, nonCompliantObjects)
+		return
+	}
+
+	drifts, unchanged := drift.Compare(stateBeforeIntrusiveTests, stateAfterIntrusiveTests)
+	for _, d := range drifts {
+		check.LogError("Drift found in %s of %s: %q before, %q after", d.Property, d.Object, d.Before, d.After)
+		nonCompliantObjects = append(nonCompliantObjects, testhelper.NewClusterStateDriftReportObject(d.Property, d.Object, d.Before, d.After,
+			"State changed after the intrusive test cases", false))
+	}
+
+	if len(drifts) == 0 {
+		check.LogInfo("No drift found in the %d values compared before and after the intrusive test cases", unchanged)
+		compliantObjects = append(compliantObjects, testhelper.NewReportObject("State restored after the intrusive test cases",
+			testhelper.ClusterStateDriftType, true).AddField(testhelper.DriftProperty, fmt.Sprintf("%d values compared", unchanged)))
+	}
+
+	check.SetResult(compliantObjects, nonCompliantObjects)
+}
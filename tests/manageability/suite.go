@@ -52,6 +52,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainersImageTag)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(skipIfNoContainersFn).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersImageTag(c, &env)
@@ -59,6 +60,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestContainerPortNameFormat)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(skipIfNoContainersFn).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainerPortNameFormat(c, &env)
@@ -132,6 +132,7 @@ func RunNetworkingTests( //nolint:funlen
 	netsUnderTest map[string]netcommons.NetTestContext,
 	count int,
 	aIPVersion netcommons.IPVersion,
+	checkID string,
 	logger *log.Logger) (report testhelper.FailureReasonOut, skip bool) {
 	logger.Debug("%s", netcommons.PrintNetTestContextMap(netsUnderTest))
 	skip = false
@@ -160,7 +161,7 @@ func RunNetworkingTests( //nolint:funlen
 				aIPVersion, netName,
 				netUnderTest.TesterSource.ContainerIdentifier, netUnderTest.TesterSource.IP,
 				aDestIP.ContainerIdentifier, aDestIP.IP)
-			result, err := TestPing(netUnderTest.TesterSource.ContainerIdentifier, aDestIP, count)
+			result, err := TestPing(netUnderTest.TesterSource.ContainerIdentifier, aDestIP, count, checkID)
 			logger.Debug("Ping results: %q", result)
 			logger.Info("%q ping test on network %q from ( %q  srcip: %q ) to ( %q dstip: %q ) result: %q",
 				aIPVersion, netName,
@@ -226,15 +227,16 @@ func RunNetworkingTests( //nolint:funlen
 	return report, skip
 }
 
-// TestPing Initiates a ping test between a source container and network (1 ip) and a destination container and network (1 ip)
-var TestPing = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int) (results PingResults, err error) {
+// TestPing Initiates a ping test between a source container and network (1 ip) and a destination container and network (1 ip).
+// The ping command is recorded in the audit trail as run by the given check.
+var TestPing = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int, checkID string) (results PingResults, err error) {
 	// Specify the interface to use for the ping test (if any)
 	interfaceFlag := fmt.Sprintf("-I %s", targetContainerIP.InterfaceName)
 	if targetContainerIP.InterfaceName == "" {
 		interfaceFlag = ""
 	}
 	command := fmt.Sprintf("ping %s -c %d %s", interfaceFlag, count, targetContainerIP.IP)
-	stdout, stderr, err := crclient.ExecCommandContainerNSEnter(command, sourceContainerID)
+	stdout, stderr, err := crclient.ExecCommandContainerNSEnter(command, sourceContainerID, checkID)
 	if err != nil || stderr != "" {
 		results.outcome = testhelper.ERROR
 		return results, fmt.Errorf("ping failed with stderr:%s err:%s", stderr, err)
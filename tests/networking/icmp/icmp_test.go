@@ -971,6 +971,7 @@ func TestRunNetworkingTests(t *testing.T) {
 				tt.args.netsUnderTest,
 				tt.args.count,
 				tt.args.aIPVersion,
+				"",
 				log.GetLogger(),
 			)
 			if !gotReport.Equal(tt.wantReport) {
@@ -984,11 +985,11 @@ func TestRunNetworkingTests(t *testing.T) {
 	}
 }
 
-var TestPingSuccess = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int) (results PingResults, err error) {
+var TestPingSuccess = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int, checkID string) (results PingResults, err error) {
 	return PingResults{outcome: testhelper.SUCCESS, transmitted: 10, received: 10, errors: 0}, nil
 }
 
-var TestPingFailure = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int) (results PingResults, err error) {
+var TestPingFailure = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int, checkID string) (results PingResults, err error) {
 	return PingResults{
 			outcome:     testhelper.FAILURE,
 			transmitted: 10,
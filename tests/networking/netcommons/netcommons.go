@@ -191,15 +191,20 @@ var ReservedIstioPorts = map[int32]bool{
 	15000: true, // Envoy admin port (commands/diagnostics)
 }
 
-func findRoguePodsListeningToPorts(pods []*provider.Pod, portsToTest map[int32]bool, portsOrigin string, logger *log.Logger) (compliantObjects, nonCompliantObjects []*testhelper.ReportObject) {
-	for _, put := range pods {
+func findRoguePodsListeningToPorts(env *provider.TestEnvironment, portsToTest map[int32]bool, portsOrigin, checkID string, logger *log.Logger) (compliantObjects, nonCompliantObjects []*testhelper.ReportObject) {
+	for _, put := range env.Pods {
 		logger.Info("Testing Pod %q", put)
 		compliantObjectsEntries, nonCompliantObjectsEntries := findRogueContainersDeclaringPorts(put.Containers, portsToTest, portsOrigin, logger)
 		nonCompliantPortFound := len(nonCompliantObjectsEntries) > 0
 		compliantObjects = append(compliantObjects, compliantObjectsEntries...)
 		nonCompliantObjects = append(nonCompliantObjects, nonCompliantObjectsEntries...)
 		cut := put.Containers[0]
-		listeningPorts, err := netutil.GetListeningPorts(cut)
+		if env.IsNodeNotInspected(cut.NodeName) {
+			logger.Warn("Pod %q not inspected: the debug DaemonSet is not scheduled on node %q", put, cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
+		listeningPorts, err := netutil.GetListeningPorts(cut, checkID)
 		if err != nil {
 			logger.Error("Failed to get the listening ports on %q, err: %v", cut, err)
 			nonCompliantObjects = append(nonCompliantObjects,
@@ -247,8 +252,8 @@ func findRoguePodsListeningToPorts(pods []*provider.Pod, portsToTest map[int32]b
 	return compliantObjects, nonCompliantObjects
 }
 
-func TestReservedPortsUsage(env *provider.TestEnvironment, reservedPorts map[int32]bool, portsOrigin string, logger *log.Logger) (compliantObjects, nonCompliantObjects []*testhelper.ReportObject) {
-	compliantObjectsEntries, nonCompliantObjectsEntries := findRoguePodsListeningToPorts(env.Pods, reservedPorts, portsOrigin, logger)
+func TestReservedPortsUsage(env *provider.TestEnvironment, reservedPorts map[int32]bool, portsOrigin, checkID string, logger *log.Logger) (compliantObjects, nonCompliantObjects []*testhelper.ReportObject) {
+	compliantObjectsEntries, nonCompliantObjectsEntries := findRoguePodsListeningToPorts(env, reservedPorts, portsOrigin, checkID, logger)
 	compliantObjects = append(compliantObjects, compliantObjectsEntries...)
 	nonCompliantObjects = append(nonCompliantObjects, nonCompliantObjectsEntries...)
 
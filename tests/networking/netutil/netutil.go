@@ -69,8 +69,10 @@ func parseListeningPorts(cmdOut string) (map[PortInfo]bool, error) {
 	return portSet, nil
 }
 
-func GetListeningPorts(cut *provider.Container) (map[PortInfo]bool, error) {
-	outStr, errStr, err := crclient.ExecCommandContainerNSEnter(getListeningPortsCmd, cut)
+// GetListeningPorts returns the ports the container listens on. The command is recorded in the audit trail as run
+// by the given check.
+func GetListeningPorts(cut *provider.Container, checkID string) (map[PortInfo]bool, error) {
+	outStr, errStr, err := crclient.ExecCommandContainerNSEnter(getListeningPortsCmd, cut, checkID)
 	if err != nil || errStr != "" {
 		return nil, fmt.Errorf("failed to execute command %s on %s, err: %v", getListeningPortsCmd, cut, err)
 	}
@@ -78,9 +80,9 @@ func GetListeningPorts(cut *provider.Container) (map[PortInfo]bool, error) {
 	return parseListeningPorts(outStr)
 }
 
-func GetSSHDaemonPort(cut *provider.Container) (string, error) {
+func GetSSHDaemonPort(cut *provider.Container, checkID string) (string, error) {
 	const findSSHDaemonPort = "ss -tpln | grep sshd | head -1 | awk '{ print $4 }' | awk -F : '{ print $2 }'"
-	outStr, errStr, err := crclient.ExecCommandContainerNSEnter(findSSHDaemonPort, cut)
+	outStr, errStr, err := crclient.ExecCommandContainerNSEnter(findSSHDaemonPort, cut, checkID)
 	if err != nil || errStr != "" {
 		return "", fmt.Errorf("failed to execute command %s on %s, err: %v", findSSHDaemonPort, cut, err)
 	}
@@ -63,6 +63,7 @@ func LoadChecks() {
 
 	// Default interface ICMP IPv4 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv4ConnectivityIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv4, netcommons.DEFAULT, c)
@@ -71,6 +72,7 @@ func LoadChecks() {
 
 	// Multus interfaces ICMP IPv4 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv4ConnectivityMultusIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv4, netcommons.MULTUS, c)
@@ -79,6 +81,7 @@ func LoadChecks() {
 
 	// Default interface ICMP IPv6 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv6ConnectivityIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv6, netcommons.DEFAULT, c)
@@ -87,6 +90,7 @@ func LoadChecks() {
 
 	// Multus interfaces ICMP IPv6 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv6ConnectivityMultusIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv6, netcommons.MULTUS, c)
@@ -95,6 +99,7 @@ func LoadChecks() {
 
 	// Undeclared container ports usage test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestUndeclaredContainerPortsUsage)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testUndeclaredContainerPortsUsage(c, &env)
@@ -103,6 +108,7 @@ func LoadChecks() {
 
 	// OCP reserved ports usage test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOCPReservedPortsUsage)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOCPReservedPortsUsage(c, &env)
@@ -111,6 +117,7 @@ func LoadChecks() {
 
 	// Dual stack services test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestServiceDualStackIdentifier)).
+		WithRequiredPermissions(common.PermissionListServices).
 		WithSkipCheckFn(testhelper.GetNoServicesUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testDualStackServices(c, &env)
@@ -119,6 +126,7 @@ func LoadChecks() {
 
 	// Network policy deny all test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNetworkPolicyDenyAllIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionListAllNetworkPolicies).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkPolicyDenyAll(c, &env)
@@ -127,6 +135,7 @@ func LoadChecks() {
 
 	// Extended partner ports test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestReservedExtendedPartnerPorts)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPartnerSpecificTCPPorts(c, &env)
@@ -135,6 +144,7 @@ func LoadChecks() {
 
 	// DPDK CPU pinning exec probe test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestDpdkCPUPinningExecProbe)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoCPUPinningPodsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			dpdkPods := env.GetCPUPinningPodsWithDpdk()
@@ -144,6 +154,7 @@ func LoadChecks() {
 
 	// Restart on reboot label test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestRestartOnRebootLabelOnPodsUsingSRIOV)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionGetNetworkAttachmentDefinitions).
 		WithSkipCheckFn(testhelper.GetNoSRIOVPodsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			sriovPods, err := env.GetPodsUsingSRIOV()
@@ -197,7 +208,12 @@ func testUndeclaredContainerPortsUsage(check *checksdb.Check, env *provider.Test
 
 		// Then check the actual ports that the containers are listening on
 		firstPodContainer := put.Containers[0]
-		listeningPorts, err := netutil.GetListeningPorts(firstPodContainer)
+		if env.IsNodeNotInspected(firstPodContainer.NodeName) {
+			check.LogWarn("Pod %q not inspected: the debug DaemonSet is not scheduled on node %q", put, firstPodContainer.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(firstPodContainer.NodeName))
+			continue
+		}
+		listeningPorts, err := netutil.GetListeningPorts(firstPodContainer, check.ID)
 		if err != nil {
 			check.LogError("Failed to get container %q listening ports, err: %v", firstPodContainer, err)
 			nonCompliantObjects = append(nonCompliantObjects,
@@ -251,12 +267,25 @@ func testUndeclaredContainerPortsUsage(check *checksdb.Check, env *provider.Test
 
 // testDefaultNetworkConnectivity test the connectivity between the default interfaces of containers under test
 func testNetworkConnectivity(env *provider.TestEnvironment, aIPVersion netcommons.IPVersion, aType netcommons.IFType, check *checksdb.Check) {
-	netsUnderTest := icmp.BuildNetTestContext(env.Pods, aIPVersion, aType, check.GetLogger())
-	report, skip := icmp.RunNetworkingTests(netsUnderTest, defaultNumPings, aIPVersion, check.GetLogger())
+	// The pings are sent from the debug pod of the source container's node, so the pods on nodes without a debug
+	// pod can't be tested.
+	pods := []*provider.Pod{}
+	var notInspectedObjects []*testhelper.ReportObject
+	for _, put := range env.Pods {
+		if env.IsNodeNotInspected(put.Spec.NodeName) {
+			check.LogWarn("Pod %q not inspected: the debug DaemonSet is not scheduled on node %q", put, put.Spec.NodeName)
+			notInspectedObjects = append(notInspectedObjects, testhelper.NewNodeNotInspectedReportObject(put.Spec.NodeName))
+			continue
+		}
+		pods = append(pods, put)
+	}
+
+	netsUnderTest := icmp.BuildNetTestContext(pods, aIPVersion, aType, check.GetLogger())
+	report, skip := icmp.RunNetworkingTests(netsUnderTest, defaultNumPings, aIPVersion, check.ID, check.GetLogger())
 	if skip {
 		check.LogInfo("There are no %q networks to test with at least 2 pods, skipping test", aIPVersion)
 	}
-	check.SetResult(report.CompliantObjectsOut, report.NonCompliantObjectsOut)
+	check.SetResult(append(report.CompliantObjectsOut, notInspectedObjects...), report.NonCompliantObjectsOut)
 }
 
 func testOCPReservedPortsUsage(check *checksdb.Check, env *provider.TestEnvironment) {
@@ -264,7 +293,7 @@ func testOCPReservedPortsUsage(check *checksdb.Check, env *provider.TestEnvironm
 	OCPReservedPorts := map[int32]bool{
 		22623: true,
 		22624: true}
-	compliantObjects, nonCompliantObjects := netcommons.TestReservedPortsUsage(env, OCPReservedPorts, "OCP", check.GetLogger())
+	compliantObjects, nonCompliantObjects := netcommons.TestReservedPortsUsage(env, OCPReservedPorts, "OCP", check.ID, check.GetLogger())
 	check.SetResult(compliantObjects, nonCompliantObjects)
 }
 
@@ -281,7 +310,7 @@ func testPartnerSpecificTCPPorts(check *checksdb.Check, env *provider.TestEnviro
 		15001: true,
 		15000: true,
 	}
-	compliantObjects, nonCompliantObjects := netcommons.TestReservedPortsUsage(env, ReservedPorts, "Partner", check.GetLogger())
+	compliantObjects, nonCompliantObjects := netcommons.TestReservedPortsUsage(env, ReservedPorts, "Partner", check.ID, check.GetLogger())
 	check.SetResult(compliantObjects, nonCompliantObjects)
 }
 
@@ -49,6 +49,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorInstallStatusSucceededIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionGetAllCsvs).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorInstallationPhaseSucceeded(c, &env)
@@ -56,6 +57,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorNoSCCAccess)).
+		WithRequiredPermissions(common.PermissionListAllCsvs).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorInstallationAccessToSCC(c, &env)
@@ -63,6 +65,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorIsInstalledViaOLMIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionListAllSubscriptions).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorOlmSubscription(c, &env)
@@ -70,6 +73,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorHasSemanticVersioningIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllCsvs).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorSemanticVersioning(c, &env)
@@ -77,6 +81,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorCrdVersioningIdentifier)).
+		WithRequiredPermissions(common.PermissionListCrds).
 		WithSkipCheckFn(testhelper.GetNoOperatorCrdsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorCrdVersioning(c, &env)
@@ -84,6 +89,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorCrdSchemaIdentifier)).
+		WithRequiredPermissions(common.PermissionListCrds).
 		WithSkipCheckFn(testhelper.GetNoOperatorCrdsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorCrdOpenAPISpec(c, &env)
@@ -91,6 +97,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorSingleCrdOwnerIdentifier)).
+		WithRequiredPermissions(common.PermissionListAllCsvs).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorSingleCrdOwner(c, &env)
@@ -98,12 +105,14 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorRunAsUserID)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionListAllPods).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorPodsRunAsUserID(c, &env)
 			return nil
 		}))
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorRunAsNonRoot)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionListAllPods).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorPodsRunAsNonRoot(c, &env)
@@ -111,6 +120,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorAutomountTokens)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionListAllPods, common.PermissionGetAllServiceAccounts).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorPodsAutomountTokens(c, &env)
@@ -118,6 +128,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorReadOnlyFilesystem)).
+		WithRequiredPermissions(common.PermissionListAllCsvs, common.PermissionListAllPods).
 		WithSkipCheckFn(testhelper.GetNoOperatorsSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorContainersReadOnlyFilesystem(c, &env)
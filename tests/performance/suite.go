@@ -86,6 +86,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestExclusiveCPUPoolIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testExclusiveCPUPool(c, &env)
@@ -93,6 +94,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestRtAppNoExecProbes)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(skipIfNoGuaranteedPodContainersWithExclusiveCPUs).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testRtAppsNoExecProbes(c, &env)
@@ -100,6 +102,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSharedCPUPoolSchedulingPolicy)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(skipIfNoNonGuaranteedPodContainersWithoutHostPID).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetNonGuaranteedPodContainersWithoutHostPID(), scheduling.SharedCPUScheduling)
@@ -107,6 +110,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestExclusiveCPUPoolSchedulingPolicy)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(skipIfNoGuaranteedPodContainersWithExclusiveCPUsWithoutHostPID).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetGuaranteedPodContainersWithExclusiveCPUsWithoutHostPID(), scheduling.ExclusiveCPUScheduling)
@@ -114,6 +118,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestIsolatedCPUPoolSchedulingPolicy)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(skipIfNoGuaranteedPodContainersWithIsolatedCPUsWithoutHostPID).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetGuaranteedPodContainersWithIsolatedCPUsWithoutHostPID(), scheduling.ExclusiveCPUScheduling)
@@ -121,6 +126,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestLimitedUseOfExecProbesIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testLimitedUseOfExecProbes(c, &env)
@@ -247,9 +253,14 @@ func testSchedulingPolicyInCPUPool(check *checksdb.Check, env *provider.TestEnvi
 	var nonCompliantContainersPids []*testhelper.ReportObject
 	for _, cut := range podContainers {
 		check.LogInfo("Testing Container %q", cut)
+		if env.IsNodeNotInspected(cut.NodeName) {
+			check.LogWarn("Container %q not inspected: the debug DaemonSet is not scheduled on node %q", cut, cut.NodeName)
+			compliantContainersPids = append(compliantContainersPids, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 
 		// Get the pid namespace
-		pidNamespace, err := crclient.GetContainerPidNamespace(cut, env)
+		pidNamespace, err := crclient.GetContainerPidNamespace(cut, env, check.ID)
 		if err != nil {
 			check.LogError("Unable to get pid namespace for Container %q, err: %v", cut, err)
 			nonCompliantContainersPids = append(nonCompliantContainersPids,
@@ -259,14 +270,14 @@ func testSchedulingPolicyInCPUPool(check *checksdb.Check, env *provider.TestEnvi
 		check.LogDebug("PID namespace for Container %q is %q", cut, pidNamespace)
 
 		// Get the list of process ids running in the pid namespace
-		processes, err := crclient.GetPidsFromPidNamespace(pidNamespace, cut)
+		processes, err := crclient.GetPidsFromPidNamespace(pidNamespace, cut, check.ID)
 		if err != nil {
 			check.LogError("Unable to get PIDs from PID namespace %q for Container %q, err: %v", pidNamespace, cut, err)
 			nonCompliantContainersPids = append(nonCompliantContainersPids,
 				testhelper.NewContainerReportObject(cut.Namespace, cut.Podname, cut.Name, fmt.Sprintf("Internal error, err=%s", err), false))
 		}
 
-		compliantPids, nonCompliantPids := scheduling.ProcessPidsCPUScheduling(processes, cut, schedulingType, check.GetLogger())
+		compliantPids, nonCompliantPids := scheduling.ProcessPidsCPUScheduling(processes, cut, schedulingType, check.ID, check.GetLogger())
 		// Check for the specified priority for each processes running in that pid namespace
 
 		compliantContainersPids = append(compliantContainersPids, compliantPids...)
@@ -313,8 +324,13 @@ func testRtAppsNoExecProbes(check *checksdb.Check, env *provider.TestEnvironment
 			compliantObjects = append(compliantObjects, testhelper.NewContainerReportObject(cut.Namespace, cut.Podname, cut.Name, "Container does not define exec probes", true))
 			continue
 		}
+		if env.IsNodeNotInspected(cut.NodeName) {
+			check.LogWarn("Container %q not inspected: the debug DaemonSet is not scheduled on node %q", cut, cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 
-		processes, err := crclient.GetContainerProcesses(cut, env)
+		processes, err := crclient.GetContainerProcesses(cut, env, check.ID)
 		if err != nil {
 			check.LogError("Could not determine the processes pids for container %q, err: %v", cut, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewContainerReportObject(cut.Namespace, cut.Podname, cut.Name, "Could not determine the processes pids for container", false))
@@ -326,7 +342,7 @@ func testRtAppsNoExecProbes(check *checksdb.Check, env *provider.TestEnvironment
 		allProcessesCompliant := true
 		for _, p := range notExecProbeProcesses {
 			check.LogInfo("Testing process %q", p)
-			schedPolicy, _, err := scheduling.GetProcessCPUScheduling(p.Pid, cut)
+			schedPolicy, _, err := scheduling.GetProcessCPUScheduling(p.Pid, cut, check.ID)
 			if err != nil {
 				// If the process does not exist anymore it means that it has finished since the time the process list
 				// was retrieved. In this case, just ignore the error and continue processing the rest of the processes.
@@ -31,17 +31,17 @@ const (
 	kernelArgscommand     = "cat /host/proc/cmdline"
 )
 
-func TestBootParamsHelper(env *provider.TestEnvironment, cut *provider.Container, logger *log.Logger) error {
+func TestBootParamsHelper(env *provider.TestEnvironment, cut *provider.Container, checkID string, logger *log.Logger) error {
 	debugPod := env.DebugPods[cut.NodeName]
 	if debugPod == nil {
 		return fmt.Errorf("debug pod for container %s not found on node %s", cut, cut.NodeName)
 	}
 	mcKernelArgumentsMap := GetMcKernelArguments(env, cut.NodeName)
-	currentKernelArgsMap, err := getCurrentKernelCmdlineArgs(env, cut.NodeName)
+	currentKernelArgsMap, err := getCurrentKernelCmdlineArgs(env, cut.NodeName, checkID)
 	if err != nil {
 		return fmt.Errorf("error getting kernel cli arguments from container: %s, err=%s", cut, err)
 	}
-	grubKernelConfigMap, err := getGrubKernelArgs(env, cut.NodeName)
+	grubKernelConfigMap, err := getGrubKernelArgs(env, cut.NodeName, checkID)
 	if err != nil {
 		return fmt.Errorf("error getting grub  kernel arguments for node: %s, err=%s", cut.NodeName, err)
 	}
@@ -71,9 +71,9 @@ func GetMcKernelArguments(env *provider.TestEnvironment, nodeName string) (aMap
 	return mcKernelArgumentsMap
 }
 
-func getGrubKernelArgs(env *provider.TestEnvironment, nodeName string) (aMap map[string]string, err error) {
+func getGrubKernelArgs(env *provider.TestEnvironment, nodeName, checkID string) (aMap map[string]string, err error) {
 	o := clientsholder.GetClientsHolder()
-	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name)
+	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name).WithCheckID(checkID)
 	bootConfig, errStr, err := o.ExecCommandContainer(ctx, grubKernelArgsCommand)
 	if err != nil || errStr != "" {
 		return aMap, fmt.Errorf("cannot execute %s on debug pod %s, err=%s, stderr=%s", grubKernelArgsCommand, env.DebugPods[nodeName], err, errStr)
@@ -92,9 +92,9 @@ func getGrubKernelArgs(env *provider.TestEnvironment, nodeName string) (aMap map
 	return arrayhelper.ArgListToMap(grubSplitKernelConfig), nil
 }
 
-func getCurrentKernelCmdlineArgs(env *provider.TestEnvironment, nodeName string) (aMap map[string]string, err error) {
+func getCurrentKernelCmdlineArgs(env *provider.TestEnvironment, nodeName, checkID string) (aMap map[string]string, err error) {
 	o := clientsholder.GetClientsHolder()
-	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name)
+	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name).WithCheckID(checkID)
 	currentKernelCmdlineArgs, errStr, err := o.ExecCommandContainer(ctx, kernelArgscommand)
 	if err != nil || errStr != "" {
 		return aMap, fmt.Errorf("cannot execute %s on debug pod container %s, err=%s, stderr=%s", grubKernelArgsCommand, env.DebugPods[nodeName].Name, err, errStr)
@@ -76,11 +76,12 @@ func hugepageSizeToInt(s string) int {
 	return num
 }
 
-func NewTester(node *provider.Node, debugPod *corev1.Pod, commander clientsholder.Command) (*Tester, error) {
+// NewTester returns the hugepages tester of a node, whose commands are recorded in the audit trail as run by the given check.
+func NewTester(node *provider.Node, debugPod *corev1.Pod, commander clientsholder.Command, checkID string) (*Tester, error) {
 	tester := &Tester{
 		node:      node,
 		commander: commander,
-		context:   clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name),
+		context:   clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name).WithCheckID(checkID),
 	}
 
 	log.Info("Getting node %s numa's hugepages values.", node.Data.Name)
@@ -307,6 +307,7 @@ func TestPositiveMachineConfigSystemdHugepages(t *testing.T) {
 			},
 			fakeDebugPod,
 			&client,
+			"",
 		)
 
 		assert.Nil(t, hpTester.Run())
@@ -478,6 +479,7 @@ func TestNegativeMachineConfigSystemdHugepages(t *testing.T) {
 			},
 			fakeDebugPod,
 			&client,
+			"",
 		)
 
 		assert.Equal(t, errors.New(tc.expectedErrorMsg), hpTester.Run())
@@ -600,6 +602,7 @@ func TestPositiveMachineConfigKernelArgsHugepages(t *testing.T) {
 				Mc:   getMcFromKernelArgs(tc.mcKernelArgs)},
 			fakeDebugPod,
 			&client,
+			"",
 		)
 
 		assert.Nil(t, hpTester.Run())
@@ -697,6 +700,7 @@ func TestNegativeMachineConfigKernelArgsHugepages(t *testing.T) {
 				Mc:   getMcFromKernelArgs(tc.mcKernelArgs)},
 			fakeDebugPod,
 			&client,
+			"",
 		)
 
 		assert.Equal(t, errors.New(tc.expectedErrorMsg), hpTester.Run())
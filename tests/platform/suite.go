@@ -57,6 +57,7 @@ func LoadChecks() {
 		WithBeforeEachFn(beforeEachFn)
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestHyperThreadEnable)).
+		WithRequiredPermissions(common.PermissionListNodes, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetNoBareMetalNodesSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testHyperThreadingEnabled(c, &env)
@@ -64,6 +65,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestUnalteredBaseImageIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInDebugPods, common.PermissionGetClusterOperators).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env),
@@ -74,6 +76,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNonTaintedNodeKernelsIdentifier)).
+		WithRequiredPermissions(common.PermissionListNodes, common.PermissionListPods, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testTainted(c, &env)
@@ -81,6 +84,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestIsRedHatReleaseIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionExecInTargetPods).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testIsRedHatRelease(c, &env)
@@ -88,6 +92,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestIsSELinuxEnforcingIdentifier)).
+		WithRequiredPermissions(common.PermissionListNodes, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
@@ -97,6 +102,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestHugepagesNotManuallyManipulated)).
+		WithRequiredPermissions(common.PermissionListNodes, common.PermissionGetMachineConfigs, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
@@ -106,6 +112,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestUnalteredStartupBootParamsIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionGetMachineConfigs, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
@@ -115,6 +122,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestSysctlConfigsIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionGetMachineConfigs, common.PermissionExecInDebugPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
@@ -124,6 +132,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestServiceMeshIdentifier)).
+		WithRequiredPermissions(common.PermissionListPods, common.PermissionGetIstioDeployment).
 		WithSkipCheckFn(
 			testhelper.GetNoIstioSkipFn(&env),
 			testhelper.GetNoPodsUnderTestSkipFn(&env)).
@@ -133,6 +142,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOCPLifecycleIdentifier)).
+		WithRequiredPermissions(common.PermissionGetClusterOperators).
 		WithSkipCheckFn(testhelper.GetNonOCPClusterSkipFn()).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOCPStatus(c, &env)
@@ -140,6 +150,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNodeOperatingSystemIdentifier)).
+		WithRequiredPermissions(common.PermissionListNodes, common.PermissionGetClusterOperators).
 		WithSkipCheckFn(testhelper.GetNonOCPClusterSkipFn()).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNodeOperatingSystemStatus(c, &env)
@@ -147,6 +158,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHugePages2M)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetNoHugepagesPodsSkipFn(&env)).
@@ -156,6 +168,7 @@ func LoadChecks() {
 		}))
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestPodHugePages1G)).
+		WithRequiredPermissions(common.PermissionListPods).
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetNoHugepagesPodsSkipFn(&env)).
@@ -172,7 +185,12 @@ func testHyperThreadingEnabled(check *checksdb.Check, env *provider.TestEnvironm
 	for _, node := range baremetalNodes {
 		nodeName := node.Data.Name
 		check.LogInfo("Testing node %q", nodeName)
-		enable, err := node.IsHyperThreadNode(env)
+		if _, exist := env.DebugPods[nodeName]; !exist && env.DebugPodsNodeScoped {
+			check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", nodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(nodeName))
+			continue
+		}
+		enable, err := node.IsHyperThreadNode(env, check.ID)
 		//nolint:gocritic
 		if enable {
 			check.LogInfo("Node %q has hyperthreading enabled", nodeName)
@@ -220,8 +238,18 @@ func testContainersFsDiff(check *checksdb.Check, env *provider.TestEnvironment)
 	for _, cut := range env.Containers {
 		check.LogInfo("Testing Container %q", cut)
 		debugPod := env.DebugPods[cut.NodeName]
+		if debugPod == nil {
+			if env.DebugPodsNodeScoped {
+				check.LogWarn("Container %q not inspected: the debug DaemonSet is not scheduled on node %q", cut, cut.NodeName)
+				compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+				continue
+			}
+			check.LogError("Debug Pod not found for node %q", cut.NodeName)
+			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(cut.NodeName, "tnf debug pod not found", false))
+			continue
+		}
 
-		ctxt := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name)
+		ctxt := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name).WithCheckID(check.ID)
 		fsDiffTester := cnffsdiff.NewFsDiffTester(check, clientsholder.GetClientsHolder(), ctxt, env.OpenshiftVersion)
 		fsDiffTester.RunTest(cut.UID)
 		switch fsDiffTester.GetResults() {
@@ -281,8 +309,18 @@ func testTainted(check *checksdb.Check, env *provider.TestEnvironment) {
 		}
 
 		dp := env.DebugPods[nodeName]
+		if dp == nil {
+			if env.DebugPodsNodeScoped {
+				check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", nodeName)
+				compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(nodeName))
+				continue
+			}
+			check.LogError("Debug Pod not found for node %q", nodeName)
+			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "tnf debug pod not found", false))
+			continue
+		}
 
-		ocpContext := clientsholder.NewContext(dp.Namespace, dp.Name, dp.Spec.Containers[0].Name)
+		ocpContext := clientsholder.NewContext(dp.Namespace, dp.Name, dp.Spec.Containers[0].Name).WithCheckID(check.ID)
 		tf := nodetainted.NewNodeTaintedTester(&ocpContext, nodeName)
 
 		// Get the taints mask from the node kernel
@@ -384,7 +422,7 @@ func testIsRedHatRelease(check *checksdb.Check, env *provider.TestEnvironment) {
 	var nonCompliantObjects []*testhelper.ReportObject
 	for _, cut := range env.Containers {
 		check.LogInfo("Testing Container %q", cut)
-		baseImageTester := isredhat.NewBaseImageTester(clientsholder.GetClientsHolder(), clientsholder.NewContext(cut.Namespace, cut.Podname, cut.Name))
+		baseImageTester := isredhat.NewBaseImageTester(clientsholder.GetClientsHolder(), clientsholder.NewContext(cut.Namespace, cut.Podname, cut.Name).WithCheckID(check.ID))
 
 		result, err := baseImageTester.TestContainerIsRedHatRelease()
 		if err != nil {
@@ -412,8 +450,14 @@ func testIsSELinuxEnforcing(check *checksdb.Check, env *provider.TestEnvironment
 	o := clientsholder.GetClientsHolder()
 	nodesFailed := 0
 	nodesError := 0
+	if env.DebugPodsNodeScoped {
+		for _, nodeName := range env.GetNodesWithoutDebugPod() {
+			check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", nodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(nodeName))
+		}
+	}
 	for _, debugPod := range env.DebugPods {
-		ctx := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name)
+		ctx := clientsholder.NewContext(debugPod.Namespace, debugPod.Name, debugPod.Spec.Containers[0].Name).WithCheckID(check.ID)
 		outStr, errStr, err := o.ExecCommandContainer(ctx, getenforceCommand)
 		if err != nil || errStr != "" {
 			check.LogError("Could not execute command %q in Debug Pod %q, errStr: %q, err: %v", getenforceCommand, debugPod, errStr, err)
@@ -448,13 +492,18 @@ func testHugepages(check *checksdb.Check, env *provider.TestEnvironment) {
 		}
 
 		debugPod, exist := env.DebugPods[nodeName]
+		if !exist && env.DebugPodsNodeScoped {
+			check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", nodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(nodeName))
+			continue
+		}
 		if !exist {
 			check.LogError("Could not find a Debug Pod in node %q.", nodeName)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "tnf debug pod not found", false))
 			continue
 		}
 
-		hpTester, err := hugepages.NewTester(&node, debugPod, clientsholder.GetClientsHolder())
+		hpTester, err := hugepages.NewTester(&node, debugPod, clientsholder.GetClientsHolder(), check.ID)
 		if err != nil {
 			check.LogError("Unable to get node hugepages tester for node %q, err: %v", nodeName, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "Unable to get node hugepages tester", false))
@@ -483,8 +532,13 @@ func testUnalteredBootParams(check *checksdb.Check, env *provider.TestEnvironmen
 			continue
 		}
 		alreadyCheckedNodes[cut.NodeName] = true
+		if _, exist := env.DebugPods[cut.NodeName]; !exist && env.DebugPodsNodeScoped {
+			check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 
-		err := bootparams.TestBootParamsHelper(env, cut, check.GetLogger())
+		err := bootparams.TestBootParamsHelper(env, cut, check.ID, check.GetLogger())
 		if err != nil {
 			check.LogError("Node %q failed the boot params check", cut.NodeName)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(cut.NodeName, "Failed the boot params check", false).
@@ -511,13 +565,18 @@ func testSysctlConfigs(check *checksdb.Check, env *provider.TestEnvironment) {
 		}
 		alreadyCheckedNodes[cut.NodeName] = true
 		debugPod := env.DebugPods[cut.NodeName]
+		if debugPod == nil && env.DebugPodsNodeScoped {
+			check.LogWarn("Node %q not inspected: the debug DaemonSet is not scheduled on it", cut.NodeName)
+			compliantObjects = append(compliantObjects, testhelper.NewNodeNotInspectedReportObject(cut.NodeName))
+			continue
+		}
 		if debugPod == nil {
 			check.LogError("Debug Pod not found for node %q", cut.NodeName)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(cut.NodeName, "tnf debug pod not found", false))
 			continue
 		}
 
-		sysctlSettings, err := sysctlconfig.GetSysctlSettings(env, cut.NodeName)
+		sysctlSettings, err := sysctlconfig.GetSysctlSettings(env, cut.NodeName, check.ID)
 		if err != nil {
 			check.LogError("Could not get sysctl settings for node %q, error: %v", cut.NodeName, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(cut.NodeName, "Could not get sysctl settings", false))
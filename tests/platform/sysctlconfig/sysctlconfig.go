@@ -46,13 +46,14 @@ func parseSysctlSystemOutput(sysctlSystemOutput string) map[string]string {
 	return retval
 }
 
-func GetSysctlSettings(env *provider.TestEnvironment, nodeName string) (map[string]string, error) {
+// GetSysctlSettings returns the sysctl settings of a node. The command is recorded in the audit trail as run by the given check.
+func GetSysctlSettings(env *provider.TestEnvironment, nodeName, checkID string) (map[string]string, error) {
 	const (
 		sysctlCommand = "chroot /host sysctl --system"
 	)
 
 	o := clientsholder.GetClientsHolder()
-	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name)
+	ctx := clientsholder.NewContext(env.DebugPods[nodeName].Namespace, env.DebugPods[nodeName].Name, env.DebugPods[nodeName].Spec.Containers[0].Name).WithCheckID(checkID)
 
 	outStr, errStr, err := o.ExecCommandContainer(ctx, sysctlCommand)
 	if err != nil || errStr != "" {
@@ -0,0 +1,225 @@
+package webserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// Maximum size of the run requests, with the kubeconfig and the configuration files.
+	maxRunRequestSize = 10 << 20
+
+	defaultLabelsFilter = "all"
+)
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeAPIResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Failed to write the API response: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIResponse(w, status, apiError{Error: err.Error()})
+}
+
+// readFormFile returns the content of a file of the multipart form, or the value of the field with that name.
+func readFormFile(r *http.Request, name string) ([]byte, error) {
+	file, _, err := r.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return []byte(r.FormValue(name)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// getJobConfig returns the test configuration of the run: the configuration file of the server with the fields
+// set in the overrides.
+func getJobConfig(overrides []byte) ([]byte, error) {
+	config := configuration.TestConfiguration{}
+
+	baseConfig, err := os.ReadFile(configuration.GetTestParameters().ConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read the configuration file: %v", err)
+	}
+	if err := yaml.Unmarshal(baseConfig, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration file: %v", err)
+	}
+	if err := yaml.Unmarshal(overrides, &config); err != nil {
+		return nil, fmt.Errorf("invalid configuration overrides: %v", err)
+	}
+
+	return yaml.Marshal(&config)
+}
+
+// installAPIHandlers installs the handlers of the runs REST API:
+//   - POST /api/v1/runs: queues a run. The multipart form has the kubeconfig file, and optionally the labels filter,
+//     the timeout and the config YAML file with the fields of the test configuration to override.
+//   - GET /api/v1/runs: the status of all the runs.
+//   - GET /api/v1/runs/{id}: the status and the progress of a run.
+//   - GET /api/v1/runs/{id}/log: the output of a run, from the byte set in the offset query parameter.
+//   - GET /api/v1/runs/{id}/claim and /api/v1/runs/{id}/artifacts: the claim and the results artifacts file.
+//   - DELETE /api/v1/runs/{id}: cancels a queued run or aborts a running one.
+func installAPIHandlers(mux *http.ServeMux, jobs *JobManager) {
+	mux.HandleFunc("POST /api/v1/runs", func(w http.ResponseWriter, r *http.Request) {
+		submitRunHandler(w, r, jobs)
+	})
+	mux.HandleFunc("GET /api/v1/runs", func(w http.ResponseWriter, _ *http.Request) {
+		writeAPIResponse(w, http.StatusOK, jobs.List())
+	})
+	mux.HandleFunc("GET /api/v1/runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if job := getRequestJob(w, r, jobs); job != nil {
+			writeAPIResponse(w, http.StatusOK, job.Status())
+		}
+	})
+	mux.HandleFunc("GET /api/v1/runs/{id}/log", func(w http.ResponseWriter, r *http.Request) {
+		if job := getRequestJob(w, r, jobs); job != nil {
+			runLogHandler(w, r, job)
+		}
+	})
+	mux.HandleFunc("GET /api/v1/runs/{id}/claim", func(w http.ResponseWriter, r *http.Request) {
+		if job := getRequestJob(w, r, jobs); job != nil {
+			serveJobFile(w, r, job, job.getFilePath(jobClaimFileName))
+		}
+	})
+	mux.HandleFunc("GET /api/v1/runs/{id}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		if job := getRequestJob(w, r, jobs); job != nil {
+			serveJobFile(w, r, job, job.getArtifactsFilePath())
+		}
+	})
+	mux.HandleFunc("DELETE /api/v1/runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		err := jobs.Cancel(r.PathValue("id"))
+		switch {
+		case errors.Is(err, errJobNotFound):
+			writeAPIError(w, http.StatusNotFound, err)
+		case errors.Is(err, errJobFinished):
+			writeAPIError(w, http.StatusConflict, err)
+		default:
+			job, _ := jobs.Get(r.PathValue("id"))
+			writeAPIResponse(w, http.StatusAccepted, job.Status())
+		}
+	})
+}
+
+func submitRunHandler(w http.ResponseWriter, r *http.Request, jobs *JobManager) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRunRequestSize)
+	if err := r.ParseMultipartForm(maxRunRequestSize); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid run request: %v", err))
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	kubeconfig, err := readFormFile(r, "kubeconfig")
+	if err != nil || len(kubeconfig) == 0 {
+		writeAPIError(w, http.StatusBadRequest, errors.New("the kubeconfig file is required"))
+		return
+	}
+
+	overrides, err := readFormFile(r, "config")
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("failed to read the config file: %v", err))
+		return
+	}
+	config, err := getJobConfig(overrides)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	timeout := r.FormValue("timeout")
+	if timeout != "" {
+		if _, err := time.ParseDuration(timeout); err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid timeout %q", timeout))
+			return
+		}
+	}
+
+	labelsFilter := r.FormValue("labels")
+	if labelsFilter == "" {
+		labelsFilter = defaultLabelsFilter
+	}
+
+	job, err := jobs.Submit(&JobRequest{Kubeconfig: kubeconfig, LabelsFilter: labelsFilter, Config: config, Timeout: timeout})
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/runs/"+job.Status().ID)
+	writeAPIResponse(w, http.StatusAccepted, job.Status())
+}
+
+func getRequestJob(w http.ResponseWriter, r *http.Request, jobs *JobManager) *Job {
+	job, err := jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err)
+		return nil
+	}
+	return job
+}
+
+// runLogHandler writes the output of the run from the offset query parameter. The X-Log-Offset header has the offset
+// to get the next output from.
+func runLogHandler(w http.ResponseWriter, r *http.Request, job *Job) {
+	offset := int64(0)
+	if offsetParam := r.URL.Query().Get("offset"); offsetParam != "" {
+		var err error
+		if offset, err = strconv.ParseInt(offsetParam, 10, 64); err != nil || offset < 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid offset %q", offsetParam))
+			return
+		}
+	}
+
+	output, err := readJobLog(job, offset)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("failed to read the run log: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Log-Offset", strconv.FormatInt(offset+int64(len(output)), 10))
+	if _, err := w.Write(output); err != nil {
+		log.Error("Failed to write the log of job %s: %v", job.Status().ID, err)
+	}
+}
+
+// serveJobFile serves a results file of a finished run.
+func serveJobFile(w http.ResponseWriter, r *http.Request, job *Job, filePath string) {
+	if !job.isFinished() {
+		writeAPIError(w, http.StatusConflict, errors.New("job not finished"))
+		return
+	}
+	if filePath == "" {
+		writeAPIError(w, http.StatusNotFound, errors.New("file not found"))
+		return
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		writeAPIError(w, http.StatusNotFound, errors.New("file not found"))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(filePath)))
+	http.ServeFile(w, r, filePath)
+}
@@ -0,0 +1,170 @@
+package webserver
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // Used to check the {SHA} passwords of the htpasswd files
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	authRealm = "certsuite"
+
+	htpasswdSHAPrefix = "{SHA}"
+)
+
+// Authenticator checks the credentials of the requests: a bearer token, or the basic auth user and password of an
+// htpasswd file.
+type Authenticator struct {
+	// SHA256 of the tokens, so they're compared in constant time regardless of their length.
+	tokens [][sha256.Size]byte
+	// Password hashes of the htpasswd users.
+	users map[string]string
+}
+
+// readLines returns the lines of a file that are not empty nor comments.
+func readLines(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// NewAuthenticator loads the bearer tokens file, with one token per line, and the htpasswd file. Any of them can be
+// empty, and it returns nil when both are.
+func NewAuthenticator(tokensFile, htpasswdFile string) (*Authenticator, error) {
+	if tokensFile == "" && htpasswdFile == "" {
+		return nil, nil
+	}
+
+	auth := &Authenticator{users: map[string]string{}}
+	if tokensFile != "" {
+		tokens, err := readLines(tokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the tokens file %s: %v", tokensFile, err)
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("no tokens found in %s", tokensFile)
+		}
+		for _, token := range tokens {
+			auth.tokens = append(auth.tokens, sha256.Sum256([]byte(token)))
+		}
+	}
+
+	if htpasswdFile != "" {
+		lines, err := readLines(htpasswdFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the htpasswd file %s: %v", htpasswdFile, err)
+		}
+		for i, line := range lines {
+			name, hash, found := strings.Cut(line, ":")
+			if !found || name == "" {
+				return nil, fmt.Errorf("invalid entry %d of the htpasswd file %s", i+1, htpasswdFile)
+			}
+			if !strings.HasPrefix(hash, htpasswdSHAPrefix) {
+				if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+					return nil, fmt.Errorf("unsupported password hash of user %s in %s, only bcrypt (htpasswd -B) and SHA1 (htpasswd -s) are supported",
+						name, htpasswdFile)
+				}
+			}
+			auth.users[name] = hash
+		}
+		if len(auth.users) == 0 {
+			return nil, fmt.Errorf("no users found in %s", htpasswdFile)
+		}
+	}
+
+	return auth, nil
+}
+
+func (a *Authenticator) checkToken(token string) bool {
+	sum := sha256.Sum256([]byte(token))
+	valid := 0
+	for i := range a.tokens {
+		valid |= subtle.ConstantTimeCompare(sum[:], a.tokens[i][:])
+	}
+	return valid == 1
+}
+
+func (a *Authenticator) checkPassword(name, password string) bool {
+	hash, found := a.users[name]
+	if !found {
+		return false
+	}
+
+	if strings.HasPrefix(hash, htpasswdSHAPrefix) {
+		sum := sha1.Sum([]byte(password)) //nolint:gosec
+		return subtle.ConstantTimeCompare([]byte(base64.StdEncoding.EncodeToString(sum[:])), []byte(hash[len(htpasswdSHAPrefix):])) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Authenticate returns whether the request has a valid bearer token or basic auth user and password.
+func (a *Authenticator) Authenticate(r *http.Request) bool {
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+		return len(a.tokens) > 0 && a.checkToken(strings.TrimSpace(token))
+	}
+	if name, password, ok := r.BasicAuth(); ok {
+		return a.checkPassword(name, password)
+	}
+	return false
+}
+
+// Handler returns a handler that only calls next for the authenticated requests. The browsers ask for the user and
+// password when there are htpasswd users.
+func (a *Authenticator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Authenticate(r) {
+			if len(a.users) > 0 {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", authRealm))
+			} else {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer realm=%q", authRealm))
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newOriginChecker returns the CheckOrigin function of the log stream websocket upgrader. The requests without
+// origin, which don't come from browsers, the same origin requests and the ones from the allowed origins are
+// accepted. An allowed origin "*" accepts all of them.
+func newOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	allowed := map[string]bool{}
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed["*"] || allowed[strings.ToLower(origin)] {
+			return true
+		}
+
+		originURL, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(originURL.Host, r.Host)
+	}
+}
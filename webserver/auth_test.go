@@ -0,0 +1,119 @@
+package webserver
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(filePath, []byte(content), 0o600))
+	return filePath
+}
+
+func TestAuthenticator(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.Nil(t, err)
+	tokensFile := writeTestFile(t, "tokens", "# CI tokens\ntoken-1\n\ntoken-2\n")
+	// The SHA1 password is "password".
+	htpasswdFile := writeTestFile(t, "htpasswd", "alice:"+string(hash)+"\nbob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n")
+
+	auth, err := NewAuthenticator("", "")
+	assert.Nil(t, err)
+	assert.Nil(t, auth)
+
+	auth, err = NewAuthenticator(tokensFile, htpasswdFile)
+	assert.Nil(t, err)
+	handler := auth.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		header         string
+		user, password string
+		expectedStatus int
+	}{
+		{expectedStatus: http.StatusUnauthorized},
+		{header: "Bearer token-2", expectedStatus: http.StatusOK},
+		{header: "Bearer token", expectedStatus: http.StatusUnauthorized},
+		{user: "alice", password: "secret", expectedStatus: http.StatusOK},
+		{user: "alice", password: "password", expectedStatus: http.StatusUnauthorized},
+		{user: "bob", password: "password", expectedStatus: http.StatusOK},
+		{user: "carol", password: "password", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		if tc.user != "" {
+			req.SetBasicAuth(tc.user, tc.password)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, tc.expectedStatus, rec.Code)
+		if tc.expectedStatus == http.StatusUnauthorized {
+			assert.Equal(t, `Basic realm="certsuite"`, rec.Header().Get("WWW-Authenticate"))
+		}
+	}
+
+	// The APR1 MD5 passwords are not supported.
+	_, err = NewAuthenticator("", writeTestFile(t, "htpasswd", "alice:$apr1$Vv3zG4wX$DZ4rF0nU5Dn3b6o1G0yBq/\n"))
+	assert.NotNil(t, err)
+	_, err = NewAuthenticator(writeTestFile(t, "tokens", "# no tokens\n"), "")
+	assert.NotNil(t, err)
+}
+
+func TestOriginChecker(t *testing.T) {
+	testCases := []struct {
+		allowedOrigins []string
+		origin         string
+		expected       bool
+	}{
+		{origin: "", expected: true},
+		{origin: "http://lab1:8084", expected: true},
+		{origin: "https://LAB1:8084", expected: true},
+		{origin: "http://evil.example.com", expected: false},
+		{allowedOrigins: []string{"https://lab.example.com/"}, origin: "https://lab.example.com", expected: true},
+		{allowedOrigins: []string{"https://lab.example.com"}, origin: "http://lab.example.com", expected: false},
+		{allowedOrigins: []string{"*"}, origin: "http://evil.example.com", expected: true},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "http://lab1:8084/logstream", http.NoBody)
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		assert.Equal(t, tc.expected, newOriginChecker(tc.allowedOrigins)(req), tc.origin)
+	}
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	config, err := getTLSConfig("", "", false, ":8084")
+	assert.Nil(t, err)
+	assert.Nil(t, config)
+
+	_, err = getTLSConfig("cert.pem", "", false, ":8084")
+	assert.NotNil(t, err)
+	_, err = getTLSConfig("cert.pem", "key.pem", true, ":8084")
+	assert.NotNil(t, err)
+	_, err = getTLSConfig("missing-cert.pem", "missing-key.pem", false, ":8084")
+	assert.NotNil(t, err)
+
+	config, err = getTLSConfig("", "", true, "10.1.2.3:8443")
+	assert.Nil(t, err)
+	assert.Len(t, config.Certificates, 1)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	assert.Nil(t, err)
+	assert.Contains(t, cert.DNSNames, "localhost")
+	assert.Nil(t, cert.VerifyHostname("10.1.2.3"))
+	assert.Nil(t, cert.VerifyHostname("127.0.0.1"))
+}
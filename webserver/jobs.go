@@ -0,0 +1,448 @@
+package webserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/metrics"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+)
+
+// States of the jobs.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCanceled  = "canceled"
+)
+
+const (
+	jobsDirName           = "jobs"
+	jobKubeconfigFileName = "kubeconfig"
+	jobConfigFileName     = "tnf_config.yml"
+	jobLogFileName        = "output.log"
+	jobClaimFileName      = "claim.json"
+
+	// Time given to a canceled run to abort its checks and save its results before it's killed.
+	jobCancelGracePeriod = 2 * time.Minute
+)
+
+var (
+	errJobNotFound = errors.New("job not found")
+	errJobFinished = errors.New("job already finished")
+
+	// Check result lines printed by the runs, e.g. "[ PASS ] access-control-namespace".
+	checkLineRegex   = regexp.MustCompile(`^\[ (RUNNING|PASS|FAIL|SKIP|ABORTED|ERROR) \] (\S+)`)
+	ansiEscapesRegex = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+)
+
+// JobRequest has the parameters of a run.
+type JobRequest struct {
+	Kubeconfig   []byte
+	LabelsFilter string
+	// Test configuration of the run, in YAML format.
+	Config  []byte
+	Timeout string
+}
+
+type JobProgress struct {
+	ChecksDone   int    `json:"checksDone"`
+	ChecksTotal  int    `json:"checksTotal"`
+	CurrentCheck string `json:"currentCheck,omitempty"`
+}
+
+// JobStatus is the state of a job returned by the API.
+type JobStatus struct {
+	ID           string      `json:"id"`
+	State        string      `json:"state"`
+	LabelsFilter string      `json:"labelsFilter"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	StartedAt    *time.Time  `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time  `json:"finishedAt,omitempty"`
+	Error        string      `json:"error,omitempty"`
+	Progress     JobProgress `json:"progress"`
+}
+
+// Job is a run of the test suite in its own output folder, with its own log.
+type Job struct {
+	mutex     sync.Mutex
+	status    JobStatus
+	timeout   string
+	outputDir string
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func (job *Job) Status() JobStatus {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.status
+}
+
+func (job *Job) isFinished() bool {
+	select {
+	case <-job.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (job *Job) getFilePath(fileName string) string {
+	return filepath.Join(job.outputDir, fileName)
+}
+
+// getArtifactsFilePath returns the path of the results artifacts file of the job, if it exists.
+func (job *Job) getArtifactsFilePath() string {
+	files, err := filepath.Glob(filepath.Join(job.outputDir, "*.tar.gz"))
+	if err != nil || len(files) == 0 {
+		return ""
+	}
+	return files[0]
+}
+
+// readJobLog returns the output of the job from an offset. It's empty until the job starts.
+func readJobLog(job *Job, offset int64) ([]byte, error) {
+	logFile, err := os.Open(job.getFilePath(jobLogFileName))
+	if os.IsNotExist(err) {
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+
+	if _, err := logFile.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(logFile)
+}
+
+// progressWriter saves the output of a run in its log file and updates the progress of the job with the results of
+// the checks.
+type progressWriter struct {
+	job      *Job
+	out      io.Writer
+	lineBuff []byte
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.lineBuff = append(w.lineBuff, p...)
+	for {
+		i := bytes.IndexByte(w.lineBuff, '\n')
+		if i < 0 {
+			break
+		}
+		w.parseLine(string(w.lineBuff[:i]))
+		w.lineBuff = w.lineBuff[i+1:]
+	}
+	return w.out.Write(p)
+}
+
+func (w *progressWriter) parseLine(line string) {
+	line = ansiEscapesRegex.ReplaceAllString(line, "")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+
+	match := checkLineRegex.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+
+	w.job.mutex.Lock()
+	defer w.job.mutex.Unlock()
+	progress := &w.job.status.Progress
+	if match[1] == "RUNNING" {
+		progress.CurrentCheck = match[2]
+		return
+	}
+
+	progress.ChecksDone++
+	progress.CurrentCheck = ""
+	// The total is an estimate made when the job is created, as the preflight checks are not always loaded.
+	if progress.ChecksDone > progress.ChecksTotal {
+		progress.ChecksTotal = progress.ChecksDone
+	}
+}
+
+type jobRunFunc func(ctx context.Context, job *Job, output io.Writer) error
+
+// JobManager queues the runs and runs a limited number of them at the same time, in the order they were submitted.
+type JobManager struct {
+	mutex             sync.Mutex
+	jobs              map[string]*Job
+	queue             []*Job
+	running           int
+	maxConcurrentRuns int
+	outputDir         string
+	run               jobRunFunc
+}
+
+func NewJobManager(outputDir string, maxConcurrentRuns int) *JobManager {
+	if maxConcurrentRuns < 1 {
+		maxConcurrentRuns = 1
+	}
+
+	return &JobManager{
+		jobs:              map[string]*Job{},
+		maxConcurrentRuns: maxConcurrentRuns,
+		outputDir:         filepath.Join(outputDir, jobsDirName),
+		run:               runCertsuite,
+	}
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, 8) //nolint:mnd
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to create job id: %v", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Submit creates the output folder of a job and queues it.
+func (m *JobManager) Submit(request *JobRequest) (*Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	checksTotal, err := checksdb.CountChecks(request.LabelsFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels filter %q: %v", request.LabelsFilter, err)
+	}
+
+	job := &Job{
+		status: JobStatus{
+			ID:           id,
+			State:        JobQueued,
+			LabelsFilter: request.LabelsFilter,
+			CreatedAt:    time.Now(),
+			Progress:     JobProgress{ChecksTotal: checksTotal},
+		},
+		timeout:   request.Timeout,
+		outputDir: filepath.Join(m.outputDir, id),
+		done:      make(chan struct{}),
+	}
+
+	if err := os.MkdirAll(job.outputDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the job output folder: %v", err)
+	}
+	if err := os.WriteFile(job.getFilePath(jobKubeconfigFileName), request.Kubeconfig, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save the job kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(job.getFilePath(jobConfigFileName), request.Config, 0o600); err != nil {
+		_ = os.RemoveAll(job.outputDir)
+		return nil, fmt.Errorf("failed to save the job configuration: %v", err)
+	}
+
+	job.ctx, job.cancel = context.WithCancel(context.Background())
+
+	log.Info("Job %s queued. Labels filter: %s", id, request.LabelsFilter)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.jobs[id] = job
+	m.queue = append(m.queue, job)
+	m.dispatch()
+
+	return job, nil
+}
+
+// dispatch starts the first queued jobs while there are less running jobs than the limit. The caller holds the
+// mutex of the manager.
+func (m *JobManager) dispatch() {
+	for m.running < m.maxConcurrentRuns && len(m.queue) > 0 {
+		job := m.queue[0]
+		m.queue = m.queue[1:]
+		m.running++
+		go m.process(job)
+	}
+}
+
+// process runs a job, and then the next queued one.
+func (m *JobManager) process(job *Job) {
+	defer func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		m.running--
+		m.dispatch()
+	}()
+	defer close(job.done)
+
+	job.mutex.Lock()
+	now := time.Now()
+	job.status.State = JobRunning
+	job.status.StartedAt = &now
+	job.mutex.Unlock()
+
+	log.Info("Job %s started", job.status.ID)
+	logFile, err := os.Create(job.getFilePath(jobLogFileName))
+	if err != nil {
+		m.finish(job, fmt.Errorf("failed to create the job log file: %v", err))
+		return
+	}
+	defer logFile.Close()
+
+	err = m.run(job.ctx, job, &progressWriter{job: job, out: logFile})
+	m.finish(job, err)
+}
+
+func (m *JobManager) finish(job *Job, err error) {
+	// The uploaded kubeconfig is only needed by the run.
+	if removeErr := os.Remove(job.getFilePath(jobKubeconfigFileName)); removeErr != nil && !os.IsNotExist(removeErr) {
+		log.Error("Failed to remove the kubeconfig of job %s: %v", job.Status().ID, removeErr)
+	}
+
+	// The run's metrics are only updated in the certsuite process of the job, so the ones served by the
+	// web server are taken from the job's claim file.
+	claimFile := job.getFilePath(jobClaimFileName)
+	if _, statErr := os.Stat(claimFile); statErr == nil {
+		if metricsErr := metrics.UpdateFromClaimFile(claimFile); metricsErr != nil {
+			log.Error("Failed to update the metrics with the results of job %s: %v", job.Status().ID, metricsErr)
+		}
+	}
+
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	now := time.Now()
+	job.status.FinishedAt = &now
+	job.status.Progress.CurrentCheck = ""
+	switch {
+	case job.ctx.Err() != nil:
+		job.status.State = JobCanceled
+	case err != nil:
+		job.status.State = JobFailed
+		job.status.Error = err.Error()
+	default:
+		job.status.State = JobSucceeded
+	}
+	log.Info("Job %s finished: %s", job.status.ID, job.status.State)
+}
+
+func (m *JobManager) Get(id string) (*Job, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	job, found := m.jobs[id]
+	if !found {
+		return nil, errJobNotFound
+	}
+	return job, nil
+}
+
+// List returns the status of the jobs, the newest first.
+func (m *JobManager) List() []JobStatus {
+	m.mutex.Lock()
+	jobs := []JobStatus{}
+	for _, job := range m.jobs {
+		jobs = append(jobs, job.Status())
+	}
+	m.mutex.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
+
+// Latest returns the most recent job, or nil if there's none.
+func (m *JobManager) Latest() *Job {
+	jobs := m.List()
+	if len(jobs) == 0 {
+		return nil
+	}
+	job, _ := m.Get(jobs[0].ID)
+	return job
+}
+
+// Cancel cancels a queued job, or aborts a running one.
+func (m *JobManager) Cancel(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	job, found := m.jobs[id]
+	if !found {
+		return errJobNotFound
+	}
+	if job.isFinished() {
+		return errJobFinished
+	}
+
+	log.Info("Canceling job %s", id)
+	job.cancel()
+
+	for i, queuedJob := range m.queue {
+		if queuedJob == job {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			m.finish(job, nil)
+			close(job.done)
+			break
+		}
+	}
+	return nil
+}
+
+// runCertsuite runs the test suite in a new process with the kubeconfig, the configuration and the output folder
+// of the job, so the runs don't share any state. A canceled run is interrupted to abort the running checks and
+// save the results of the finished ones.
+func runCertsuite(ctx context.Context, job *Job, output io.Writer) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the certsuite executable: %v", err)
+	}
+
+	params := configuration.GetTestParameters()
+	args := []string{"run",
+		"--output-dir", job.outputDir,
+		"--label-filter", job.Status().LabelsFilter,
+		"--config-file", job.getFilePath(jobConfigFileName),
+		"--kubeconfig", job.getFilePath(jobKubeconfigFileName),
+		"--log-level", params.LogLevel,
+		"--tnf-image-repository", params.TnfImageRepo,
+		"--tnf-debug-image", params.TnfDebugImage,
+	}
+	if job.timeout != "" {
+		args = append(args, "--timeout", job.timeout)
+	}
+	if params.OfflineDB != "" {
+		args = append(args, "--offline-db", params.OfflineDB)
+	}
+	if params.PfltDockerconfig != "" {
+		args = append(args, "--preflight-dockerconfig", params.PfltDockerconfig)
+	}
+	if params.NonIntrusiveOnly {
+		args = append(args, "--non-intrusive")
+	}
+	if params.AllowPreflightInsecure {
+		args = append(args, "--allow-preflight-insecure")
+	}
+
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = jobCancelGracePeriod
+
+	return cmd.Run()
+}
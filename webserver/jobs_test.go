@@ -0,0 +1,196 @@
+package webserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/metrics"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+const testClaim = `{"claim": {"metadata": {"startTime": "2024-05-10 10:00:00 +0000 UTC", "endTime": "2024-05-10 10:05:00 +0000 UTC"}}}`
+
+// newTestJobManager returns a job manager whose runs print the check results, save a claim file and wait for the
+// release channel to be closed or to be canceled.
+func newTestJobManager(t *testing.T, maxConcurrentRuns int, release chan struct{}) *JobManager {
+	jobs := NewJobManager(t.TempDir(), maxConcurrentRuns)
+	jobs.run = func(ctx context.Context, job *Job, output io.Writer) error {
+		_, _ = io.WriteString(output, "Running checks\n[ \x1b[32mPASS\x1b[0m ] access-control-namespace\n")
+		_, _ = io.WriteString(output, "[ RUNNING ] lifecycle-pod-owner-type\n")
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		_, _ = io.WriteString(output, "\x1b[2K\r[ FAIL ] lifecycle-pod-owner-type\n")
+		return os.WriteFile(job.getFilePath(jobClaimFileName), []byte(testClaim), 0o600)
+	}
+	return jobs
+}
+
+func waitForState(t *testing.T, job *Job, state string) {
+	assert.Eventually(t, func() bool { return job.Status().State == state }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestJobManager(t *testing.T) {
+	release := make(chan struct{})
+	jobs := newTestJobManager(t, 1, release)
+
+	job1, err := jobs.Submit(&JobRequest{Kubeconfig: []byte("kubeconfig"), LabelsFilter: "all", Config: []byte("{}")})
+	assert.Nil(t, err)
+	job2, err := jobs.Submit(&JobRequest{Kubeconfig: []byte("kubeconfig"), LabelsFilter: "common", Config: []byte("{}")})
+	assert.Nil(t, err)
+	job3, err := jobs.Submit(&JobRequest{Kubeconfig: []byte("kubeconfig"), LabelsFilter: "common", Config: []byte("{}")})
+	assert.Nil(t, err)
+	_, err = jobs.Submit(&JobRequest{Kubeconfig: []byte("kubeconfig"), LabelsFilter: "common &&", Config: []byte("{}")})
+	assert.NotNil(t, err)
+
+	// A single run at a time: the other jobs wait in the queue.
+	waitForState(t, job1, JobRunning)
+	assert.Eventually(t, func() bool { return job1.Status().Progress.CurrentCheck == "lifecycle-pod-owner-type" },
+		5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, job1.Status().Progress.ChecksDone)
+	assert.Equal(t, JobQueued, job2.Status().State)
+	assert.Len(t, jobs.List(), 3)
+	assert.FileExists(t, job1.getFilePath(jobKubeconfigFileName))
+
+	// Cancel a queued job and a running one.
+	assert.Nil(t, jobs.Cancel(job3.Status().ID))
+	waitForState(t, job3, JobCanceled)
+	assert.Nil(t, jobs.Cancel(job1.Status().ID))
+	waitForState(t, job1, JobCanceled)
+	assert.Equal(t, errJobFinished, jobs.Cancel(job1.Status().ID))
+	assert.Equal(t, errJobNotFound, jobs.Cancel("unknown"))
+
+	waitForState(t, job2, JobRunning)
+	close(release)
+	waitForState(t, job2, JobSucceeded)
+	status := job2.Status()
+	assert.Equal(t, 2, status.Progress.ChecksDone)
+	assert.Equal(t, "", status.Progress.CurrentCheck)
+	assert.NotNil(t, status.StartedAt)
+	assert.NotNil(t, status.FinishedAt)
+
+	// The metrics served by the web server are taken from the claim file of the finished job.
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", http.NoBody))
+	assert.Contains(t, recorder.Body.String(), "certsuite_run_duration_seconds 300")
+
+	// Each job has its own output folder and log.
+	output, err := readJobLog(job2, 0)
+	assert.Nil(t, err)
+	assert.Contains(t, string(output), "[ FAIL ] lifecycle-pod-owner-type")
+	assert.NotEqual(t, job1.outputDir, job2.outputDir)
+	assert.FileExists(t, job2.getFilePath(jobConfigFileName))
+	assert.NoFileExists(t, job1.getFilePath(jobClaimFileName))
+
+	// The kubeconfig files are removed once the jobs finish, also the canceled ones.
+	for _, job := range []*Job{job1, job2, job3} {
+		assert.NoFileExists(t, job.getFilePath(jobKubeconfigFileName))
+	}
+}
+
+func newRunRequest(t *testing.T, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range fields {
+		fw, err := w.CreateFormFile(name, name)
+		assert.Nil(t, err)
+		_, err = fw.Write([]byte(value))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestRunsAPI(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "tnf_config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte("targetNameSpaces:\n  - name: tnf\nexecutedBy: ci\n"), 0o600))
+	configuration.GetTestParameters().ConfigFile = configFile
+
+	release := make(chan struct{})
+	jobs := newTestJobManager(t, 1, release)
+	mux := http.NewServeMux()
+	installAPIHandlers(mux, jobs)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, newRunRequest(t, map[string]string{"kubeconfig": "kubeconfig", "config": "executedBy: api\n"}))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	status := JobStatus{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, "all", status.LabelsFilter)
+	assert.Equal(t, "/api/v1/runs/"+status.ID, rec.Header().Get("Location"))
+
+	// The overrides are applied to the configuration of the server.
+	job, err := jobs.Get(status.ID)
+	assert.Nil(t, err)
+	config, err := os.ReadFile(job.getFilePath(jobConfigFileName))
+	assert.Nil(t, err)
+	assert.Contains(t, string(config), "executedBy: api")
+	assert.Contains(t, string(config), "- name: tnf")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, newRunRequest(t, map[string]string{"config": "executedBy: api\n"}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	waitForState(t, job, JobRunning)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+status.ID+"/claim", http.NoBody))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	close(release)
+	waitForState(t, job, JobSucceeded)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+status.ID, http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, JobSucceeded, status.State)
+	assert.Equal(t, 2, status.Progress.ChecksDone)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+status.ID+"/log?offset=15", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[ \x1b[32mPASS", rec.Body.String()[:11])
+	output, err := readJobLog(job, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, len(output), atoi(t, rec.Header().Get("X-Log-Offset")))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+status.ID+"/claim", http.NoBody))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testClaim, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+status.ID+"/artifacts", http.NoBody))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/runs/"+status.ID, http.NoBody))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/runs/unknown", http.NoBody))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func atoi(t *testing.T, s string) int {
+	n := 0
+	assert.Nil(t, json.Unmarshal([]byte(s), &n))
+	return n
+}
@@ -1,6 +1,7 @@
 import '@rhds/elements/rh-code-block/rh-code-block.js';
 
-const socket = new WebSocket('ws://localhost:8084/logstream');
+const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+const socket = new WebSocket(`${protocol}//${window.location.host}/logstream`);
 const code = document
   .getElementById('logs')
   .querySelector('rh-code-block');
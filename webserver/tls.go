@@ -0,0 +1,107 @@
+package webserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+const (
+	selfSignedCertValidity = 365 * 24 * time.Hour
+	serialNumberBits       = 128
+)
+
+// getTLSConfig returns the TLS configuration of the web server with the certificate and key files, or with a
+// generated self-signed certificate. It returns nil when the server has to use plain HTTP.
+func getTLSConfig(certFile, keyFile string, selfSigned bool, address string) (*tls.Config, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("both the TLS certificate and key files must be set")
+	}
+	if certFile != "" && selfSigned {
+		return nil, errors.New("the TLS certificate files and the self-signed certificate are mutually exclusive")
+	}
+
+	var cert tls.Certificate
+	var err error
+	switch {
+	case certFile != "":
+		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS certificate: %v", err)
+		}
+	case selfSigned:
+		cert, err = generateSelfSignedCert(getCertHosts(address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the self-signed certificate: %v", err)
+		}
+		log.Info("Generated a self-signed certificate, SHA256 fingerprint %X", sha256.Sum256(cert.Certificate[0]))
+	default:
+		return nil, nil
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// getCertHosts returns the host names and IPs of the self-signed certificate: localhost, the host name of the
+// machine and the host of the listen address.
+func getCertHosts(address string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname)
+	}
+	if host, _, err := net.SplitHostPort(address); err == nil && host != "" {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+func generateSelfSignedCert(hosts []string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"certsuite"}, CommonName: hosts[0]},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
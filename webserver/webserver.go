@@ -3,13 +3,10 @@ package webserver
 import (
 	"bufio"
 	"bytes"
-	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/fs"
-	"net"
 	"net/http"
 	"os"
 	"sort"
@@ -18,30 +15,22 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
-	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/metrics"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/arrayhelper"
-	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
-	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
 	"github.com/robert-nix/ansihtml"
 
 	yaml "gopkg.in/yaml.v2"
 )
 
-type webServerContextKey string
-
 const (
-	logTimeout = 1000
+	logPollInterval = time.Second
 
 	readTimeoutSeconds = 10
 )
 
-var (
-	outputFolderCtxKey webServerContextKey = "output-folder"
-)
-
 //go:embed index.html
 var indexHTML []byte
 
@@ -57,40 +46,58 @@ var toast []byte
 //go:embed index.js
 var index []byte
 
-var buf *bytes.Buffer
-
+// The origins allowed besides the server one are set when the server starts.
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	CheckOrigin: newOriginChecker(nil),
 }
 
-func logStreamHandler(w http.ResponseWriter, r *http.Request) {
+// logStreamHandler streams the output of a run, the one of the job query parameter or else the latest one, until
+// it finishes.
+func logStreamHandler(w http.ResponseWriter, r *http.Request, jobs *JobManager) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Info("WebSocket upgrade error: %v", err)
 		return
 	}
 	defer conn.Close()
-	// Create a scanner to read the log file line by line
+
+	job := jobs.Latest()
+	if id := r.URL.Query().Get("job"); id != "" {
+		job, _ = jobs.Get(id)
+	}
+	if job == nil {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("No run found<br>"))
+		return
+	}
+
+	offset := int64(0)
 	for {
-		scanner := bufio.NewScanner(buf)
-		for scanner.Scan() {
-			line := scanner.Bytes()
-			fmt.Println(string(line))
-			line = append(ansihtml.ConvertToHTML(line), []byte("<br>")...)
+		finished := job.isFinished()
+		output, err := readJobLog(job, offset)
+		if err != nil {
+			log.Info("Error reading the log of job %s: %v", job.Status().ID, err)
+			return
+		}
+
+		// Send the complete lines only, the rest is sent when it's complete.
+		if !finished {
+			output = output[:bytes.LastIndexByte(output, '\n')+1]
+		}
+		offset += int64(len(output))
 
-			// Send each log line to the client
+		scanner := bufio.NewScanner(bytes.NewReader(output))
+		for scanner.Scan() {
+			line := append(ansihtml.ConvertToHTML(scanner.Bytes()), []byte("<br>")...)
 			if err := conn.WriteMessage(websocket.TextMessage, line); err != nil {
-				fmt.Println(err)
+				log.Info("Error sending the log of job %s: %v", job.Status().ID, err)
 				return
 			}
-			time.Sleep(logTimeout)
 		}
-		if err := scanner.Err(); err != nil {
-			log.Info("Error reading log file: %v", err)
+
+		if finished {
 			return
 		}
+		time.Sleep(logPollInterval)
 	}
 }
 
@@ -190,39 +197,65 @@ func installReqHandlers() {
 		}
 	})
 
-	// Serve the static HTML file
-	http.HandleFunc("/logstream", logStreamHandler)
 }
 
-func StartServer(outputFolder string) {
-	ctx := context.Background()
-	server := &http.Server{
-		Addr:        ":8084",                          // Server address
-		ReadTimeout: readTimeoutSeconds * time.Second, // Maximum duration for reading the entire request
-		BaseContext: func(l net.Listener) context.Context {
-			ctx = context.WithValue(ctx, outputFolderCtxKey, outputFolder)
-			return ctx
-		},
+// StartServer runs the web server with the address, TLS and authentication settings of the test parameters.
+func StartServer(outputFolder string) error {
+	params := configuration.GetTestParameters()
+
+	tlsConfig, err := getTLSConfig(params.ServerTLSCertFile, params.ServerTLSKeyFile, params.ServerSelfSignedCert, params.ServerAddress)
+	if err != nil {
+		return err
 	}
+	auth, err := NewAuthenticator(params.ServerTokensFile, params.ServerHtpasswdFile)
+	if err != nil {
+		return err
+	}
+	upgrader.CheckOrigin = newOriginChecker(params.ServerAllowedOrigins)
 
 	installReqHandlers()
 
-	http.HandleFunc("/runFunction", runHandler)
+	jobs := NewJobManager(outputFolder, params.MaxConcurrentRuns)
+	installAPIHandlers(http.DefaultServeMux, jobs)
 
-	log.Info("Server is running on :8084...")
-	if err := server.ListenAndServe(); err != nil {
-		panic(err)
+	http.HandleFunc("/runFunction", func(w http.ResponseWriter, r *http.Request) {
+		runHandler(w, r, jobs)
+	})
+	http.HandleFunc("/logstream", func(w http.ResponseWriter, r *http.Request) {
+		logStreamHandler(w, r, jobs)
+	})
+	http.Handle("/metrics", metrics.Handler())
+
+	var handler http.Handler = http.DefaultServeMux
+	if auth != nil {
+		handler = auth.Handler(handler)
+	} else {
+		log.Warn("No tokens or htpasswd file set: anyone that can reach the web server can run the test suite")
+	}
+	if tlsConfig == nil {
+		log.Warn("TLS not enabled: the kubeconfig files and the credentials are sent in clear text")
 	}
-}
 
-// Define an HTTP handler that triggers CERTSUITE tests
-//
-//nolint:funlen
-func runHandler(w http.ResponseWriter, r *http.Request) {
-	buf = bytes.NewBufferString("")
-	// The log output will be written to the log file and to this buffer buf
-	log.SetLogger(log.GetMultiLogger(buf))
+	server := &http.Server{
+		Addr:        params.ServerAddress,
+		Handler:     handler,
+		TLSConfig:   tlsConfig,
+		ReadTimeout: readTimeoutSeconds * time.Second, // Maximum duration for reading the entire request
+	}
+
+	if tlsConfig != nil {
+		log.Info("Server is running on %s (HTTPS)...", params.ServerAddress)
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		log.Info("Server is running on %s...", params.ServerAddress)
+		err = server.ListenAndServe()
+	}
+	return fmt.Errorf("web server stopped: %v", err)
+}
 
+// Define an HTTP handler that triggers CERTSUITE tests. The run is queued like the ones of the REST API, and the
+// response is sent when it finishes.
+func runHandler(w http.ResponseWriter, r *http.Request, jobs *JobManager) {
 	jsonData := r.FormValue("jsonData") // "jsonData" is the name of the JSON input field
 	log.Info(jsonData)
 	var data RequestedData
@@ -240,57 +273,40 @@ func runHandler(w http.ResponseWriter, r *http.Request) {
 	defer file.Close()
 
 	log.Info("Kubeconfig file name received: %s", fileHeader.Filename)
-	kubeconfigTempFile, err := os.CreateTemp("", "webserver-kubeconfig-*")
-	if err != nil {
-		http.Error(w, "Failed to create temp file to store the kubeconfig content.", http.StatusBadRequest)
-		return
-	}
-
-	defer func() {
-		log.Info("Removing temporary kubeconfig file %s", kubeconfigTempFile.Name())
-		err = os.Remove(kubeconfigTempFile.Name())
-		if err != nil {
-			log.Error("Failed to remove temp kubeconfig file %s", kubeconfigTempFile.Name())
-		}
-	}()
-
-	_, err = io.Copy(kubeconfigTempFile, file)
+	kubeconfig, err := io.ReadAll(file)
 	if err != nil {
-		http.Error(w, "Unable to copy file", http.StatusInternalServerError)
+		http.Error(w, "Unable to read file", http.StatusInternalServerError)
 		return
 	}
 
-	_ = kubeconfigTempFile.Close()
-
-	log.Info("Web Server kubeconfig file : %v (copied into %v)", fileHeader.Filename, kubeconfigTempFile.Name())
 	log.Info("Web Server Labels filter   : %v", flattenedOptions)
 
-	tnfConfig, err := os.ReadFile("tnf_config.yml")
-	if err != nil {
-		log.Fatal("Error reading YAML file: %v", err) //nolint:gocritic // exitAfterDefer
+	tnfConfig, err := os.ReadFile(configuration.GetTestParameters().ConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		http.Error(w, "Failed to read the configuration file", http.StatusInternalServerError)
+		return
 	}
 
-	newData := updateTnf(tnfConfig, &data)
-
-	// Write the modified YAML data back to the file
-	var filePerm fs.FileMode = 0o644 // owner can read/write, group and others can only read
-	err = os.WriteFile("tnf_config.yml", newData, filePerm)
+	labelsFilter := strings.Join(flattenedOptions, ",")
+	job, err := jobs.Submit(&JobRequest{
+		Kubeconfig:   kubeconfig,
+		LabelsFilter: labelsFilter,
+		Config:       updateTnf(tnfConfig, &data),
+	})
 	if err != nil {
-		log.Fatal("Error writing YAML file: %v", err)
+		log.Error("Failed to run CNF Cert Suite: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	_ = clientsholder.GetNewClientsHolder(kubeconfigTempFile.Name())
-
-	var env provider.TestEnvironment
-	env.SetNeedsRefresh()
-	env = provider.GetTestEnvironment()
 
-	labelsFilter := strings.Join(flattenedOptions, ",")
-	outputFolder := r.Context().Value(outputFolderCtxKey).(string)
+	log.Info("Running CNF Cert Suite (web-mode). Labels filter: %s, job: %s", labelsFilter, job.Status().ID)
+	<-job.done
 
-	log.Info("Running CNF Cert Suite (web-mode). Labels filter: %s, outputFolder: %s", labelsFilter, outputFolder)
-	err = certsuite.Run(labelsFilter, outputFolder)
-	if err != nil {
-		log.Error("Failed to run CNF Cert Suite: %v", err)
+	status := job.Status()
+	if status.State != JobSucceeded {
+		log.Error("Failed to run CNF Cert Suite: job %s %s %s", status.ID, status.State, status.Error)
+		http.Error(w, fmt.Sprintf("Run %s %s", status.ID, status.State), http.StatusInternalServerError)
+		return
 	}
 
 	// Return the result as JSON